@@ -0,0 +1,9 @@
+// Package events holds the JSON Schemas of every event published to Kafka.
+// Files are named <type>.v<version>.json; a change that breaks existing
+// consumers gets a new version file instead of editing the old one.
+package events
+
+import "embed"
+
+//go:embed *.json
+var Schemas embed.FS
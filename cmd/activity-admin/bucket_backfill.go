@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"go.uber.org/zap"
+
+	"github.com/Sol1tud9/taskflow/internal/activity/storage/sharded"
+	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+func runBucketBackfill(ctx context.Context, cfg *config.ActivityServiceConfig, args []string) error {
+	fs := flag.NewFlagSet("bucket-backfill", flag.ExitOnError)
+	batchSize := fs.Int("batch-size", 1000, "users whose activities are updated per round")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	storage, err := sharded.NewShardedStorage(cfg.Sharding)
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+
+	updated, err := storage.BackfillBuckets(ctx, *batchSize)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("bucket backfill finished", zap.Int64("rows", updated))
+	return nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"github.com/Sol1tud9/taskflow/internal/activity/consumer"
+	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/kafka"
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func runDLQReplay(ctx context.Context, cfg *config.ActivityServiceConfig, args []string) error {
+	fs := flag.NewFlagSet("dlq-replay", flag.ExitOnError)
+	limit := fs.Int("limit", 0, "maximum number of messages to replay (0 = all)")
+	dryRun := fs.Bool("dry-run", false, "log dead-lettered messages without replaying them")
+	idleTimeout := fs.Duration("idle-timeout", 5*time.Second, "stop after waiting this long for the next message")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	reader := kafka.NewConsumer(cfg.Kafka.Brokers, cfg.Kafka.Topics["dead_letter"], cfg.Kafka.ConsumerGroups["dlq_replay"])
+	defer reader.Close()
+
+	writers := make(map[string]consumer.MessageWriter)
+	for name, topic := range cfg.Kafka.Topics {
+		if name == "dead_letter" {
+			continue
+		}
+		producer := kafka.NewProducer(cfg.Kafka.Brokers, topic)
+		defer producer.Close()
+		writers[topic] = producer
+	}
+
+	replayed, err := consumer.NewReplayer(reader, writers).Replay(ctx, consumer.ReplayOptions{
+		Limit:       *limit,
+		DryRun:      *dryRun,
+		IdleTimeout: *idleTimeout,
+	})
+	logger.Info("dlq replay finished", zap.Int("messages", replayed), zap.Bool("dry_run", *dryRun))
+
+	return err
+}
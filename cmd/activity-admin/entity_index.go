@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"go.uber.org/zap"
+
+	"github.com/Sol1tud9/taskflow/internal/activity/storage/sharded"
+	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+func runEntityIndexBackfill(ctx context.Context, cfg *config.ActivityServiceConfig, args []string) error {
+	fs := flag.NewFlagSet("entity-index-backfill", flag.ExitOnError)
+	batchSize := fs.Int("batch-size", 1000, "index rows written per insert")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	storage, err := sharded.NewShardedStorage(cfg.Sharding)
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+
+	indexed, err := storage.BackfillEntityIndex(ctx, *batchSize)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("entity index backfill finished", zap.Int64("entries", indexed))
+	return nil
+}
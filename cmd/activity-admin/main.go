@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const usage = `usage: activity-admin <command> [flags]
+
+commands:
+  dlq-replay             replay dead-lettered events back into their original topics
+  bucket-map             print the activity bucket-to-shard map
+  rebalance              move activity buckets to another shard without downtime
+  entity-index-backfill  index activities stored before the entity index existed
+  bucket-backfill        store the bucket of activities written before it was recorded
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	configPath := os.Getenv("CONFIG_PATH")
+	if configPath == "" {
+		configPath = "configs/activity.yaml"
+	}
+
+	cfg, err := config.Load[config.ActivityServiceConfig](configPath)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := logger.Init(cfg.App.LogLevel); err != nil {
+		panic(err)
+	}
+	defer logger.Sync()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	command, args := os.Args[1], os.Args[2:]
+	switch command {
+	case "dlq-replay":
+		err = runDLQReplay(ctx, cfg, args)
+	case "bucket-map":
+		err = runBucketMap(ctx, cfg, args)
+	case "rebalance":
+		err = runRebalance(ctx, cfg, args)
+	case "entity-index-backfill":
+		err = runEntityIndexBackfill(ctx, cfg, args)
+	case "bucket-backfill":
+		err = runBucketBackfill(ctx, cfg, args)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		logger.Fatal("command failed", zap.String("command", command), zap.Error(err))
+	}
+}
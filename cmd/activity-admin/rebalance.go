@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/Sol1tud9/taskflow/internal/activity/storage/sharded"
+	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+func runBucketMap(ctx context.Context, cfg *config.ActivityServiceConfig, args []string) error {
+	fs := flag.NewFlagSet("bucket-map", flag.ExitOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	storage, err := sharded.NewShardedStorage(cfg.Sharding)
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+
+	states, err := storage.BucketMap(ctx)
+	if err != nil {
+		return err
+	}
+
+	buckets := make([]int, 0, len(states))
+	perShard := make(map[int]int)
+	for bucketID, state := range states {
+		buckets = append(buckets, bucketID)
+		perShard[state.ShardID]++
+	}
+	sort.Ints(buckets)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "BUCKET\tSHARD\tSTATUS")
+	for _, bucketID := range buckets {
+		state := states[bucketID]
+		status := ""
+		switch {
+		case state.Migrating():
+			status = fmt.Sprintf("migrating to %d", state.MigratingTo)
+		case state.Draining():
+			status = fmt.Sprintf("draining from %d", state.DrainingFrom)
+		}
+		fmt.Fprintf(w, "%d\t%d\t%s\n", bucketID, state.ShardID, status)
+	}
+	fmt.Fprintln(w)
+	for shardID := 0; shardID < storage.ShardCount(); shardID++ {
+		fmt.Fprintf(w, "shard %d\t%d buckets\t\n", shardID, perShard[shardID])
+	}
+
+	return w.Flush()
+}
+
+func runRebalance(ctx context.Context, cfg *config.ActivityServiceConfig, args []string) error {
+	fs := flag.NewFlagSet("rebalance", flag.ExitOnError)
+	bucketList := fs.String("buckets", "", "comma-separated buckets to move")
+	target := fs.Int("to", -1, "target shard")
+	settle := fs.Duration("settle", 0, "wait after each bucket map change (default: twice the mapping refresh interval)")
+	batchSize := fs.Int("batch-size", 1000, "rows copied per insert")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	buckets, err := parseBuckets(*bucketList)
+	if err != nil {
+		return err
+	}
+	if *target < 0 {
+		return errors.New("-to is required")
+	}
+
+	storage, err := sharded.NewShardedStorage(cfg.Sharding)
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+
+	opts := sharded.RebalanceOptions{Settle: *settle, BatchSize: *batchSize}
+	if opts.Settle <= 0 {
+		opts.Settle = 2 * storage.MappingRefreshInterval()
+	}
+
+	for _, bucketID := range buckets {
+		result, err := storage.MoveBucket(ctx, bucketID, *target, opts)
+		if err != nil {
+			return errors.Wrapf(err, "failed to move bucket %d", bucketID)
+		}
+		logger.Info("rebalance step finished",
+			zap.Int("bucket", result.Bucket),
+			zap.Int("from", result.From),
+			zap.Int("to", result.To),
+			zap.Int64("copied", result.Copied),
+			zap.Int64("deleted", result.Deleted),
+			zap.Int64("index_copied", result.IndexCopied),
+			zap.Int64("index_deleted", result.IndexDeleted),
+		)
+	}
+
+	return nil
+}
+
+func parseBuckets(list string) ([]int, error) {
+	if list == "" {
+		return nil, errors.New("-buckets is required")
+	}
+
+	var buckets []int
+	for _, part := range strings.Split(list, ",") {
+		bucketID, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, errors.Errorf("invalid bucket %q", part)
+		}
+		buckets = append(buckets, bucketID)
+	}
+	return buckets, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/Sol1tud9/taskflow/internal/activity/archive"
+	"github.com/Sol1tud9/taskflow/internal/activity/storage/sharded"
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+func runExport(ctx context.Context, cfg *config.ActivityServiceConfig, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	fromFlag := fs.String("from", "", "start of the range, inclusive (YYYY-MM-DD or RFC 3339)")
+	toFlag := fs.String("to", "", "end of the range, exclusive (YYYY-MM-DD or RFC 3339)")
+	out := fs.String("out", "", "directory to write the archive to; must not exist or be empty")
+	formatFlag := fs.String("format", string(archive.FormatNDJSON), "data file format: ndjson or parquet")
+	rowsPerFile := fs.Int("rows-per-file", archive.DefaultRowsPerFile, "rows per data file")
+	batchSize := fs.Int("batch-size", 1000, "rows read per query")
+	dropArchived := fs.Bool("drop-archived", false, "drop archived partitions inside the range after a successful export")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	from, err := parseTime("from", *fromFlag)
+	if err != nil {
+		return err
+	}
+	to, err := parseTime("to", *toFlag)
+	if err != nil {
+		return err
+	}
+	if !from.Before(to) {
+		return errors.New("-from must be before -to")
+	}
+	if *out == "" {
+		return errors.New("-out is required")
+	}
+	format, err := archive.ParseFormat(*formatFlag)
+	if err != nil {
+		return err
+	}
+
+	storage, err := sharded.NewShardedStorage(cfg.Sharding)
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+
+	writer, err := archive.NewWriter(*out, format, from, to, *rowsPerFile)
+	if err != nil {
+		return err
+	}
+
+	for shardID := range storage.GetAllShards() {
+		err := storage.StreamActivities(ctx, shardID, from, to, *batchSize, func(activities []*domain.Activity) error {
+			for _, a := range activities {
+				if err := writer.Write(shardID, a); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return errors.Wrapf(err, "shard %d", shardID)
+		}
+	}
+
+	manifest, err := writer.Close()
+	if err != nil {
+		return err
+	}
+	logger.Info("archive exported", zap.String("dir", *out), zap.String("format", string(manifest.Format)),
+		zap.Int("files", len(manifest.Files)), zap.Int64("rows", manifest.Rows))
+
+	if !*dropArchived {
+		return nil
+	}
+	for shardID := range storage.GetAllShards() {
+		dropped, err := storage.DropArchivedPartitions(ctx, shardID, from, to)
+		if err != nil {
+			return errors.Wrapf(err, "shard %d", shardID)
+		}
+		if len(dropped) > 0 {
+			logger.Info("archived partitions dropped", zap.Int("shard", shardID), zap.Strings("partitions", dropped))
+		}
+	}
+
+	return nil
+}
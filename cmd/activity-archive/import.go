@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/Sol1tud9/taskflow/internal/activity/archive"
+	"github.com/Sol1tud9/taskflow/internal/activity/storage/sharded"
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+func runImport(ctx context.Context, cfg *config.ActivityServiceConfig, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	in := fs.String("in", "", "archive directory written by export")
+	batchSize := fs.Int("batch-size", 1000, "rows written per insert")
+	verifyOnly := fs.Bool("verify-only", false, "only check the manifest and checksums")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *in == "" {
+		return errors.New("-in is required")
+	}
+
+	manifest, err := archive.ReadManifest(*in)
+	if err != nil {
+		return err
+	}
+	if err := archive.Verify(*in, manifest); err != nil {
+		return err
+	}
+	logger.Info("archive verified", zap.String("dir", *in),
+		zap.Int("files", len(manifest.Files)), zap.Int64("rows", manifest.Rows))
+	if *verifyOnly {
+		return nil
+	}
+
+	storage, err := sharded.NewShardedStorage(cfg.Sharding)
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+
+	storage.PreparePartitions(ctx, manifest.From, manifest.To)
+
+	var imported int64
+	for _, entry := range manifest.Files {
+		err := archive.ReadFile(*in, manifest, entry, *batchSize, func(activities []*domain.Activity) error {
+			n, err := storage.ImportActivities(ctx, activities)
+			imported += n
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		logger.Info("archive file imported", zap.String("file", entry.Name), zap.Int64("rows", entry.Rows))
+	}
+
+	logger.Info("archive imported", zap.Int64("rows", manifest.Rows), zap.Int64("inserted", imported))
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+const usage = `usage: activity-archive <command> [flags]
+
+commands:
+  export   write activities of a time range from all shards to gzip NDJSON or Parquet files
+  import   restore an exported archive into the shards that own its rows
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	configPath := os.Getenv("CONFIG_PATH")
+	if configPath == "" {
+		configPath = "configs/activity.yaml"
+	}
+
+	cfg, err := config.Load[config.ActivityServiceConfig](configPath)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := logger.Init(cfg.App.LogLevel); err != nil {
+		panic(err)
+	}
+	defer logger.Sync()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	command, args := os.Args[1], os.Args[2:]
+	switch command {
+	case "export":
+		err = runExport(ctx, cfg, args)
+	case "import":
+		err = runImport(ctx, cfg, args)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		logger.Fatal("command failed", zap.String("command", command), zap.Error(err))
+	}
+}
+
+// parseTime accepts a date (2006-01-02) or an RFC 3339 timestamp, in UTC.
+func parseTime(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.Errorf("-%s is required", name)
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid -%s %q: want YYYY-MM-DD or RFC 3339", name, value)
+	}
+	return t.UTC(), nil
+}
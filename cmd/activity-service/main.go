@@ -2,7 +2,11 @@ package main
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/Sol1tud9/taskflow/internal/activity/bootstrap"
 	"github.com/Sol1tud9/taskflow/pkg/config"
@@ -34,11 +38,29 @@ func main() {
 	}
 	defer app.Close()
 
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go app.Storage.WatchBucketMap(ctx)
+	go app.Storage.RunPartitionMaintenance(ctx)
 	app.Consumer.Start(ctx)
 
+	go func() {
+		if err := app.GRPCServer.Run(); err != nil {
+			logger.Fatal("failed to start grpc server", zap.Error(err))
+		}
+	}()
+
+	go func() {
+		logger.Info("http gateway started", zap.String("addr", app.HTTPServer.Addr))
+		if err := app.HTTPServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Fatal("failed to start http server", zap.Error(err))
+		}
+	}()
+
 	logger.Info("activity-service started successfully")
 
-	select {}
+	<-ctx.Done()
+	logger.Info("shutting down activity-service")
 }
 
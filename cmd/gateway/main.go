@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -35,6 +36,13 @@ func main() {
 	}
 	defer app.Close()
 
+	go func() {
+		logger.Info("metrics server started", zap.String("addr", app.MetricsServer.Addr))
+		if err := app.MetricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Fatal("failed to start metrics server", zap.Error(err))
+		}
+	}()
+
 	addr := fmt.Sprintf(":%d", cfg.Server.HTTPPort)
 	logger.Info("api-gateway started", zap.String("addr", addr))
 
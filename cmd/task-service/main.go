@@ -1,7 +1,12 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/Sol1tud9/taskflow/internal/task/bootstrap"
 	"github.com/Sol1tud9/taskflow/pkg/config"
@@ -33,8 +38,27 @@ func main() {
 	}
 	defer app.Close()
 
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		if err := app.GRPCServer.Run(); err != nil {
+			logger.Fatal("failed to start grpc server", zap.Error(err))
+		}
+	}()
+
+	go func() {
+		logger.Info("metrics server started", zap.String("addr", app.MetricsServer.Addr))
+		if err := app.MetricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Fatal("failed to start metrics server", zap.Error(err))
+		}
+	}()
+
+	go app.Relay.Run(ctx)
+
 	logger.Info("task-service started successfully")
 
-	select {}
+	<-ctx.Done()
+	logger.Info("shutting down task-service")
 }
 
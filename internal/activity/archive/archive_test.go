@@ -0,0 +1,150 @@
+package archive
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+)
+
+type ArchiveSuite struct {
+	suite.Suite
+	dir  string
+	from time.Time
+	to   time.Time
+}
+
+func (s *ArchiveSuite) SetupTest() {
+	s.dir = filepath.Join(s.T().TempDir(), "archive")
+	s.from = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	s.to = time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *ArchiveSuite) activity(i int) *domain.Activity {
+	return &domain.Activity{
+		ID:         fmt.Sprintf("activity-%d", i),
+		UserID:     "user-1",
+		EntityType: domain.EntityTypeTask,
+		EntityID:   "task-1",
+		Action:     domain.ActionTypeUpdated,
+		Metadata:   `{"field":"status"}`,
+		CreatedAt:  s.from.Add(time.Duration(i) * time.Minute),
+	}
+}
+
+func (s *ArchiveSuite) export(format Format, rowsPerFile int, rows map[int]int) *Manifest {
+	w, err := NewWriter(s.dir, format, s.from, s.to, rowsPerFile)
+	s.Require().NoError(err)
+
+	i := 0
+	for shardID := 0; shardID < len(rows); shardID++ {
+		for n := 0; n < rows[shardID]; n++ {
+			s.Require().NoError(w.Write(shardID, s.activity(i)))
+			i++
+		}
+	}
+
+	m, err := w.Close()
+	s.Require().NoError(err)
+	return m
+}
+
+func (s *ArchiveSuite) TestExport_RotatesFilesPerShard() {
+	m := s.export(FormatNDJSON, 2, map[int]int{0: 3, 1: 1})
+
+	assert.Equal(s.T(), int64(4), m.Rows)
+	s.Require().Len(m.Files, 3)
+	assert.Equal(s.T(), "shard-0-00001.ndjson.gz", m.Files[0].Name)
+	assert.Equal(s.T(), int64(2), m.Files[0].Rows)
+	assert.Equal(s.T(), "shard-0-00002.ndjson.gz", m.Files[1].Name)
+	assert.Equal(s.T(), "shard-1-00001.ndjson.gz", m.Files[2].Name)
+	assert.Equal(s.T(), 1, m.Files[2].Shard)
+
+	sums, err := os.ReadFile(filepath.Join(s.dir, ChecksumsName))
+	s.Require().NoError(err)
+	assert.Contains(s.T(), string(sums), m.Files[0].SHA256+"  shard-0-00001.ndjson.gz\n")
+}
+
+func (s *ArchiveSuite) TestRoundTrip() {
+	for _, format := range []Format{FormatNDJSON, FormatParquet} {
+		s.Run(string(format), func() {
+			s.SetupTest()
+			s.roundTrip(format)
+		})
+	}
+}
+
+func (s *ArchiveSuite) roundTrip(format Format) {
+	s.export(format, 10, map[int]int{0: 3})
+
+	m, err := ReadManifest(s.dir)
+	s.Require().NoError(err)
+	s.Require().NoError(Verify(s.dir, m))
+	assert.True(s.T(), m.From.Equal(s.from))
+	assert.Equal(s.T(), format, m.Format)
+	assert.Equal(s.T(), "shard-0-00001"+format.extension(), m.Files[0].Name)
+
+	var restored []*domain.Activity
+	err = ReadFile(s.dir, m, m.Files[0], 2, func(batch []*domain.Activity) error {
+		assert.LessOrEqual(s.T(), len(batch), 2)
+		restored = append(restored, batch...)
+		return nil
+	})
+
+	s.Require().NoError(err)
+	s.Require().Len(restored, 3)
+	assert.Equal(s.T(), s.activity(2).ID, restored[2].ID)
+	assert.True(s.T(), s.activity(2).CreatedAt.Equal(restored[2].CreatedAt))
+	assert.Equal(s.T(), s.activity(2).Metadata, restored[2].Metadata)
+	assert.Equal(s.T(), s.activity(2).Action, restored[2].Action)
+}
+
+func (s *ArchiveSuite) TestReadManifest_Version1IsNDJSON() {
+	s.Require().NoError(os.MkdirAll(s.dir, 0o755))
+	s.Require().NoError(os.WriteFile(filepath.Join(s.dir, ManifestName), []byte(`{"version":1,"files":[]}`), 0o644))
+
+	m, err := ReadManifest(s.dir)
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), FormatNDJSON, m.Format)
+}
+
+func (s *ArchiveSuite) TestParseFormat() {
+	f, err := ParseFormat("parquet")
+	s.Require().NoError(err)
+	assert.Equal(s.T(), FormatParquet, f)
+
+	_, err = ParseFormat("csv")
+	assert.Error(s.T(), err)
+}
+
+func (s *ArchiveSuite) TestVerify_DetectsCorruption() {
+	m := s.export(FormatNDJSON, 10, map[int]int{0: 3})
+
+	path := filepath.Join(s.dir, m.Files[0].Name)
+	data, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	data[len(data)/2] ^= 0xff
+	s.Require().NoError(os.WriteFile(path, data, 0o644))
+
+	assert.ErrorIs(s.T(), Verify(s.dir, m), ErrChecksumMismatch)
+}
+
+func (s *ArchiveSuite) TestNewWriter_RejectsNonEmptyDirectory() {
+	s.Require().NoError(os.MkdirAll(s.dir, 0o755))
+	s.Require().NoError(os.WriteFile(filepath.Join(s.dir, "leftover"), nil, 0o644))
+
+	_, err := NewWriter(s.dir, FormatNDJSON, s.from, s.to, 10)
+
+	assert.Error(s.T(), err)
+}
+
+func TestArchiveSuite(t *testing.T) {
+	suite.Run(t, new(ArchiveSuite))
+}
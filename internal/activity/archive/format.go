@@ -0,0 +1,166 @@
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/pkg/errors"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+)
+
+// Format is the encoding of an archive's data files.
+type Format string
+
+const (
+	// FormatNDJSON is gzip-compressed NDJSON, one activity per line.
+	FormatNDJSON Format = "ndjson"
+	// FormatParquet is one Parquet file per data file with zstd-compressed
+	// columns, readable by analytics tools without a restore.
+	FormatParquet Format = "parquet"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatNDJSON, FormatParquet:
+		return f, nil
+	default:
+		return "", errors.Errorf("unknown archive format %q: want %s or %s", s, FormatNDJSON, FormatParquet)
+	}
+}
+
+func (f Format) extension() string {
+	if f == FormatParquet {
+		return ".parquet"
+	}
+	return ".ndjson.gz"
+}
+
+// encoder writes activities to one data file.
+type encoder interface {
+	Encode(activity *domain.Activity) error
+	Close() error
+}
+
+// decoder reads the activities of one data file; Decode returns io.EOF after
+// the last one.
+type decoder interface {
+	Decode() (*domain.Activity, error)
+	Close() error
+}
+
+func (f Format) newEncoder(w io.Writer) encoder {
+	if f == FormatParquet {
+		return &parquetEncoder{w: parquet.NewGenericWriter[parquetActivity](w, parquet.Compression(&parquet.Zstd))}
+	}
+	gz := gzip.NewWriter(w)
+	return &ndjsonEncoder{gz: gz, enc: json.NewEncoder(gz)}
+}
+
+func (f Format) newDecoder(file *os.File) (decoder, error) {
+	if f == FormatParquet {
+		return &parquetDecoder{r: parquet.NewGenericReader[parquetActivity](file)}, nil
+	}
+	gz, err := gzip.NewReader(bufio.NewReader(file))
+	if err != nil {
+		return nil, err
+	}
+	return &ndjsonDecoder{gz: gz, dec: json.NewDecoder(gz)}, nil
+}
+
+type ndjsonEncoder struct {
+	gz  *gzip.Writer
+	enc *json.Encoder
+}
+
+func (e *ndjsonEncoder) Encode(activity *domain.Activity) error {
+	return e.enc.Encode(activity)
+}
+
+func (e *ndjsonEncoder) Close() error {
+	return e.gz.Close()
+}
+
+type ndjsonDecoder struct {
+	gz  *gzip.Reader
+	dec *json.Decoder
+}
+
+func (d *ndjsonDecoder) Decode() (*domain.Activity, error) {
+	var a domain.Activity
+	if err := d.dec.Decode(&a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (d *ndjsonDecoder) Close() error {
+	return d.gz.Close()
+}
+
+// parquetActivity is the Parquet schema of an archived activity.
+type parquetActivity struct {
+	ID         string    `parquet:"id"`
+	UserID     string    `parquet:"user_id"`
+	EntityType string    `parquet:"entity_type,dict"`
+	EntityID   string    `parquet:"entity_id"`
+	Action     string    `parquet:"action,dict"`
+	Metadata   string    `parquet:"metadata"`
+	CreatedAt  time.Time `parquet:"created_at,timestamp(microsecond)"`
+}
+
+type parquetEncoder struct {
+	w *parquet.GenericWriter[parquetActivity]
+}
+
+func (e *parquetEncoder) Encode(a *domain.Activity) error {
+	_, err := e.w.Write([]parquetActivity{{
+		ID:         a.ID,
+		UserID:     a.UserID,
+		EntityType: string(a.EntityType),
+		EntityID:   a.EntityID,
+		Action:     string(a.Action),
+		Metadata:   a.Metadata,
+		CreatedAt:  a.CreatedAt,
+	}})
+	return err
+}
+
+func (e *parquetEncoder) Close() error {
+	return e.w.Close()
+}
+
+type parquetDecoder struct {
+	r   *parquet.GenericReader[parquetActivity]
+	row [1]parquetActivity
+}
+
+func (d *parquetDecoder) Decode() (*domain.Activity, error) {
+	n, err := d.r.Read(d.row[:])
+	if n == 0 {
+		if err == nil {
+			err = io.EOF
+		}
+		return nil, err
+	}
+
+	row := d.row[0]
+	return &domain.Activity{
+		ID:         row.ID,
+		UserID:     row.UserID,
+		EntityType: domain.EntityType(row.EntityType),
+		EntityID:   row.EntityID,
+		Action:     domain.ActionType(row.Action),
+		Metadata:   row.Metadata,
+		CreatedAt:  row.CreatedAt.UTC(),
+	}, nil
+}
+
+func (d *parquetDecoder) Close() error {
+	return d.r.Close()
+}
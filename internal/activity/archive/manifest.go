@@ -0,0 +1,85 @@
+package archive
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ManifestName    = "manifest.json"
+	ChecksumsName   = "SHA256SUMS"
+	manifestVersion = 2
+)
+
+var ErrChecksumMismatch = errors.New("archive checksum mismatch")
+
+// Manifest describes an archive directory: the format of its data files, the
+// exported time range and every data file with its row count and SHA-256 of
+// the file's bytes.
+type Manifest struct {
+	Version   int         `json:"version"`
+	Format    Format      `json:"format"`
+	CreatedAt time.Time   `json:"created_at"`
+	From      time.Time   `json:"from"`
+	To        time.Time   `json:"to"`
+	Rows      int64       `json:"rows"`
+	Files     []FileEntry `json:"files"`
+}
+
+type FileEntry struct {
+	Name   string `json:"name"`
+	Shard  int    `json:"shard"`
+	Rows   int64  `json:"rows"`
+	Bytes  int64  `json:"bytes"`
+	SHA256 string `json:"sha256"`
+}
+
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestName))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read manifest")
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, errors.Wrap(err, "failed to parse manifest")
+	}
+	switch m.Version {
+	case 1:
+		// Version 1 archives predate the format field and are all NDJSON.
+		m.Format = FormatNDJSON
+	case manifestVersion:
+		if _, err := ParseFormat(string(m.Format)); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.Errorf("unsupported archive version %d", m.Version)
+	}
+
+	return &m, nil
+}
+
+func writeManifest(dir string, m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode manifest")
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestName), append(data, '\n'), 0o644); err != nil {
+		return errors.Wrap(err, "failed to write manifest")
+	}
+
+	// SHA256SUMS lets operators check an archive with `sha256sum -c`.
+	var sums []byte
+	for _, f := range m.Files {
+		sums = append(sums, f.SHA256+"  "+f.Name+"\n"...)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ChecksumsName), sums, 0o644); err != nil {
+		return errors.Wrap(err, "failed to write checksums")
+	}
+
+	return nil
+}
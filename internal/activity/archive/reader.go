@@ -0,0 +1,93 @@
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+)
+
+const defaultReadBatch = 1000
+
+// Verify checks the size and checksum of every file in the manifest, so a
+// damaged archive is rejected before anything is imported.
+func Verify(dir string, m *Manifest) error {
+	for _, entry := range m.Files {
+		if err := verifyFile(dir, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func verifyFile(dir string, entry FileEntry) error {
+	file, err := os.Open(filepath.Join(dir, filepath.Base(entry.Name)))
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", entry.Name)
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, file)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", entry.Name)
+	}
+	if n != entry.Bytes || hex.EncodeToString(h.Sum(nil)) != entry.SHA256 {
+		return errors.Wrap(ErrChecksumMismatch, entry.Name)
+	}
+
+	return nil
+}
+
+// ReadFile decodes the activities of one data file of the manifest's format in
+// order and passes them to fn in batches of at most batchSize.
+func ReadFile(dir string, m *Manifest, entry FileEntry, batchSize int, fn func([]*domain.Activity) error) error {
+	if batchSize <= 0 {
+		batchSize = defaultReadBatch
+	}
+
+	file, err := os.Open(filepath.Join(dir, filepath.Base(entry.Name)))
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", entry.Name)
+	}
+	defer file.Close()
+
+	dec, err := m.Format.newDecoder(file)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", entry.Name)
+	}
+	defer dec.Close()
+
+	batch := make([]*domain.Activity, 0, batchSize)
+	var rows int64
+	for {
+		a, err := dec.Decode()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return errors.Wrapf(err, "failed to decode %s at row %d", entry.Name, rows+1)
+		}
+		rows++
+
+		batch = append(batch, a)
+		if len(batch) >= batchSize {
+			if err := fn(batch); err != nil {
+				return err
+			}
+			batch = make([]*domain.Activity, 0, batchSize)
+		}
+	}
+	if rows != entry.Rows {
+		return errors.Errorf("%s has %d rows, manifest says %d", entry.Name, rows, entry.Rows)
+	}
+	if len(batch) > 0 {
+		return fn(batch)
+	}
+
+	return nil
+}
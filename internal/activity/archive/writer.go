@@ -0,0 +1,158 @@
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+)
+
+const DefaultRowsPerFile = 100000
+
+// Writer writes activities in the archive's format, one file per shard and
+// at most rowsPerFile rows each, and finishes with the manifest.
+type Writer struct {
+	dir         string
+	format      Format
+	rowsPerFile int64
+	manifest    Manifest
+	seq         map[int]int
+	current     *dataFile
+}
+
+type dataFile struct {
+	entry FileEntry
+	file  *os.File
+	enc   encoder
+	hash  hash.Hash
+	count *countingWriter
+}
+
+// NewWriter creates dir, which must not exist or be empty.
+func NewWriter(dir string, format Format, from, to time.Time, rowsPerFile int) (*Writer, error) {
+	if rowsPerFile <= 0 {
+		rowsPerFile = DefaultRowsPerFile
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "failed to create archive directory")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read archive directory")
+	}
+	if len(entries) > 0 {
+		return nil, errors.Errorf("archive directory %s is not empty", dir)
+	}
+
+	return &Writer{
+		dir:         dir,
+		format:      format,
+		rowsPerFile: int64(rowsPerFile),
+		manifest: Manifest{
+			Version:   manifestVersion,
+			Format:    format,
+			CreatedAt: time.Now().UTC(),
+			From:      from.UTC(),
+			To:        to.UTC(),
+			Files:     []FileEntry{},
+		},
+		seq: make(map[int]int),
+	}, nil
+}
+
+func (w *Writer) Write(shardID int, activity *domain.Activity) error {
+	if w.current != nil && (w.current.entry.Shard != shardID || w.current.entry.Rows >= w.rowsPerFile) {
+		if err := w.closeFile(); err != nil {
+			return err
+		}
+	}
+	if w.current == nil {
+		if err := w.openFile(shardID); err != nil {
+			return err
+		}
+	}
+
+	if err := w.current.enc.Encode(activity); err != nil {
+		return errors.Wrapf(err, "failed to write %s", w.current.entry.Name)
+	}
+	w.current.entry.Rows++
+
+	return nil
+}
+
+// Close finishes the last data file and writes the manifest.
+func (w *Writer) Close() (*Manifest, error) {
+	if w.current != nil {
+		if err := w.closeFile(); err != nil {
+			return nil, err
+		}
+	}
+	if err := writeManifest(w.dir, &w.manifest); err != nil {
+		return nil, err
+	}
+	return &w.manifest, nil
+}
+
+func (w *Writer) openFile(shardID int) error {
+	w.seq[shardID]++
+	name := fmt.Sprintf("shard-%d-%05d%s", shardID, w.seq[shardID], w.format.extension())
+
+	file, err := os.Create(filepath.Join(w.dir, name))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s", name)
+	}
+
+	h := sha256.New()
+	count := &countingWriter{}
+
+	w.current = &dataFile{
+		entry: FileEntry{Name: name, Shard: shardID},
+		file:  file,
+		enc:   w.format.newEncoder(io.MultiWriter(file, h, count)),
+		hash:  h,
+		count: count,
+	}
+	return nil
+}
+
+func (w *Writer) closeFile() error {
+	f := w.current
+	w.current = nil
+
+	if err := f.enc.Close(); err != nil {
+		f.file.Close()
+		return errors.Wrapf(err, "failed to finish %s", f.entry.Name)
+	}
+	if err := f.file.Sync(); err != nil {
+		f.file.Close()
+		return errors.Wrapf(err, "failed to sync %s", f.entry.Name)
+	}
+	if err := f.file.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close %s", f.entry.Name)
+	}
+
+	f.entry.Bytes = f.count.n
+	f.entry.SHA256 = hex.EncodeToString(f.hash.Sum(nil))
+	w.manifest.Files = append(w.manifest.Files, f.entry)
+	w.manifest.Rows += f.entry.Rows
+
+	return nil
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
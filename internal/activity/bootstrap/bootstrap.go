@@ -1,43 +1,100 @@
 package bootstrap
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	apievents "github.com/Sol1tud9/taskflow/api/events"
 	"github.com/Sol1tud9/taskflow/internal/activity/consumer"
+	"github.com/Sol1tud9/taskflow/internal/activity/server"
 	"github.com/Sol1tud9/taskflow/internal/activity/storage/sharded"
 	"github.com/Sol1tud9/taskflow/internal/activity/usecase"
+	"github.com/Sol1tud9/taskflow/internal/pb/activity_api"
 	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/events"
+	"github.com/Sol1tud9/taskflow/pkg/grpcserver"
 	"github.com/Sol1tud9/taskflow/pkg/logger"
+	"github.com/Sol1tud9/taskflow/pkg/metrics"
 	"go.uber.org/zap"
 )
 
+const httpShutdownTimeout = 10 * time.Second
+
 type App struct {
 	Config     *config.ActivityServiceConfig
 	Storage    *sharded.ShardedStorage
 	Consumer   *consumer.EventConsumer
 	ActivityUC *usecase.ActivityUseCase
+	GRPCServer *grpcserver.Server
+	HTTPServer *http.Server
 }
 
 func NewApp(cfg *config.ActivityServiceConfig) (*App, error) {
+	registry, err := events.NewRegistry(apievents.Schemas)
+	if err != nil {
+		logger.Error("failed to load event schemas", zap.Error(err))
+		return nil, err
+	}
+
 	storage, err := sharded.NewShardedStorage(cfg.Sharding)
 	if err != nil {
 		logger.Error("failed to init sharded storage", zap.Error(err))
 		return nil, err
 	}
+	for i, shard := range storage.GetAllShards() {
+		if err := metrics.RegisterPool(shard, cfg.Sharding.Shards[i].Name, strconv.Itoa(i)); err != nil {
+			logger.Error("failed to register pool metrics", zap.Error(err))
+			return nil, err
+		}
+	}
 
 	activityUC := usecase.NewActivityUseCase(storage)
 
 	groupID := cfg.Kafka.ConsumerGroups["activity_consumer"]
-	eventConsumer := consumer.NewEventConsumer(cfg.Kafka.Brokers, cfg.Kafka.Topics, groupID, activityUC)
+	eventConsumer := consumer.NewEventConsumer(cfg.Kafka.Brokers, cfg.Kafka.Topics, groupID, activityUC, events.NewDecoder(registry), cfg.Retry)
+
+	activityServer := server.NewServer(activityUC)
+
+	grpcServer := grpcserver.New(cfg.Server.GRPCPort)
+	grpcServer.RegisterService(&activity_api.ActivityService_ServiceDesc, activityServer)
+
+	httpHandler, err := server.NewHTTPHandler(context.Background(), activityServer)
+	if err != nil {
+		logger.Error("failed to init http gateway", zap.Error(err))
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metrics.Path, metrics.Handler())
+	mux.Handle("/", httpHandler)
+
+	httpServer := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Server.HTTPPort),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
 
 	return &App{
 		Config:     cfg,
 		Storage:    storage,
 		Consumer:   eventConsumer,
 		ActivityUC: activityUC,
+		GRPCServer: grpcServer,
+		HTTPServer: httpServer,
 	}, nil
 }
 
 func (a *App) Close() {
-	a.Storage.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+	defer cancel()
+	if err := a.HTTPServer.Shutdown(ctx); err != nil {
+		logger.Error("failed to shutdown http server", zap.Error(err))
+	}
+
+	a.GRPCServer.Stop()
 	_ = a.Consumer.Close()
+	a.Storage.Close()
 }
-
@@ -3,8 +3,16 @@ package consumer
 import (
 	"context"
 	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	kafkago "github.com/segmentio/kafka-go"
 
 	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/events"
 	"github.com/Sol1tud9/taskflow/pkg/kafka"
 	"github.com/Sol1tud9/taskflow/pkg/logger"
 	"go.uber.org/zap"
@@ -15,134 +23,282 @@ type ActivityRecorder interface {
 	RecordUserUpdated(ctx context.Context, event domain.UserUpdatedEvent) error
 	RecordTaskCreated(ctx context.Context, event domain.TaskCreatedEvent) error
 	RecordTaskUpdated(ctx context.Context, event domain.TaskUpdatedEvent) error
+	RecordTaskDeleted(ctx context.Context, event domain.TaskDeletedEvent) error
+	RecordTeamCreated(ctx context.Context, event domain.TeamCreatedEvent) error
+	RecordTeamUpdated(ctx context.Context, event domain.TeamUpdatedEvent) error
+	RecordTeamMemberAdded(ctx context.Context, event domain.TeamMemberAddedEvent) error
+	RecordTeamMemberRemoved(ctx context.Context, event domain.TeamMemberRemovedEvent) error
+}
+
+// MessageReader is the part of kafka.Consumer used by the event loop.
+type MessageReader interface {
+	Fetch(ctx context.Context) (kafkago.Message, error)
+	Commit(ctx context.Context, msg kafkago.Message) error
+	Close() error
+}
+
+// MessageWriter is the part of kafka.Producer used for dead letters and replay.
+type MessageWriter interface {
+	PublishBytes(ctx context.Context, key string, data []byte, headers ...kafkago.Header) error
+	Close() error
+}
+
+type handlerFunc func(ctx context.Context, payload []byte) error
+
+// versions maps a schema version to the handler for payloads of that version.
+type versions map[int]handlerFunc
+
+type subscription struct {
+	topic     string
+	eventType string
+	reader    MessageReader
+	versions  versions
+	lag       *partitionLag
 }
 
 type EventConsumer struct {
-	userCreatedConsumer *kafka.Consumer
-	userUpdatedConsumer *kafka.Consumer
-	taskCreatedConsumer *kafka.Consumer
-	taskUpdatedConsumer *kafka.Consumer
-	recorder            ActivityRecorder
-}
-
-func NewEventConsumer(brokers []string, topics map[string]string, groupID string, recorder ActivityRecorder) *EventConsumer {
-	return &EventConsumer{
-		userCreatedConsumer: kafka.NewConsumer(brokers, topics["user_created"], groupID),
-		userUpdatedConsumer: kafka.NewConsumer(brokers, topics["user_updated"], groupID),
-		taskCreatedConsumer: kafka.NewConsumer(brokers, topics["task_created"], groupID),
-		taskUpdatedConsumer: kafka.NewConsumer(brokers, topics["task_updated"], groupID),
-		recorder:            recorder,
+	subscriptions []subscription
+	decoder       *events.Decoder
+	deadLetter    MessageWriter
+	retry         RetryPolicy
+}
+
+func NewEventConsumer(
+	brokers []string,
+	topics map[string]string,
+	groupID string,
+	recorder ActivityRecorder,
+	decoder *events.Decoder,
+	retry config.ConsumerRetryConfig,
+) *EventConsumer {
+	c := &EventConsumer{
+		decoder:    decoder,
+		deadLetter: kafka.NewProducer(brokers, topics["dead_letter"]),
+		retry:      NewRetryPolicy(retry),
+	}
+
+	routes := []struct {
+		topic     string
+		eventType string
+		versions  versions
+	}{
+		{"user_created", domain.EventTypeUserCreated, versions{1: decode(recorder.RecordUserCreated)}},
+		{"user_updated", domain.EventTypeUserUpdated, versions{1: decode(recorder.RecordUserUpdated)}},
+		{"task_created", domain.EventTypeTaskCreated, versions{1: decode(recorder.RecordTaskCreated)}},
+		{"task_updated", domain.EventTypeTaskUpdated, versions{
+			1: decode(upgradeTaskUpdatedV1(recorder.RecordTaskUpdated)),
+			2: decode(recorder.RecordTaskUpdated),
+		}},
+		{"task_deleted", domain.EventTypeTaskDeleted, versions{1: decode(recorder.RecordTaskDeleted)}},
+		{"team_created", domain.EventTypeTeamCreated, versions{1: decode(recorder.RecordTeamCreated), 2: decode(recorder.RecordTeamCreated)}},
+		{"team_updated", domain.EventTypeTeamUpdated, versions{1: decode(recorder.RecordTeamUpdated), 2: decode(recorder.RecordTeamUpdated)}},
+		{"team_member_added", domain.EventTypeTeamMemberAdded, versions{1: decode(recorder.RecordTeamMemberAdded), 2: decode(recorder.RecordTeamMemberAdded)}},
+		{"team_member_removed", domain.EventTypeTeamMemberRemoved, versions{1: decode(recorder.RecordTeamMemberRemoved), 2: decode(recorder.RecordTeamMemberRemoved)}},
 	}
+	for _, route := range routes {
+		topic := topics[route.topic]
+		c.subscribe(topic, route.eventType, kafka.NewConsumer(brokers, topic, groupID), route.versions)
+	}
+
+	return c
+}
+
+func (c *EventConsumer) subscribe(topic, eventType string, reader MessageReader, handlers versions) {
+	c.subscriptions = append(c.subscriptions, subscription{
+		topic:     topic,
+		eventType: eventType,
+		reader:    reader,
+		versions:  handlers,
+		lag:       &partitionLag{positions: make(map[int]partitionPosition)},
+	})
 }
 
 func (c *EventConsumer) Start(ctx context.Context) {
-	go c.consumeUserCreated(ctx)
-	go c.consumeUserUpdated(ctx)
-	go c.consumeTaskCreated(ctx)
-	go c.consumeTaskUpdated(ctx)
+	for _, sub := range c.subscriptions {
+		go c.consume(ctx, sub)
+	}
+	go c.reportLag(ctx)
 }
 
-func (c *EventConsumer) consumeUserCreated(ctx context.Context) {
+// lagInterval is how often the lag is republished while no message is being
+// committed, e.g. when a handler is stuck retrying.
+var lagInterval = 15 * time.Second
+
+// reportLag republishes the lag of every subscription until ctx is cancelled.
+func (c *EventConsumer) reportLag(ctx context.Context) {
+	ticker := time.NewTicker(lagInterval)
+	defer ticker.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		default:
-			msg, err := c.userCreatedConsumer.Read(ctx)
-			if err != nil {
-				continue
+		case <-ticker.C:
+			for _, sub := range c.subscriptions {
+				sub.lag.publish(sub.topic)
 			}
+		}
+	}
+}
 
-			var event domain.UserCreatedEvent
-			if err := json.Unmarshal(msg.Value, &event); err != nil {
-				logger.Error("failed to unmarshal user created event", zap.Error(err))
-				continue
-			}
+// partitionLag remembers the high-water mark and committed offset last seen
+// on each partition of a subscription.
+type partitionLag struct {
+	mu        sync.Mutex
+	positions map[int]partitionPosition
+}
 
-			if err := c.recorder.RecordUserCreated(ctx, event); err != nil {
-				logger.Error("failed to record user created activity", zap.Error(err))
-			}
-		}
+type partitionPosition struct {
+	highWaterMark int64
+	offset        int64
+}
+
+// commit records msg as the partition's last committed message and publishes
+// the subscription's lag.
+func (l *partitionLag) commit(topic string, msg kafkago.Message) {
+	l.mu.Lock()
+	l.positions[msg.Partition] = partitionPosition{highWaterMark: msg.HighWaterMark, offset: msg.Offset}
+	l.mu.Unlock()
+
+	l.publish(topic)
+}
+
+func (l *partitionLag) publish(topic string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for partition, pos := range l.positions {
+		consumerLag.WithLabelValues(topic, strconv.Itoa(partition)).Set(float64(pos.lag()))
 	}
 }
 
-func (c *EventConsumer) consumeUserUpdated(ctx context.Context) {
+// lag returns how many messages of the partition follow the committed one.
+func (p partitionPosition) lag() int64 {
+	if behind := p.highWaterMark - p.offset - 1; behind > 0 {
+		return behind
+	}
+	return 0
+}
+
+func (c *EventConsumer) consume(ctx context.Context, sub subscription) {
+	readFailures := 0
 	for {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-			msg, err := c.userUpdatedConsumer.Read(ctx)
-			if err != nil {
-				continue
+		msg, err := sub.reader.Fetch(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
 			}
-
-			var event domain.UserUpdatedEvent
-			if err := json.Unmarshal(msg.Value, &event); err != nil {
-				logger.Error("failed to unmarshal user updated event", zap.Error(err))
-				continue
+			readFailures++
+			if !sleep(ctx, c.retry.Backoff(readFailures)) {
+				return
 			}
+			continue
+		}
+		readFailures = 0
 
-			if err := c.recorder.RecordUserUpdated(ctx, event); err != nil {
-				logger.Error("failed to record user updated activity", zap.Error(err))
-			}
+		if err := c.process(ctx, sub, msg); err != nil {
+			return
 		}
+
+		_ = sub.reader.Commit(ctx, msg)
+		sub.lag.commit(sub.topic, msg)
 	}
 }
 
-func (c *EventConsumer) consumeTaskCreated(ctx context.Context) {
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-			msg, err := c.taskCreatedConsumer.Read(ctx)
-			if err != nil {
-				continue
-			}
-
-			var event domain.TaskCreatedEvent
-			if err := json.Unmarshal(msg.Value, &event); err != nil {
-				logger.Error("failed to unmarshal task created event", zap.Error(err))
-				continue
-			}
+// process handles msg, retrying transient failures. A message that still
+// fails after the last attempt, or cannot be decoded at all, is moved to the
+// dead-letter topic. It only returns an error when ctx is cancelled, in which
+// case the offset must not be committed.
+func (c *EventConsumer) process(ctx context.Context, sub subscription, msg kafkago.Message) error {
+	var (
+		err     error
+		attempt int
+	)
+	for attempt = 1; ; attempt++ {
+		if err = c.handle(ctx, sub, msg.Value); err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if isPermanent(err) || attempt >= c.retry.MaxAttempts {
+			break
+		}
 
-			if err := c.recorder.RecordTaskCreated(ctx, event); err != nil {
-				logger.Error("failed to record task created activity", zap.Error(err))
-			}
+		delay := c.retry.Backoff(attempt)
+		logger.Warn("failed to handle event, retrying",
+			zap.Error(err),
+			zap.String("topic", sub.topic),
+			zap.Int("attempt", attempt),
+			zap.Duration("retry_in", delay),
+		)
+		if !sleep(ctx, delay) {
+			return ctx.Err()
 		}
 	}
+
+	logger.Error("moving event to dead-letter topic",
+		zap.Error(err),
+		zap.String("topic", sub.topic),
+		zap.Int("attempts", attempt),
+	)
+	return c.sendToDeadLetter(ctx, sub.topic, msg, err, attempt)
 }
 
-func (c *EventConsumer) consumeTaskUpdated(ctx context.Context) {
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-			msg, err := c.taskUpdatedConsumer.Read(ctx)
-			if err != nil {
-				continue
-			}
+// handle unwraps the event envelope and dispatches the payload to the
+// handler for its schema version. Invalid envelopes and unknown versions are
+// permanent failures.
+func (c *EventConsumer) handle(ctx context.Context, sub subscription, value []byte) error {
+	envelope, err := c.decoder.Decode(sub.eventType, value)
+	if err != nil {
+		return permanent(err)
+	}
 
-			var event domain.TaskUpdatedEvent
-			if err := json.Unmarshal(msg.Value, &event); err != nil {
-				logger.Error("failed to unmarshal task updated event", zap.Error(err))
-				continue
-			}
+	handle, ok := sub.versions[envelope.SchemaVersion]
+	if !ok {
+		return permanent(errors.Errorf("unsupported %s schema version %d", envelope.Type, envelope.SchemaVersion))
+	}
 
-			if err := c.recorder.RecordTaskUpdated(ctx, event); err != nil {
-				logger.Error("failed to record task updated activity", zap.Error(err))
-			}
+	return handle(events.WithTrace(ctx, envelope.Trace), envelope.Payload)
+}
+
+func (c *EventConsumer) sendToDeadLetter(ctx context.Context, topic string, msg kafkago.Message, cause error, attempts int) error {
+	headers := []kafkago.Header{
+		{Key: HeaderOriginalTopic, Value: []byte(topic)},
+		{Key: HeaderOriginalPartition, Value: []byte(strconv.Itoa(msg.Partition))},
+		{Key: HeaderOriginalOffset, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		{Key: HeaderError, Value: []byte(cause.Error())},
+		{Key: HeaderAttempts, Value: []byte(strconv.Itoa(attempts))},
+		{Key: HeaderFailedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339))},
+	}
+
+	for failures := 1; ; failures++ {
+		err := c.deadLetter.PublishBytes(ctx, string(msg.Key), msg.Value, headers...)
+		if err == nil {
+			return nil
+		}
+		if !sleep(ctx, c.retry.Backoff(failures)) {
+			return ctx.Err()
 		}
 	}
 }
 
 func (c *EventConsumer) Close() error {
-	_ = c.userCreatedConsumer.Close()
-	_ = c.userUpdatedConsumer.Close()
-	_ = c.taskCreatedConsumer.Close()
-	_ = c.taskUpdatedConsumer.Close()
+	for _, sub := range c.subscriptions {
+		_ = sub.reader.Close()
+	}
+	_ = c.deadLetter.Close()
 	return nil
 }
 
+// decode adapts a typed recorder method to a raw message handler. Payloads
+// that cannot be unmarshalled are never retried.
+func decode[T any](record func(ctx context.Context, event T) error) handlerFunc {
+	return func(ctx context.Context, value []byte) error {
+		var event T
+		if err := json.Unmarshal(value, &event); err != nil {
+			return permanent(errors.Wrap(err, "failed to unmarshal event"))
+		}
+		return record(ctx, event)
+	}
+}
@@ -0,0 +1,447 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	apievents "github.com/Sol1tud9/taskflow/api/events"
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/events"
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+type fakeReader struct {
+	mu        sync.Mutex
+	messages  []kafkago.Message
+	committed []kafkago.Message
+}
+
+func (f *fakeReader) Fetch(ctx context.Context) (kafkago.Message, error) {
+	f.mu.Lock()
+	if len(f.messages) > 0 {
+		msg := f.messages[0]
+		f.messages = f.messages[1:]
+		f.mu.Unlock()
+		return msg, nil
+	}
+	f.mu.Unlock()
+
+	<-ctx.Done()
+	return kafkago.Message{}, ctx.Err()
+}
+
+func (f *fakeReader) Commit(ctx context.Context, msg kafkago.Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.committed = append(f.committed, msg)
+	return nil
+}
+
+func (f *fakeReader) Close() error { return nil }
+
+func (f *fakeReader) committedCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.committed)
+}
+
+type sentMessage struct {
+	key     string
+	value   []byte
+	headers []kafkago.Header
+}
+
+type fakeWriter struct {
+	mu       sync.Mutex
+	failures int
+	sent     []sentMessage
+}
+
+func (f *fakeWriter) PublishBytes(ctx context.Context, key string, data []byte, headers ...kafkago.Header) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("broker unavailable")
+	}
+	f.sent = append(f.sent, sentMessage{key: key, value: data, headers: headers})
+	return nil
+}
+
+func (f *fakeWriter) Close() error { return nil }
+
+const taskCreatedV1 = `{"event_id":"evt-1","task_id":"task-1","creator_id":"user-1","created_at":"2026-01-01T00:00:00Z"}`
+
+type fakeRecorder struct {
+	failures int
+	calls    int
+	events   []domain.TaskCreatedEvent
+	updates  []domain.TaskUpdatedEvent
+	members  []domain.TeamMemberAddedEvent
+}
+
+func (f *fakeRecorder) RecordTaskCreated(ctx context.Context, event domain.TaskCreatedEvent) error {
+	f.calls++
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("shard unavailable")
+	}
+	f.events = append(f.events, event)
+	return nil
+}
+
+func (f *fakeRecorder) RecordTaskUpdated(ctx context.Context, event domain.TaskUpdatedEvent) error {
+	f.calls++
+	f.updates = append(f.updates, event)
+	return nil
+}
+
+func (f *fakeRecorder) RecordTeamMemberAdded(ctx context.Context, event domain.TeamMemberAddedEvent) error {
+	f.calls++
+	f.members = append(f.members, event)
+	return nil
+}
+
+type EventConsumerSuite struct {
+	suite.Suite
+	ctx        context.Context
+	reader     *fakeReader
+	deadLetter *fakeWriter
+	recorder   *fakeRecorder
+	registry   *events.Registry
+	consumer   *EventConsumer
+}
+
+func (s *EventConsumerSuite) SetupSuite() {
+	_ = logger.Init("error")
+
+	registry, err := events.NewRegistry(apievents.Schemas)
+	s.Require().NoError(err)
+	s.registry = registry
+}
+
+func (s *EventConsumerSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.reader = &fakeReader{}
+	s.deadLetter = &fakeWriter{}
+	s.recorder = &fakeRecorder{}
+	s.consumer = &EventConsumer{
+		decoder:    events.NewDecoder(s.registry),
+		deadLetter: s.deadLetter,
+		retry: NewRetryPolicy(config.ConsumerRetryConfig{
+			MaxAttempts:      3,
+			InitialBackoffMs: 1,
+			MaxBackoffMs:     5,
+		}),
+	}
+	s.consumer.subscribe("task.created", domain.EventTypeTaskCreated, s.reader, versions{1: decode(s.recorder.RecordTaskCreated)})
+	s.consumer.subscribe("task.updated", domain.EventTypeTaskUpdated, s.reader, versions{
+		1: decode(upgradeTaskUpdatedV1(s.recorder.RecordTaskUpdated)),
+		2: decode(s.recorder.RecordTaskUpdated),
+	})
+	s.consumer.subscribe("team.member-added", domain.EventTypeTeamMemberAdded, s.reader, versions{
+		1: decode(s.recorder.RecordTeamMemberAdded),
+		2: decode(s.recorder.RecordTeamMemberAdded),
+	})
+}
+
+func (s *EventConsumerSuite) message(value string) kafkago.Message {
+	return kafkago.Message{Topic: "task.created", Partition: 1, Offset: 42, Key: []byte("task-1"), Value: []byte(value)}
+}
+
+func (s *EventConsumerSuite) TestProcess_RetriesTransientFailure() {
+	s.recorder.failures = 2
+
+	err := s.consumer.process(s.ctx, s.consumer.subscriptions[0], s.message(taskCreatedV1))
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 3, s.recorder.calls)
+	assert.Len(s.T(), s.recorder.events, 1)
+	assert.Empty(s.T(), s.deadLetter.sent)
+}
+
+func (s *EventConsumerSuite) TestProcess_DeadLettersAfterMaxAttempts() {
+	s.recorder.failures = 10
+
+	err := s.consumer.process(s.ctx, s.consumer.subscriptions[0], s.message(taskCreatedV1))
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 3, s.recorder.calls)
+	s.Require().Len(s.deadLetter.sent, 1)
+
+	sent := s.deadLetter.sent[0]
+	msg := kafkago.Message{Headers: sent.headers}
+	assert.Equal(s.T(), "task-1", sent.key)
+	assert.Equal(s.T(), taskCreatedV1, string(sent.value))
+	assert.Equal(s.T(), "task.created", headerValue(msg, HeaderOriginalTopic))
+	assert.Equal(s.T(), "1", headerValue(msg, HeaderOriginalPartition))
+	assert.Equal(s.T(), "42", headerValue(msg, HeaderOriginalOffset))
+	assert.Equal(s.T(), "3", headerValue(msg, HeaderAttempts))
+	assert.Equal(s.T(), "shard unavailable", headerValue(msg, HeaderError))
+	assert.NotEmpty(s.T(), headerValue(msg, HeaderFailedAt))
+}
+
+func (s *EventConsumerSuite) TestProcess_DoesNotRetryMalformedPayload() {
+	err := s.consumer.process(s.ctx, s.consumer.subscriptions[0], s.message(`not json`))
+
+	assert.NoError(s.T(), err)
+	assert.Zero(s.T(), s.recorder.calls)
+	s.Require().Len(s.deadLetter.sent, 1)
+	assert.Equal(s.T(), "1", headerValue(kafkago.Message{Headers: s.deadLetter.sent[0].headers}, HeaderAttempts))
+}
+
+func (s *EventConsumerSuite) TestProcess_RetriesDeadLetterPublish() {
+	s.recorder.failures = 10
+	s.deadLetter.failures = 2
+
+	err := s.consumer.process(s.ctx, s.consumer.subscriptions[0], s.message(taskCreatedV1))
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), s.deadLetter.sent, 1)
+}
+
+func (s *EventConsumerSuite) TestProcess_StopsOnCancel() {
+	s.recorder.failures = 10
+	s.consumer.retry.InitialBackoff = time.Hour
+	s.consumer.retry.MaxBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(s.ctx, 20*time.Millisecond)
+	defer cancel()
+
+	err := s.consumer.process(ctx, s.consumer.subscriptions[0], s.message(taskCreatedV1))
+
+	assert.ErrorIs(s.T(), err, context.DeadlineExceeded)
+	assert.Empty(s.T(), s.deadLetter.sent)
+}
+
+func (s *EventConsumerSuite) TestProcess_HandlesEnvelope() {
+	ctx := events.WithTrace(s.ctx, map[string]string{"x-request-id": "req-1"})
+	event := domain.TaskCreatedEvent{EventID: "evt-2", TaskID: "task-2", CreatorID: "user-1", CreatedAt: time.Now()}
+	envelope, err := events.NewEncoder(s.registry, "task-service").Encode(ctx, event.EventID, domain.EventTypeTaskCreated, event)
+	s.Require().NoError(err)
+	value, _ := json.Marshal(envelope)
+
+	err = s.consumer.process(s.ctx, s.consumer.subscriptions[0], s.message(string(value)))
+
+	assert.NoError(s.T(), err)
+	s.Require().Len(s.recorder.events, 1)
+	assert.Equal(s.T(), "task-2", s.recorder.events[0].TaskID)
+	assert.Empty(s.T(), s.deadLetter.sent)
+}
+
+func (s *EventConsumerSuite) TestProcess_HandlesLegacyPayloadWithoutEventID() {
+	legacy := `{"task_id":"task-1","creator_id":"user-1","created_at":"2026-01-01T00:00:00Z"}`
+
+	err := s.consumer.process(s.ctx, s.consumer.subscriptions[0], s.message(legacy))
+
+	assert.NoError(s.T(), err)
+	s.Require().Len(s.recorder.events, 1)
+	assert.Equal(s.T(), "task-1", s.recorder.events[0].TaskID)
+	assert.Empty(s.T(), s.recorder.events[0].EventID)
+	assert.Empty(s.T(), s.deadLetter.sent)
+}
+
+func (s *EventConsumerSuite) TestProcess_DeadLettersSchemaViolation() {
+	err := s.consumer.process(s.ctx, s.consumer.subscriptions[0], s.message(`{"event_id":"evt-1","task_id":"task-1"}`))
+
+	assert.NoError(s.T(), err)
+	assert.Zero(s.T(), s.recorder.calls)
+	s.Require().Len(s.deadLetter.sent, 1)
+	assert.Contains(s.T(), headerValue(kafkago.Message{Headers: s.deadLetter.sent[0].headers}, HeaderError), "does not match schema")
+}
+
+func (s *EventConsumerSuite) TestProcess_DeadLettersUnknownVersion() {
+	value := `{"event_id":"evt-1","type":"task.created","schema_version":7,"payload":` + taskCreatedV1 + `}`
+
+	err := s.consumer.process(s.ctx, s.consumer.subscriptions[0], s.message(value))
+
+	assert.NoError(s.T(), err)
+	assert.Zero(s.T(), s.recorder.calls)
+	assert.Len(s.T(), s.deadLetter.sent, 1)
+}
+
+func (s *EventConsumerSuite) TestProcess_HandlesTaskUpdatedVersions() {
+	v1 := `{"event_id":"evt-1","task_id":"task-1","user_id":"user-1","field":"status","old_value":"todo","new_value":"done","updated_at":"2026-01-01T00:00:00Z"}`
+	v2 := `{"event_id":"evt-2","type":"task.updated","schema_version":2,"payload":{"event_id":"evt-2","task_id":"task-1","user_id":"user-1",` +
+		`"changes":[{"field":"title","old_value":"a","new_value":"b"},{"field":"priority","old_value":"low","new_value":"high"}],"updated_at":"2026-01-01T00:00:00Z"}}`
+	sub := s.consumer.subscriptions[1]
+
+	s.Require().NoError(s.consumer.process(s.ctx, sub, kafkago.Message{Topic: "task.updated", Value: []byte(v1)}))
+	s.Require().NoError(s.consumer.process(s.ctx, sub, kafkago.Message{Topic: "task.updated", Value: []byte(v2)}))
+
+	assert.Empty(s.T(), s.deadLetter.sent)
+	s.Require().Len(s.recorder.updates, 2)
+	assert.Equal(s.T(), []domain.TaskFieldChange{{Field: "status", OldValue: "todo", NewValue: "done"}}, s.recorder.updates[0].Changes)
+	assert.Equal(s.T(), "evt-1", s.recorder.updates[0].EventID)
+	assert.Len(s.T(), s.recorder.updates[1].Changes, 2)
+}
+
+func (s *EventConsumerSuite) TestProcess_HandlesTeamMemberAddedVersions() {
+	v1 := `{"event_id":"evt-1","team_id":"team-1","user_id":"user-2","role":"member","joined_at":"2026-01-01T00:00:00Z"}`
+	v2 := `{"event_id":"evt-2","type":"team.member-added","schema_version":2,"payload":{"event_id":"evt-2","actor_id":"user-1",` +
+		`"team_id":"team-1","user_id":"user-3","role":"viewer","joined_at":"2026-01-01T00:00:00Z"}}`
+	noActor := `{"event_id":"evt-3","type":"team.member-added","schema_version":2,"payload":{"event_id":"evt-3",` +
+		`"team_id":"team-1","user_id":"user-4","role":"viewer","joined_at":"2026-01-01T00:00:00Z"}}`
+	sub := s.consumer.subscriptions[2]
+
+	for _, value := range []string{v1, v2, noActor} {
+		s.Require().NoError(s.consumer.process(s.ctx, sub, kafkago.Message{Topic: "team.member-added", Value: []byte(value)}))
+	}
+
+	s.Require().Len(s.recorder.members, 2)
+	assert.Empty(s.T(), s.recorder.members[0].ActorID)
+	assert.Equal(s.T(), "user-1", s.recorder.members[1].ActorID)
+	assert.Len(s.T(), s.deadLetter.sent, 1)
+}
+
+func (s *EventConsumerSuite) TestConsume_CommitsHandledAndDeadLetteredMessages() {
+	s.reader.messages = []kafkago.Message{
+		s.message(taskCreatedV1),
+		s.message(`not json`),
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	done := make(chan struct{})
+	go func() {
+		s.consumer.consume(ctx, s.consumer.subscriptions[0])
+		close(done)
+	}()
+
+	assert.Eventually(s.T(), func() bool { return s.reader.committedCount() == 2 }, time.Second, time.Millisecond)
+	cancel()
+	<-done
+}
+
+func (s *EventConsumerSuite) TestConsume_ReportsLag() {
+	msg := s.message(taskCreatedV1)
+	msg.HighWaterMark = 50
+	s.reader.messages = []kafkago.Message{msg}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	done := make(chan struct{})
+	go func() {
+		s.consumer.consume(ctx, s.consumer.subscriptions[0])
+		close(done)
+	}()
+
+	gauge := consumerLag.WithLabelValues("task.created", "1")
+	assert.Eventually(s.T(), func() bool { return testutil.ToFloat64(gauge) == 7 }, time.Second, time.Millisecond)
+	cancel()
+	<-done
+}
+
+func (s *EventConsumerSuite) TestReportLag_RefreshesWithoutCommits() {
+	defer func(interval time.Duration) { lagInterval = interval }(lagInterval)
+	lagInterval = time.Millisecond
+	sub := s.consumer.subscriptions[0]
+	first := s.message(taskCreatedV1)
+	first.HighWaterMark = 50
+	second := s.message(taskCreatedV1)
+	second.Partition, second.Offset, second.HighWaterMark = 2, 10, 20
+	sub.lag.commit(sub.topic, first)
+	sub.lag.commit(sub.topic, second)
+
+	partition1 := consumerLag.WithLabelValues("task.created", "1")
+	partition2 := consumerLag.WithLabelValues("task.created", "2")
+	partition1.Set(0)
+	partition2.Set(0)
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	done := make(chan struct{})
+	go func() {
+		s.consumer.reportLag(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(s.T(), func() bool {
+		return testutil.ToFloat64(partition1) == 7 && testutil.ToFloat64(partition2) == 9
+	}, time.Second, time.Millisecond)
+	cancel()
+	<-done
+}
+
+func (s *EventConsumerSuite) TestRetryPolicy_Backoff() {
+	policy := NewRetryPolicy(config.ConsumerRetryConfig{InitialBackoffMs: 100, MaxBackoffMs: 500})
+
+	assert.Equal(s.T(), defaultMaxAttempts, policy.MaxAttempts)
+	assert.Equal(s.T(), 100*time.Millisecond, policy.Backoff(1))
+	assert.Equal(s.T(), 200*time.Millisecond, policy.Backoff(2))
+	assert.Equal(s.T(), 400*time.Millisecond, policy.Backoff(3))
+	assert.Equal(s.T(), 500*time.Millisecond, policy.Backoff(4))
+	assert.Equal(s.T(), 500*time.Millisecond, policy.Backoff(100))
+}
+
+func (s *EventConsumerSuite) TestReplay_RoutesToOriginalTopic() {
+	created := &fakeWriter{}
+	updated := &fakeWriter{}
+	s.reader.messages = []kafkago.Message{
+		{Key: []byte("task-1"), Value: []byte(`{"a":1}`), Headers: []kafkago.Header{{Key: HeaderOriginalTopic, Value: []byte("task.created")}}},
+		{Key: []byte("task-2"), Value: []byte(`{"b":2}`), Headers: []kafkago.Header{{Key: HeaderOriginalTopic, Value: []byte("task.updated")}}},
+	}
+	replayer := NewReplayer(s.reader, map[string]MessageWriter{"task.created": created, "task.updated": updated})
+
+	replayed, err := replayer.Replay(s.ctx, ReplayOptions{IdleTimeout: 10 * time.Millisecond})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 2, replayed)
+	s.Require().Len(created.sent, 1)
+	s.Require().Len(updated.sent, 1)
+	assert.Equal(s.T(), "task-1", created.sent[0].key)
+	assert.Equal(s.T(), `{"b":2}`, string(updated.sent[0].value))
+	assert.Equal(s.T(), 2, s.reader.committedCount())
+}
+
+func (s *EventConsumerSuite) TestReplay_DryRunLeavesOffsets() {
+	created := &fakeWriter{}
+	s.reader.messages = []kafkago.Message{
+		{Key: []byte("task-1"), Headers: []kafkago.Header{{Key: HeaderOriginalTopic, Value: []byte("task.created")}}},
+	}
+	replayer := NewReplayer(s.reader, map[string]MessageWriter{"task.created": created})
+
+	replayed, err := replayer.Replay(s.ctx, ReplayOptions{DryRun: true, IdleTimeout: 10 * time.Millisecond})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, replayed)
+	assert.Empty(s.T(), created.sent)
+	assert.Zero(s.T(), s.reader.committedCount())
+}
+
+func (s *EventConsumerSuite) TestReplay_UnknownTopic() {
+	s.reader.messages = []kafkago.Message{
+		{Key: []byte("task-1"), Headers: []kafkago.Header{{Key: HeaderOriginalTopic, Value: []byte("unknown")}}},
+	}
+	replayer := NewReplayer(s.reader, map[string]MessageWriter{})
+
+	replayed, err := replayer.Replay(s.ctx, ReplayOptions{IdleTimeout: 10 * time.Millisecond})
+
+	assert.Error(s.T(), err)
+	assert.Zero(s.T(), replayed)
+	assert.Zero(s.T(), s.reader.committedCount())
+}
+
+func (s *EventConsumerSuite) TestReplay_RespectsLimit() {
+	created := &fakeWriter{}
+	header := []kafkago.Header{{Key: HeaderOriginalTopic, Value: []byte("task.created")}}
+	s.reader.messages = []kafkago.Message{{Headers: header}, {Headers: header}, {Headers: header}}
+	replayer := NewReplayer(s.reader, map[string]MessageWriter{"task.created": created})
+
+	replayed, err := replayer.Replay(s.ctx, ReplayOptions{Limit: 2, IdleTimeout: 10 * time.Millisecond})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 2, replayed)
+	assert.Len(s.T(), created.sent, 2)
+}
+
+func TestEventConsumerSuite(t *testing.T) {
+	suite.Run(t, new(EventConsumerSuite))
+}
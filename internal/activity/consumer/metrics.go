@@ -0,0 +1,11 @@
+package consumer
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var consumerLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "activity_consumer_lag_messages",
+	Help: "Messages in a topic partition after the last one committed by the activity consumer.",
+}, []string{"topic", "partition"})
@@ -0,0 +1,113 @@
+package consumer
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	kafkago "github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+// Headers attached to every dead-lettered message next to the original key and payload.
+const (
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderError             = "x-error"
+	HeaderAttempts          = "x-attempts"
+	HeaderFailedAt          = "x-failed-at"
+	HeaderReplayedAt        = "x-replayed-at"
+)
+
+const defaultIdleTimeout = 5 * time.Second
+
+type ReplayOptions struct {
+	// Limit caps the number of messages handled; zero means no limit.
+	Limit int
+	// DryRun only logs the messages and leaves the DLQ offsets untouched.
+	DryRun bool
+	// IdleTimeout is how long to wait for the next message before assuming
+	// the dead-letter topic is drained.
+	IdleTimeout time.Duration
+}
+
+// Replayer moves dead-lettered messages back to the topics they came from.
+type Replayer struct {
+	reader  MessageReader
+	writers map[string]MessageWriter
+}
+
+// NewReplayer takes a reader on the dead-letter topic and a writer for each
+// main topic, keyed by topic name.
+func NewReplayer(reader MessageReader, writers map[string]MessageWriter) *Replayer {
+	return &Replayer{
+		reader:  reader,
+		writers: writers,
+	}
+}
+
+// Replay returns the number of messages replayed (or, in dry-run mode, seen).
+func (r *Replayer) Replay(ctx context.Context, opts ReplayOptions) (int, error) {
+	if opts.IdleTimeout <= 0 {
+		opts.IdleTimeout = defaultIdleTimeout
+	}
+
+	replayed := 0
+	for opts.Limit <= 0 || replayed < opts.Limit {
+		fetchCtx, cancel := context.WithTimeout(ctx, opts.IdleTimeout)
+		msg, err := r.reader.Fetch(fetchCtx)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return replayed, ctx.Err()
+			}
+			if errors.Is(err, context.DeadlineExceeded) {
+				return replayed, nil
+			}
+			return replayed, errors.Wrap(err, "failed to read dead-letter topic")
+		}
+
+		topic := headerValue(msg, HeaderOriginalTopic)
+		writer, ok := r.writers[topic]
+		if !ok {
+			return replayed, errors.Errorf("dead-letter message at offset %d has unknown original topic %q", msg.Offset, topic)
+		}
+
+		fields := []zap.Field{
+			zap.String("topic", topic),
+			zap.String("key", string(msg.Key)),
+			zap.String("error", headerValue(msg, HeaderError)),
+			zap.String("attempts", headerValue(msg, HeaderAttempts)),
+		}
+		if opts.DryRun {
+			logger.Info("dead-letter message (dry run)", fields...)
+			replayed++
+			continue
+		}
+
+		replayedAt := kafkago.Header{Key: HeaderReplayedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339))}
+		if err := writer.PublishBytes(ctx, string(msg.Key), msg.Value, replayedAt); err != nil {
+			return replayed, errors.Wrapf(err, "failed to replay message to %s", topic)
+		}
+		if err := r.reader.Commit(ctx, msg); err != nil {
+			return replayed, errors.Wrap(err, "failed to commit dead-letter offset")
+		}
+
+		logger.Info("dead-letter message replayed", fields...)
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+func headerValue(msg kafkago.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+package consumer
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Sol1tud9/taskflow/pkg/config"
+)
+
+const (
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = 200 * time.Millisecond
+	defaultMaxBackoff     = 10 * time.Second
+)
+
+// RetryPolicy controls how often a failing message is retried before it is
+// moved to the dead-letter topic.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+func NewRetryPolicy(cfg config.ConsumerRetryConfig) RetryPolicy {
+	p := RetryPolicy{
+		MaxAttempts:    cfg.MaxAttempts,
+		InitialBackoff: time.Duration(cfg.InitialBackoffMs) * time.Millisecond,
+		MaxBackoff:     time.Duration(cfg.MaxBackoffMs) * time.Millisecond,
+	}
+
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaultMaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = defaultInitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = defaultMaxBackoff
+	}
+
+	return p
+}
+
+// Backoff returns the delay after the given failed attempt (1-based):
+// InitialBackoff doubled per attempt, capped at MaxBackoff.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	shift := attempt - 1
+	if shift < 0 {
+		shift = 0
+	}
+	if shift > 16 {
+		shift = 16
+	}
+
+	delay := p.InitialBackoff << shift
+	if delay <= 0 || delay > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return delay
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// permanent marks err as not worth retrying.
+func permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+func isPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// sleep waits for d and reports false if ctx was cancelled first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,25 @@
+package consumer
+
+import (
+	"context"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+)
+
+// upgradeTaskUpdatedV1 lets the current recorder consume task.updated v1
+// events, each of which carries a single field change.
+func upgradeTaskUpdatedV1(record func(ctx context.Context, event domain.TaskUpdatedEvent) error) func(ctx context.Context, event domain.TaskUpdatedEventV1) error {
+	return func(ctx context.Context, event domain.TaskUpdatedEventV1) error {
+		return record(ctx, domain.TaskUpdatedEvent{
+			EventID: event.EventID,
+			TaskID:  event.TaskID,
+			UserID:  event.UserID,
+			Changes: []domain.TaskFieldChange{{
+				Field:    event.Field,
+				OldValue: event.OldValue,
+				NewValue: event.NewValue,
+			}},
+			UpdatedAt: event.UpdatedAt,
+		})
+	}
+}
@@ -24,27 +24,27 @@ func (m *ActivityRepository) Create(ctx context.Context, activity *domain.Activi
 	return args.Error(0)
 }
 
-func (m *ActivityRepository) GetByUserID(ctx context.Context, userID string, filter activityUsecase.ActivityFilter) ([]*domain.Activity, int, error) {
+func (m *ActivityRepository) GetByUserID(ctx context.Context, userID string, filter activityUsecase.ActivityFilter) (*domain.ActivityPage, error) {
 	args := m.Called(ctx, userID, filter)
 	if args.Get(0) == nil {
-		return nil, args.Get(1).(int), args.Error(2)
+		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*domain.Activity), args.Get(1).(int), args.Error(2)
+	return args.Get(0).(*domain.ActivityPage), args.Error(1)
 }
 
-func (m *ActivityRepository) GetByEntity(ctx context.Context, entityType, entityID string, filter activityUsecase.ActivityFilter) ([]*domain.Activity, int, error) {
+func (m *ActivityRepository) GetByEntity(ctx context.Context, entityType, entityID string, filter activityUsecase.ActivityFilter) (*domain.ActivityPage, error) {
 	args := m.Called(ctx, entityType, entityID, filter)
 	if args.Get(0) == nil {
-		return nil, args.Get(1).(int), args.Error(2)
+		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*domain.Activity), args.Get(1).(int), args.Error(2)
+	return args.Get(0).(*domain.ActivityPage), args.Error(1)
 }
 
-func (m *ActivityRepository) GetAll(ctx context.Context, filter activityUsecase.ActivityFilter) ([]*domain.Activity, int, error) {
+func (m *ActivityRepository) GetAll(ctx context.Context, filter activityUsecase.ActivityFilter) (*domain.ActivityPage, error) {
 	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
-		return nil, args.Get(1).(int), args.Error(2)
+		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*domain.Activity), args.Get(1).(int), args.Error(2)
+	return args.Get(0).(*domain.ActivityPage), args.Error(1)
 }
 
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Sol1tud9/taskflow/internal/pb/activity_api"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// NewHTTPHandler exposes the ActivityService RPCs as REST endpoints through the
+// generated grpc-gateway handlers, calling the server in-process.
+func NewHTTPHandler(ctx context.Context, srv activity_api.ActivityServiceServer) (http.Handler, error) {
+	gwMux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+	)
+
+	if err := activity_api.RegisterActivityServiceHandlerServer(ctx, gwMux, srv); err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", gwMux)
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte("OK")); err != nil {
+			return
+		}
+	})
+
+	return mux, nil
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/pb/models"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func toActivitiesPB(activities []*domain.Activity) []*models.Activity {
+	result := make([]*models.Activity, 0, len(activities))
+	for _, a := range activities {
+		result = append(result, &models.Activity{
+			Id:         a.ID,
+			UserId:     a.UserID,
+			EntityType: string(a.EntityType),
+			EntityId:   a.EntityID,
+			Action:     string(a.Action),
+			Metadata:   a.Metadata,
+			CreatedAt:  toUnix(a.CreatedAt),
+		})
+	}
+	return result
+}
+
+func toShardIDsPB(shardIDs []int) []int32 {
+	if len(shardIDs) == 0 {
+		return nil
+	}
+	result := make([]int32, 0, len(shardIDs))
+	for _, id := range shardIDs {
+		result = append(result, int32(id))
+	}
+	return result
+}
+
+func toUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidCursor):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
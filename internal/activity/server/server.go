@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+
+	"github.com/Sol1tud9/taskflow/internal/activity/usecase"
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/pb/activity_api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultListLimit = 20
+
+type ActivityUseCase interface {
+	GetUserActivities(ctx context.Context, userID string, filter usecase.ActivityFilter) (*domain.ActivityPage, error)
+	GetActivities(ctx context.Context, entityType, entityID string, filter usecase.ActivityFilter) (*domain.ActivityPage, error)
+}
+
+type Server struct {
+	activity_api.UnimplementedActivityServiceServer
+	activityUC ActivityUseCase
+}
+
+func NewServer(activityUC ActivityUseCase) *Server {
+	return &Server{
+		activityUC: activityUC,
+	}
+}
+
+func (s *Server) GetActivities(ctx context.Context, req *activity_api.GetActivitiesRequest) (*activity_api.GetActivitiesResponse, error) {
+	if (req.GetEntityType() == "") != (req.GetEntityId() == "") {
+		return nil, status.Error(codes.InvalidArgument, "entity_type and entity_id must be set together")
+	}
+
+	page, err := s.activityUC.GetActivities(ctx, req.GetEntityType(), req.GetEntityId(), usecase.ActivityFilter{
+		FromTimestamp: req.GetFromTimestamp(),
+		ToTimestamp:   req.GetToTimestamp(),
+		Limit:         normalizeLimit(req.GetLimit()),
+		Offset:        int(req.GetOffset()),
+		Cursor:        req.GetCursor(),
+		AllowPartial:  req.GetAllowPartial(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &activity_api.GetActivitiesResponse{
+		Activities:        toActivitiesPB(page.Activities),
+		Total:             int32(page.Total),
+		NextCursor:        page.NextCursor,
+		UnavailableShards: toShardIDsPB(page.UnavailableShards),
+	}, nil
+}
+
+func (s *Server) GetUserActivities(ctx context.Context, req *activity_api.GetUserActivitiesRequest) (*activity_api.GetUserActivitiesResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	page, err := s.activityUC.GetUserActivities(ctx, req.GetUserId(), usecase.ActivityFilter{
+		FromTimestamp: req.GetFromTimestamp(),
+		ToTimestamp:   req.GetToTimestamp(),
+		Limit:         normalizeLimit(req.GetLimit()),
+		Offset:        int(req.GetOffset()),
+		Cursor:        req.GetCursor(),
+		AllowPartial:  req.GetAllowPartial(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &activity_api.GetUserActivitiesResponse{
+		Activities:        toActivitiesPB(page.Activities),
+		Total:             int32(page.Total),
+		NextCursor:        page.NextCursor,
+		UnavailableShards: toShardIDsPB(page.UnavailableShards),
+	}, nil
+}
+
+func normalizeLimit(limit int32) int {
+	if limit <= 0 {
+		return defaultListLimit
+	}
+	return int(limit)
+}
@@ -0,0 +1,172 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	repoMocks "github.com/Sol1tud9/taskflow/internal/activity/repository/mocks"
+	activityServer "github.com/Sol1tud9/taskflow/internal/activity/server"
+	activityUsecase "github.com/Sol1tud9/taskflow/internal/activity/usecase"
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/pb/activity_api"
+)
+
+type ActivityServerSuite struct {
+	suite.Suite
+	ctx          context.Context
+	activityRepo *repoMocks.ActivityRepository
+	server       *activityServer.Server
+	grpcServer   *grpc.Server
+	conn         *grpc.ClientConn
+	client       activity_api.ActivityServiceClient
+}
+
+func (s *ActivityServerSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.activityRepo = repoMocks.NewActivityRepository(s.T())
+	s.server = activityServer.NewServer(activityUsecase.NewActivityUseCase(s.activityRepo))
+
+	lis := bufconn.Listen(1024 * 1024)
+	s.grpcServer = grpc.NewServer()
+	activity_api.RegisterActivityServiceServer(s.grpcServer, s.server)
+	go func() {
+		_ = s.grpcServer.Serve(lis)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	s.Require().NoError(err)
+	s.conn = conn
+	s.client = activity_api.NewActivityServiceClient(conn)
+}
+
+func (s *ActivityServerSuite) TearDownTest() {
+	_ = s.conn.Close()
+	s.grpcServer.Stop()
+}
+
+func (s *ActivityServerSuite) TestGetActivities_ByEntity() {
+	taskID := uuid.New().String()
+	filter := activityUsecase.ActivityFilter{Limit: 20}
+
+	s.activityRepo.On("GetByEntity", mock.Anything, "task", taskID, filter).Return(&domain.ActivityPage{
+		Activities: []*domain.Activity{
+			{ID: uuid.New().String(), EntityType: domain.EntityTypeTask, EntityID: taskID, Action: domain.ActionTypeCreated, CreatedAt: time.Now()},
+		},
+		Total: 1,
+	}, nil)
+
+	resp, err := s.client.GetActivities(s.ctx, &activity_api.GetActivitiesRequest{EntityType: "task", EntityId: taskID})
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), resp.GetActivities(), 1)
+	assert.Equal(s.T(), "created", resp.GetActivities()[0].GetAction())
+	assert.Equal(s.T(), int32(1), resp.GetTotal())
+}
+
+func (s *ActivityServerSuite) TestGetActivities_PartialEntity() {
+	_, err := s.client.GetActivities(s.ctx, &activity_api.GetActivitiesRequest{EntityType: "task"})
+
+	assert.Equal(s.T(), codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ActivityServerSuite) TestGetActivities_StorageError() {
+	s.activityRepo.On("GetAll", mock.Anything, mock.Anything).Return(nil, errors.New("shard unavailable"))
+
+	_, err := s.client.GetActivities(s.ctx, &activity_api.GetActivitiesRequest{})
+
+	assert.Equal(s.T(), codes.Internal, status.Code(err))
+}
+
+func (s *ActivityServerSuite) TestGetActivities_Cursor() {
+	filter := activityUsecase.ActivityFilter{Limit: 20, Offset: 40, Cursor: "page-2"}
+
+	s.activityRepo.On("GetAll", mock.Anything, filter).Return(&domain.ActivityPage{Total: 60, NextCursor: "page-3"}, nil)
+
+	resp, err := s.client.GetActivities(s.ctx, &activity_api.GetActivitiesRequest{Offset: 40, Cursor: "page-2"})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "page-3", resp.GetNextCursor())
+}
+
+func (s *ActivityServerSuite) TestGetActivities_PartialResults() {
+	filter := activityUsecase.ActivityFilter{Limit: 20, AllowPartial: true}
+
+	s.activityRepo.On("GetAll", mock.Anything, filter).Return(&domain.ActivityPage{Total: 3, UnavailableShards: []int{1}}, nil)
+
+	resp, err := s.client.GetActivities(s.ctx, &activity_api.GetActivitiesRequest{AllowPartial: true})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []int32{1}, resp.GetUnavailableShards())
+}
+
+func (s *ActivityServerSuite) TestGetActivities_InvalidCursor() {
+	s.activityRepo.On("GetAll", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidCursor)
+
+	_, err := s.client.GetActivities(s.ctx, &activity_api.GetActivitiesRequest{Cursor: "garbage"})
+
+	assert.Equal(s.T(), codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ActivityServerSuite) TestGetUserActivities_MissingUserID() {
+	_, err := s.client.GetUserActivities(s.ctx, &activity_api.GetUserActivitiesRequest{})
+
+	assert.Equal(s.T(), codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ActivityServerSuite) TestHTTPGateway_GetUserActivities() {
+	userID := uuid.New().String()
+	filter := activityUsecase.ActivityFilter{Limit: 5, Offset: 10}
+
+	s.activityRepo.On("GetByUserID", mock.Anything, userID, filter).Return(&domain.ActivityPage{
+		Activities: []*domain.Activity{
+			{ID: uuid.New().String(), UserID: userID, EntityType: domain.EntityTypeUser, EntityID: userID, Action: domain.ActionTypeUpdated, CreatedAt: time.Now()},
+		},
+		Total: 11,
+	}, nil)
+
+	handler, err := activityServer.NewHTTPHandler(s.ctx, s.server)
+	s.Require().NoError(err)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/"+userID+"/activities?limit=5&offset=10", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(s.T(), http.StatusOK, rec.Code)
+
+	var body struct {
+		Activities []struct {
+			UserID string `json:"user_id"`
+			Action string `json:"action"`
+		} `json:"activities"`
+		Total int `json:"total"`
+	}
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(s.T(), 11, body.Total)
+	assert.Len(s.T(), body.Activities, 1)
+	assert.Equal(s.T(), userID, body.Activities[0].UserID)
+}
+
+func TestActivityServerSuite(t *testing.T) {
+	suite.Run(t, new(ActivityServerSuite))
+}
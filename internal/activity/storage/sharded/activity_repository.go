@@ -2,22 +2,26 @@ package sharded
 
 import (
 	"context"
-	"sort"
 	"time"
 
 	"github.com/Masterminds/squirrel"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pkg/errors"
 	activityUsecase "github.com/Sol1tud9/taskflow/internal/activity/usecase"
 	"github.com/Sol1tud9/taskflow/internal/domain"
 )
 
+// Create writes to the shard owning the user's bucket, and also to the target
+// shard while that bucket is being moved. The entity index is updated first.
 func (s *ShardedStorage) Create(ctx context.Context, activity *domain.Activity) error {
-	shard := s.GetShardForUser(activity.UserID)
+	if err := s.indexEntity(ctx, activity); err != nil {
+		return err
+	}
 
 	query := squirrel.Insert("activities").
-		Columns("id", "user_id", "entity_type", "entity_id", "action", "metadata", "created_at").
-		Values(activity.ID, activity.UserID, activity.EntityType, activity.EntityID, activity.Action, activity.Metadata, activity.CreatedAt).
+		Columns("id", "user_id", "entity_type", "entity_id", "action", "metadata", "created_at", "bucket_id").
+		Values(activity.ID, activity.UserID, activity.EntityType, activity.EntityID, activity.Action, activity.Metadata, activity.CreatedAt,
+			s.getBucketForUser(activity.UserID)).
+		Suffix("ON CONFLICT (id, created_at) DO NOTHING").
 		PlaceholderFormat(squirrel.Dollar)
 
 	sql, args, err := query.ToSql()
@@ -25,120 +29,130 @@ func (s *ShardedStorage) Create(ctx context.Context, activity *domain.Activity)
 		return errors.Wrap(err, "failed to build query")
 	}
 
-	if _, err := shard.Exec(ctx, sql, args...); err != nil {
-		return errors.Wrap(err, "failed to create activity")
+	for _, shard := range s.getWriteShardsForUser(activity.UserID) {
+		if _, err := shard.Exec(ctx, sql, args...); err != nil {
+			return errors.Wrap(err, "failed to create activity")
+		}
 	}
 
 	return nil
 }
 
-func (s *ShardedStorage) GetByUserID(ctx context.Context, userID string, filter activityUsecase.ActivityFilter) ([]*domain.Activity, int, error) {
-	shard := s.GetShardForUser(userID)
-
-	return s.queryActivities(ctx, shard, squirrel.Eq{"user_id": userID}, filter)
+func (s *ShardedStorage) GetByUserID(ctx context.Context, userID string, filter activityUsecase.ActivityFilter) (*domain.ActivityPage, error) {
+	return s.scatter(ctx, "get_by_user", []int{s.getShardIDForUser(userID)}, squirrel.Eq{"user_id": userID}, filter)
 }
 
-func (s *ShardedStorage) GetByEntity(ctx context.Context, entityType, entityID string, filter activityUsecase.ActivityFilter) ([]*domain.Activity, int, error) {
-	var allActivities []*domain.Activity
-	var totalCount int
-
-	for _, shard := range s.GetAllShards() {
-		activities, count, err := s.queryActivities(ctx, shard, squirrel.And{
-			squirrel.Eq{"entity_type": entityType},
-			squirrel.Eq{"entity_id": entityID},
-		}, filter)
-		if err != nil {
-			return nil, 0, err
-		}
-		allActivities = append(allActivities, activities...)
-		totalCount += count
-	}
+func (s *ShardedStorage) GetByEntity(ctx context.Context, entityType, entityID string, filter activityUsecase.ActivityFilter) (*domain.ActivityPage, error) {
+	return s.scatter(ctx, "get_by_entity", s.entityShardIDs(ctx, entityType, entityID), squirrel.And{
+		squirrel.Eq{"entity_type": entityType},
+		squirrel.Eq{"entity_id": entityID},
+	}, filter)
+}
 
-	return allActivities, totalCount, nil
+func (s *ShardedStorage) GetAll(ctx context.Context, filter activityUsecase.ActivityFilter) (*domain.ActivityPage, error) {
+	return s.scatter(ctx, "get_all", s.shardIDs(), squirrel.Expr("1=1"), filter)
 }
 
-func (s *ShardedStorage) GetAll(ctx context.Context, filter activityUsecase.ActivityFilter) ([]*domain.Activity, int, error) {
-	var allActivities []*domain.Activity
-	var totalCount int
+// scatter queries the given shards in parallel (see gather) and merges their
+// rows into one page ordered by (created_at, id) descending.
+//
+// Every shard is asked for offset+limit+1 rows past its cursor position, which
+// is enough to fill the page whichever shards the rows come from. A cursor
+// replaces the offset.
+func (s *ShardedStorage) scatter(ctx context.Context, name string, shardIDs []int, where squirrel.Sqlizer, filter activityUsecase.ActivityFilter) (*domain.ActivityPage, error) {
+	prev, err := decodeCursor(filter.Cursor)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, shard := range s.GetAllShards() {
-		activities, count, err := s.queryActivities(ctx, shard, squirrel.Expr("1=1"), filter)
-		if err != nil {
-			return nil, 0, err
-		}
-		allActivities = append(allActivities, activities...)
-		totalCount += count
+	offset := filter.Offset
+	if prev != nil {
+		offset = 0
+	}
+	fetch := 0
+	if filter.Limit > 0 {
+		fetch = offset + filter.Limit + 1
 	}
 
-	sort.Slice(allActivities, func(i, j int) bool {
-		return allActivities[i].CreatedAt.After(allActivities[j].CreatedAt)
+	pages, unavailable, err := s.gather(ctx, name, shardIDs, filter.AllowPartial, func(ctx context.Context, shardID int) (shardPage, error) {
+		return s.queryShard(ctx, shardID, where, filter, prev, fetch)
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	if filter.Limit > 0 && len(allActivities) > filter.Limit {
-		allActivities = allActivities[:filter.Limit]
+	activities, next := mergePages(pages, prev, offset, filter.Limit)
+
+	page := &domain.ActivityPage{Activities: activities, UnavailableShards: unavailable}
+	for _, p := range pages {
+		page.Total += p.total
+	}
+	if next != nil {
+		page.NextCursor = next.encode()
 	}
 
-	return allActivities, totalCount, nil
+	return page, nil
 }
 
-func (s *ShardedStorage) queryActivities(ctx context.Context, shard *pgxpool.Pool, where squirrel.Sqlizer, filter activityUsecase.ActivityFilter) ([]*domain.Activity, int, error) {
-	query := squirrel.Select("id", "user_id", "entity_type", "entity_id", "action", "metadata", "created_at").
+func (s *ShardedStorage) queryShard(ctx context.Context, shardID int, where squirrel.Sqlizer, filter activityUsecase.ActivityFilter, prev *cursor, fetch int) (shardPage, error) {
+	page := shardPage{shardID: shardID}
+	shard := s.shards[shardID]
+
+	query := squirrel.Select(activityColumns...).
 		From("activities").
 		Where(where).
-		OrderBy("created_at DESC").
+		Where(timeRange(filter)).
+		OrderBy("created_at DESC", "id DESC").
 		PlaceholderFormat(squirrel.Dollar)
 
-	if filter.FromTimestamp > 0 {
-		query = query.Where(squirrel.GtOrEq{"created_at": time.Unix(filter.FromTimestamp, 0)})
+	if after, ok := prev.after(shardID); ok {
+		// The plain bound on created_at lets the planner prune partitions
+		// newer than the cursor; the row comparison alone does not.
+		query = query.Where(squirrel.LtOrEq{"created_at": after.CreatedAt}).
+			Where("(created_at, id) < (?, ?)", after.CreatedAt, after.ID)
 	}
-	if filter.ToTimestamp > 0 {
-		query = query.Where(squirrel.LtOrEq{"created_at": time.Unix(filter.ToTimestamp, 0)})
-	}
-	if filter.Limit > 0 {
-		query = query.Limit(uint64(filter.Limit))
-	}
-	if filter.Offset > 0 {
-		query = query.Offset(uint64(filter.Offset))
-	}
-
-	sql, args, err := query.ToSql()
-	if err != nil {
-		return nil, 0, errors.Wrap(err, "failed to build query")
+	if fetch > 0 {
+		query = query.Limit(uint64(fetch))
 	}
 
-	rows, err := shard.Query(ctx, sql, args...)
+	activities, err := selectActivities(ctx, shard, query)
 	if err != nil {
-		return nil, 0, errors.Wrap(err, "failed to query activities")
+		return page, err
 	}
-	defer rows.Close()
+	page.activities = activities
+	page.full = fetch > 0 && len(activities) == fetch
 
-	var activities []*domain.Activity
-	for rows.Next() {
-		var a domain.Activity
-		if err := rows.Scan(&a.ID, &a.UserID, &a.EntityType, &a.EntityID, &a.Action, &a.Metadata, &a.CreatedAt); err != nil {
-			return nil, 0, errors.Wrap(err, "failed to scan activity")
-		}
-		activities = append(activities, &a)
-	}
-
-	countQuery := squirrel.Select("COUNT(*)").From("activities").Where(where).PlaceholderFormat(squirrel.Dollar)
-	if filter.FromTimestamp > 0 {
-		countQuery = countQuery.Where(squirrel.GtOrEq{"created_at": time.Unix(filter.FromTimestamp, 0)})
-	}
-	if filter.ToTimestamp > 0 {
-		countQuery = countQuery.Where(squirrel.LtOrEq{"created_at": time.Unix(filter.ToTimestamp, 0)})
+	countQuery := squirrel.Select("COUNT(*)").
+		From("activities").
+		Where(where).
+		Where(timeRange(filter)).
+		PlaceholderFormat(squirrel.Dollar)
+	// Rows of a bucket being moved sit on both shards. mergePages drops the
+	// duplicate rows; the count leaves out the copy on the shard that does
+	// not serve the bucket's reads.
+	if stray := s.buckets.strayBuckets(shardID); len(stray) > 0 {
+		countQuery = countQuery.Where("COALESCE(bucket_id, -1) <> ALL(?)", stray)
 	}
 
 	countSQL, countArgs, err := countQuery.ToSql()
 	if err != nil {
-		return nil, 0, errors.Wrap(err, "failed to build count query")
+		return page, errors.Wrap(err, "failed to build count query")
 	}
 
-	var total int
-	if err := shard.QueryRow(ctx, countSQL, countArgs...).Scan(&total); err != nil {
-		return nil, 0, errors.Wrap(err, "failed to count activities")
+	if err := shard.QueryRow(ctx, countSQL, countArgs...).Scan(&page.total); err != nil {
+		return page, errors.Wrap(err, "failed to count activities")
 	}
 
-	return activities, total, nil
+	return page, nil
 }
 
+func timeRange(filter activityUsecase.ActivityFilter) squirrel.And {
+	conds := squirrel.And{}
+	if filter.FromTimestamp > 0 {
+		conds = append(conds, squirrel.GtOrEq{"created_at": time.Unix(filter.FromTimestamp, 0)})
+	}
+	if filter.ToTimestamp > 0 {
+		conds = append(conds, squirrel.LtOrEq{"created_at": time.Unix(filter.ToTimestamp, 0)})
+	}
+	return conds
+}
@@ -0,0 +1,176 @@
+package sharded
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+// StreamActivities passes the activities of one shard created in [from, to)
+// to fn, batchSize at a time. It reads the live table and then the archived
+// partitions overlapping the range, each in (created_at, id) order.
+func (s *ShardedStorage) StreamActivities(ctx context.Context, shardID int, from, to time.Time, batchSize int, fn func([]*domain.Activity) error) error {
+	if batchSize <= 0 {
+		batchSize = defaultRebalanceBatch
+	}
+	shard := s.shards[shardID]
+
+	archived, err := archivedPartitions(ctx, shard)
+	if err != nil {
+		return err
+	}
+
+	tables := []string{"activities"}
+	for _, name := range archived {
+		if month, ok := parsePartitionMonth(name); ok && month.Before(to) && month.AddDate(0, 1, 0).After(from) {
+			tables = append(tables, pgx.Identifier{archiveSchema, name}.Sanitize())
+		}
+	}
+
+	for _, table := range tables {
+		if err := streamTable(ctx, shard, table, from, to, batchSize, fn); err != nil {
+			return errors.Wrapf(err, "failed to export %s", table)
+		}
+	}
+
+	return nil
+}
+
+func streamTable(ctx context.Context, shard *pgxpool.Pool, table string, from, to time.Time, batchSize int, fn func([]*domain.Activity) error) error {
+	var (
+		afterTime time.Time
+		afterID   string
+	)
+	for {
+		query := squirrel.Select(activityColumns...).
+			From(table).
+			Where(squirrel.GtOrEq{"created_at": from}).
+			Where(squirrel.Lt{"created_at": to}).
+			OrderBy("created_at", "id").
+			Limit(uint64(batchSize)).
+			PlaceholderFormat(squirrel.Dollar)
+		if afterID != "" {
+			query = query.Where(squirrel.GtOrEq{"created_at": afterTime}).
+				Where("(created_at, id) > (?, ?)", afterTime, afterID)
+		}
+
+		activities, err := selectActivities(ctx, shard, query)
+		if err != nil {
+			return err
+		}
+		if len(activities) == 0 {
+			return nil
+		}
+		if err := fn(activities); err != nil {
+			return err
+		}
+
+		last := activities[len(activities)-1]
+		afterTime, afterID = last.CreatedAt, last.ID
+	}
+}
+
+// ImportActivities writes restored activities to the shards that own their
+// buckets now, which need not be the shards they were exported from, and
+// indexes them. Rows that already exist are skipped.
+func (s *ShardedStorage) ImportActivities(ctx context.Context, activities []*domain.Activity) (int64, error) {
+	byShard := make(map[int][]*domain.Activity)
+	seen := make(map[indexEntry]struct{})
+	var entries []indexEntry
+
+	for _, a := range activities {
+		bucketID := s.getBucketForUser(a.UserID)
+		for _, shardID := range s.buckets.get(bucketID).writeShards() {
+			byShard[shardID] = append(byShard[shardID], a)
+		}
+
+		entry := indexEntry{entity: entityKey{entityType: string(a.EntityType), entityID: a.EntityID}, bucket: bucketID}
+		if _, ok := seen[entry]; !ok {
+			seen[entry] = struct{}{}
+			entries = append(entries, entry)
+		}
+	}
+
+	if err := s.insertIndexEntries(ctx, entries); err != nil {
+		return 0, err
+	}
+
+	var imported int64
+	for shardID, batch := range byShard {
+		n, err := s.insertActivities(ctx, s.shards[shardID], batch)
+		if err != nil {
+			return imported, errors.Wrapf(err, "shard %d", shardID)
+		}
+		imported += n
+	}
+
+	return imported, nil
+}
+
+// PreparePartitions creates the monthly partitions covering [from, to) on
+// every shard before an import. A month whose rows already sit in
+// activities_default cannot get its own partition; its rows are then
+// restored into the default partition.
+func (s *ShardedStorage) PreparePartitions(ctx context.Context, from, to time.Time) {
+	for shardID, shard := range s.shards {
+		for month := monthStart(from); month.Before(to); month = month.AddDate(0, 1, 0) {
+			if err := createPartition(ctx, shard, month); err != nil {
+				logger.Warn("restoring into the default partition",
+					zap.Int("shard", shardID), zap.String("partition", partitionName(month)), zap.Error(err))
+			}
+		}
+	}
+}
+
+// DropArchivedPartitions drops the archived partitions of one shard that lie
+// entirely within [from, to), once they have been exported.
+func (s *ShardedStorage) DropArchivedPartitions(ctx context.Context, shardID int, from, to time.Time) ([]string, error) {
+	shard := s.shards[shardID]
+
+	archived, err := archivedPartitions(ctx, shard)
+	if err != nil {
+		return nil, err
+	}
+
+	var dropped []string
+	for _, name := range archived {
+		month, ok := parsePartitionMonth(name)
+		if !ok || month.Before(from) || month.AddDate(0, 1, 0).After(to) {
+			continue
+		}
+		if _, err := shard.Exec(ctx, `DROP TABLE `+pgx.Identifier{archiveSchema, name}.Sanitize()); err != nil {
+			return dropped, errors.Wrapf(err, "failed to drop archived partition %s", name)
+		}
+		dropped = append(dropped, name)
+	}
+
+	return dropped, nil
+}
+
+func archivedPartitions(ctx context.Context, shard *pgxpool.Pool) ([]string, error) {
+	rows, err := shard.Query(ctx,
+		`SELECT table_name FROM information_schema.tables WHERE table_schema = $1 ORDER BY table_name`, archiveSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list archived partitions")
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, errors.Wrap(err, "failed to scan archived partition")
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
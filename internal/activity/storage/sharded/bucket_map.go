@@ -0,0 +1,193 @@
+package sharded
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+// metadataShard holds the activity_bucket_map table. The table is the source
+// of truth for routing; sharding.bucket_mapping in the config only seeds it.
+const metadataShard = 0
+
+const defaultMappingRefresh = 5 * time.Second
+
+// noShard marks an unset MigratingTo / DrainingFrom.
+const noShard = -1
+
+// BucketState records which shard owns a bucket. While a bucket is being
+// moved, MigratingTo is the target shard and writes go to both shards; reads
+// stay on ShardID until the mapping is flipped. After the flip DrainingFrom
+// names the old shard until its copy of the rows is deleted.
+type BucketState struct {
+	ShardID      int
+	MigratingTo  int
+	DrainingFrom int
+}
+
+func (b BucketState) Migrating() bool { return b.MigratingTo != noShard }
+
+func (b BucketState) Draining() bool { return b.DrainingFrom != noShard }
+
+func (b BucketState) writeShards() []int {
+	if b.Migrating() {
+		return []int{b.ShardID, b.MigratingTo}
+	}
+	return []int{b.ShardID}
+}
+
+type bucketMap struct {
+	mu     sync.RWMutex
+	states map[int]BucketState
+}
+
+func (m *bucketMap) get(bucketID int) BucketState {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.states[bucketID]
+}
+
+// strayBuckets returns, in order, the buckets whose rows shardID holds
+// copies of without serving their reads: buckets being copied to it and
+// buckets still draining from it.
+func (m *bucketMap) strayBuckets(shardID int) []int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var buckets []int
+	for bucketID, state := range m.states {
+		if state.MigratingTo == shardID || state.DrainingFrom == shardID {
+			buckets = append(buckets, bucketID)
+		}
+	}
+	sort.Ints(buckets)
+	return buckets
+}
+
+func (m *bucketMap) set(states map[int]BucketState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states = states
+}
+
+// validateBucketStates checks that every bucket is mapped to an existing shard.
+func validateBucketStates(states map[int]BucketState, bucketCount, shardCount int) error {
+	for i := 0; i < bucketCount; i++ {
+		state, ok := states[i]
+		if !ok {
+			return errors.Errorf("bucket %d has no mapping to shard", i)
+		}
+		if state.ShardID < 0 || state.ShardID >= shardCount {
+			return errors.Errorf("invalid shard_id %d for bucket %d: must be in range [0, %d)", state.ShardID, i, shardCount)
+		}
+		for _, shardID := range []int{state.MigratingTo, state.DrainingFrom} {
+			if shardID != noShard && (shardID < 0 || shardID >= shardCount) {
+				return errors.Errorf("invalid shard_id %d for bucket %d: must be in range [0, %d)", shardID, i, shardCount)
+			}
+		}
+	}
+	return nil
+}
+
+// seedBucketMap inserts the configured mapping for buckets not yet present
+// in the metadata table. Existing rows are never overwritten.
+func (s *ShardedStorage) seedBucketMap(ctx context.Context, seed map[int]int) error {
+	query := squirrel.Insert("activity_bucket_map").
+		Columns("bucket_id", "shard_id").
+		Suffix("ON CONFLICT (bucket_id) DO NOTHING").
+		PlaceholderFormat(squirrel.Dollar)
+	for bucketID, shardID := range seed {
+		query = query.Values(bucketID, shardID)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	if _, err := s.shards[metadataShard].Exec(ctx, sql, args...); err != nil {
+		return errors.Wrap(err, "failed to seed bucket map")
+	}
+
+	return nil
+}
+
+// BucketMap reads the current bucket map from the metadata table.
+func (s *ShardedStorage) BucketMap(ctx context.Context) (map[int]BucketState, error) {
+	query := squirrel.Select("bucket_id", "shard_id", "COALESCE(migrating_to, -1)", "COALESCE(draining_from, -1)").
+		From("activity_bucket_map").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	rows, err := s.shards[metadataShard].Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load bucket map")
+	}
+	defer rows.Close()
+
+	states := make(map[int]BucketState, s.bucketCount)
+	for rows.Next() {
+		var (
+			bucketID int
+			state    BucketState
+		)
+		if err := rows.Scan(&bucketID, &state.ShardID, &state.MigratingTo, &state.DrainingFrom); err != nil {
+			return nil, errors.Wrap(err, "failed to scan bucket map")
+		}
+		states[bucketID] = state
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to load bucket map")
+	}
+
+	if err := validateBucketStates(states, s.bucketCount, len(s.shards)); err != nil {
+		return nil, err
+	}
+
+	return states, nil
+}
+
+func (s *ShardedStorage) loadBucketMap(ctx context.Context) error {
+	states, err := s.BucketMap(ctx)
+	if err != nil {
+		return err
+	}
+	s.buckets.set(states)
+	return nil
+}
+
+// WatchBucketMap reloads the bucket map until ctx is cancelled, so a bucket
+// moved by the rebalancer is picked up without a restart. On error the last
+// known map stays in use.
+func (s *ShardedStorage) WatchBucketMap(ctx context.Context) {
+	ticker := time.NewTicker(s.refresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.loadBucketMap(ctx); err != nil && ctx.Err() == nil {
+				logger.Error("failed to reload bucket map", zap.Error(err))
+			}
+		}
+	}
+}
+
+// MappingRefreshInterval is how long a running service may keep using a
+// stale bucket map.
+func (s *ShardedStorage) MappingRefreshInterval() time.Duration {
+	return s.refresh
+}
@@ -0,0 +1,87 @@
+package sharded
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBucketStates(t *testing.T) {
+	tests := []struct {
+		name    string
+		states  map[int]BucketState
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			states: map[int]BucketState{
+				0: {ShardID: 0, MigratingTo: noShard, DrainingFrom: noShard},
+				1: {ShardID: 1, MigratingTo: noShard, DrainingFrom: noShard},
+			},
+		},
+		{
+			name: "migrating",
+			states: map[int]BucketState{
+				0: {ShardID: 0, MigratingTo: 1, DrainingFrom: noShard},
+				1: {ShardID: 1, MigratingTo: noShard, DrainingFrom: 0},
+			},
+		},
+		{
+			name: "missing bucket",
+			states: map[int]BucketState{
+				0: {ShardID: 0, MigratingTo: noShard, DrainingFrom: noShard},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown shard",
+			states: map[int]BucketState{
+				0: {ShardID: 0, MigratingTo: noShard, DrainingFrom: noShard},
+				1: {ShardID: 2, MigratingTo: noShard, DrainingFrom: noShard},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown migration target",
+			states: map[int]BucketState{
+				0: {ShardID: 0, MigratingTo: 5, DrainingFrom: noShard},
+				1: {ShardID: 1, MigratingTo: noShard, DrainingFrom: noShard},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBucketStates(tt.states, 2, 2)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBucketState_WriteShards(t *testing.T) {
+	stable := BucketState{ShardID: 1, MigratingTo: noShard, DrainingFrom: noShard}
+	migrating := BucketState{ShardID: 0, MigratingTo: 2, DrainingFrom: noShard}
+	draining := BucketState{ShardID: 2, MigratingTo: noShard, DrainingFrom: 0}
+
+	assert.Equal(t, []int{1}, stable.writeShards())
+	assert.Equal(t, []int{0, 2}, migrating.writeShards())
+	assert.Equal(t, []int{2}, draining.writeShards())
+}
+
+func TestBucketMap_StrayBuckets(t *testing.T) {
+	m := &bucketMap{states: map[int]BucketState{
+		0: {ShardID: 0, MigratingTo: noShard, DrainingFrom: noShard},
+		1: {ShardID: 0, MigratingTo: 1, DrainingFrom: noShard},
+		2: {ShardID: 1, MigratingTo: noShard, DrainingFrom: 0},
+		3: {ShardID: 1, MigratingTo: noShard, DrainingFrom: noShard},
+	}}
+
+	assert.Equal(t, []int{2}, m.strayBuckets(0))
+	assert.Equal(t, []int{1}, m.strayBuckets(1))
+	assert.Empty(t, m.strayBuckets(2))
+}
@@ -0,0 +1,87 @@
+package sharded
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+)
+
+// position is the (created_at, id) of the last activity a client has seen
+// from one shard. Activities are listed newest first, so the next page of
+// that shard starts strictly below it.
+type position struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+}
+
+func positionOf(a *domain.Activity) position {
+	return position{CreatedAt: a.CreatedAt, ID: a.ID}
+}
+
+// before reports whether a sorts ahead of b, i.e. is newer.
+func (p position) before(other position) bool {
+	if !p.CreatedAt.Equal(other.CreatedAt) {
+		return p.CreatedAt.After(other.CreatedAt)
+	}
+	return p.ID > other.ID
+}
+
+// cursor is the decoded form of the opaque page token: one position per shard
+// that has contributed rows so far.
+type cursor struct {
+	Shards map[int]position `json:"s"`
+}
+
+func decodeCursor(token string) (*cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.Wrap(domain.ErrInvalidCursor, err.Error())
+	}
+
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, errors.Wrap(domain.ErrInvalidCursor, err.Error())
+	}
+	if len(c.Shards) == 0 {
+		return nil, errors.Wrap(domain.ErrInvalidCursor, "no shard positions")
+	}
+
+	return &c, nil
+}
+
+func (c *cursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// after returns the position to continue shardID from. A shard that has not
+// contributed yet, or that took over a bucket since the token was issued,
+// continues from the oldest position in the token: everything newer than it
+// has already been returned.
+func (c *cursor) after(shardID int) (position, bool) {
+	if c == nil {
+		return position{}, false
+	}
+	if pos, ok := c.Shards[shardID]; ok {
+		return pos, true
+	}
+
+	var (
+		oldest position
+		found  bool
+	)
+	for _, pos := range c.Shards {
+		if !found || oldest.before(pos) {
+			oldest, found = pos, true
+		}
+	}
+	return oldest, found
+}
@@ -0,0 +1,278 @@
+package sharded
+
+import (
+	"context"
+	"hash/fnv"
+	"sort"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+// The entity index maps (entity_type, entity_id) to the buckets holding its
+// activities, so a lookup by entity only reads the shards owning those
+// buckets. Index rows are themselves placed by a bucket computed from the
+// entity (entity_bucket) and move with that bucket when it is rebalanced.
+
+type entityKey struct {
+	entityType string
+	entityID   string
+}
+
+type indexEntry struct {
+	entity entityKey
+	bucket int
+}
+
+func (s *ShardedStorage) getBucketForEntity(entityType, entityID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(entityType))
+	h.Write([]byte{':'})
+	h.Write([]byte(entityID))
+	return int(h.Sum32() % uint32(s.bucketCount))
+}
+
+// indexEntity records that the activity's bucket holds rows for its entity.
+// It runs before the activity itself is written: a failure in between leaves
+// an extra bucket in the index, which only costs one more shard read.
+func (s *ShardedStorage) indexEntity(ctx context.Context, activity *domain.Activity) error {
+	entry := indexEntry{
+		entity: entityKey{entityType: string(activity.EntityType), entityID: activity.EntityID},
+		bucket: s.getBucketForUser(activity.UserID),
+	}
+	return s.insertIndexEntries(ctx, []indexEntry{entry})
+}
+
+// insertIndexEntries writes entries to the shards owning their entity
+// buckets, and to the target shard of buckets being moved.
+func (s *ShardedStorage) insertIndexEntries(ctx context.Context, entries []indexEntry) error {
+	byShard := make(map[int][]indexEntry)
+	for _, entry := range entries {
+		entityBucket := s.getBucketForEntity(entry.entity.entityType, entry.entity.entityID)
+		for _, shardID := range s.buckets.get(entityBucket).writeShards() {
+			byShard[shardID] = append(byShard[shardID], entry)
+		}
+	}
+
+	for shardID, shardEntries := range byShard {
+		query := squirrel.Insert("activity_entity_index").
+			Columns("entity_type", "entity_id", "bucket_id", "entity_bucket").
+			Suffix("ON CONFLICT (entity_type, entity_id, bucket_id) DO NOTHING").
+			PlaceholderFormat(squirrel.Dollar)
+		for _, entry := range shardEntries {
+			entityBucket := s.getBucketForEntity(entry.entity.entityType, entry.entity.entityID)
+			query = query.Values(entry.entity.entityType, entry.entity.entityID, entry.bucket, entityBucket)
+		}
+
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return errors.Wrap(err, "failed to build query")
+		}
+
+		if _, err := s.shards[shardID].Exec(ctx, sql, args...); err != nil {
+			return errors.Wrapf(err, "failed to update entity index on shard %d", shardID)
+		}
+	}
+
+	return nil
+}
+
+// entityShardIDs returns the shards that may hold activities of the entity.
+// Entities with no index rows (written before the index existed and not yet
+// backfilled) and index lookups that fail fall back to every shard.
+func (s *ShardedStorage) entityShardIDs(ctx context.Context, entityType, entityID string) []int {
+	buckets, err := s.entityBuckets(ctx, entityType, entityID)
+	if err != nil {
+		logger.Warn("entity index lookup failed, reading all shards",
+			zap.String("entity_type", entityType), zap.String("entity_id", entityID), zap.Error(err))
+		return s.shardIDs()
+	}
+	if len(buckets) == 0 {
+		return s.shardIDs()
+	}
+	return s.shardsForBuckets(buckets)
+}
+
+func (s *ShardedStorage) entityBuckets(ctx context.Context, entityType, entityID string) ([]int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	shardID := s.buckets.get(s.getBucketForEntity(entityType, entityID)).ShardID
+
+	query := squirrel.Select("bucket_id").
+		From("activity_entity_index").
+		Where(squirrel.Eq{"entity_type": entityType, "entity_id": entityID}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	rows, err := s.shards[shardID].Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query entity index")
+	}
+	defer rows.Close()
+
+	var buckets []int
+	for rows.Next() {
+		var bucketID int
+		if err := rows.Scan(&bucketID); err != nil {
+			return nil, errors.Wrap(err, "failed to scan entity index")
+		}
+		buckets = append(buckets, bucketID)
+	}
+
+	return buckets, rows.Err()
+}
+
+// shardsForBuckets resolves buckets to the shards currently serving their reads.
+func (s *ShardedStorage) shardsForBuckets(buckets []int) []int {
+	seen := make(map[int]struct{}, len(s.shards))
+	var shardIDs []int
+	for _, bucketID := range buckets {
+		shardID := s.buckets.get(bucketID).ShardID
+		if _, ok := seen[shardID]; ok {
+			continue
+		}
+		seen[shardID] = struct{}{}
+		shardIDs = append(shardIDs, shardID)
+	}
+	sort.Ints(shardIDs)
+	return shardIDs
+}
+
+// BackfillEntityIndex indexes the activities already stored on every shard.
+// It is idempotent and safe to run while the service is writing.
+func (s *ShardedStorage) BackfillEntityIndex(ctx context.Context, batchSize int) (int64, error) {
+	if batchSize <= 0 {
+		batchSize = defaultRebalanceBatch
+	}
+
+	var indexed int64
+	for shardID, shard := range s.shards {
+		n, err := s.backfillShard(ctx, shard, batchSize)
+		indexed += n
+		if err != nil {
+			return indexed, errors.Wrapf(err, "shard %d", shardID)
+		}
+		logger.Info("entity index backfilled", zap.Int("shard", shardID), zap.Int64("entries", n))
+	}
+
+	return indexed, nil
+}
+
+func (s *ShardedStorage) backfillShard(ctx context.Context, shard *pgxpool.Pool, batchSize int) (int64, error) {
+	rows, err := shard.Query(ctx, `SELECT DISTINCT entity_type, entity_id, user_id FROM activities`)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list activity entities")
+	}
+	defer rows.Close()
+
+	var (
+		indexed int64
+		batch   []indexEntry
+		seen    = make(map[indexEntry]struct{})
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := s.insertIndexEntries(ctx, batch); err != nil {
+			return err
+		}
+		indexed += int64(len(batch))
+		batch = batch[:0]
+		return nil
+	}
+
+	for rows.Next() {
+		var key entityKey
+		var userID string
+		if err := rows.Scan(&key.entityType, &key.entityID, &userID); err != nil {
+			return indexed, errors.Wrap(err, "failed to scan activity entity")
+		}
+
+		entry := indexEntry{entity: key, bucket: s.getBucketForUser(userID)}
+		if _, ok := seen[entry]; ok {
+			continue
+		}
+		seen[entry] = struct{}{}
+
+		batch = append(batch, entry)
+		if len(batch) >= batchSize {
+			if err := flush(); err != nil {
+				return indexed, err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return indexed, errors.Wrap(err, "failed to list activity entities")
+	}
+
+	return indexed, flush()
+}
+
+// copyEntityIndex copies the index rows placed in bucketID to the target shard.
+func (s *ShardedStorage) copyEntityIndex(ctx context.Context, bucketID, from, to int) (int64, error) {
+	rows, err := s.shards[from].Query(ctx,
+		`SELECT entity_type, entity_id, bucket_id FROM activity_entity_index WHERE entity_bucket = $1`, bucketID)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read entity index")
+	}
+
+	var entries []indexEntry
+	for rows.Next() {
+		var entry indexEntry
+		if err := rows.Scan(&entry.entity.entityType, &entry.entity.entityID, &entry.bucket); err != nil {
+			rows.Close()
+			return 0, errors.Wrap(err, "failed to scan entity index")
+		}
+		entries = append(entries, entry)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, errors.Wrap(err, "failed to read entity index")
+	}
+
+	var copied int64
+	for len(entries) > 0 {
+		n := min(len(entries), defaultRebalanceBatch)
+
+		query := squirrel.Insert("activity_entity_index").
+			Columns("entity_type", "entity_id", "bucket_id", "entity_bucket").
+			Suffix("ON CONFLICT (entity_type, entity_id, bucket_id) DO NOTHING").
+			PlaceholderFormat(squirrel.Dollar)
+		for _, entry := range entries[:n] {
+			query = query.Values(entry.entity.entityType, entry.entity.entityID, entry.bucket, bucketID)
+		}
+
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return copied, errors.Wrap(err, "failed to build query")
+		}
+
+		tag, err := s.shards[to].Exec(ctx, sql, args...)
+		if err != nil {
+			return copied, errors.Wrap(err, "failed to copy entity index")
+		}
+		copied += tag.RowsAffected()
+		entries = entries[n:]
+	}
+
+	return copied, nil
+}
+
+func (s *ShardedStorage) deleteEntityIndex(ctx context.Context, bucketID, from int) (int64, error) {
+	tag, err := s.shards[from].Exec(ctx, `DELETE FROM activity_entity_index WHERE entity_bucket = $1`, bucketID)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete moved entity index rows")
+	}
+	return tag.RowsAffected(), nil
+}
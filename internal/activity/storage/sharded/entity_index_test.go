@@ -0,0 +1,33 @@
+package sharded
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBucketForEntity(t *testing.T) {
+	s := &ShardedStorage{bucketCount: 100}
+
+	bucket := s.getBucketForEntity("task", "3f2a7c1e-0000-4000-8000-000000000001")
+
+	assert.GreaterOrEqual(t, bucket, 0)
+	assert.Less(t, bucket, 100)
+	assert.Equal(t, bucket, s.getBucketForEntity("task", "3f2a7c1e-0000-4000-8000-000000000001"))
+}
+
+func TestShardsForBuckets(t *testing.T) {
+	s := &ShardedStorage{
+		shards: make([]*pgxpool.Pool, 3),
+		buckets: &bucketMap{states: map[int]BucketState{
+			0: {ShardID: 0, MigratingTo: noShard, DrainingFrom: noShard},
+			1: {ShardID: 2, MigratingTo: noShard, DrainingFrom: noShard},
+			2: {ShardID: 0, MigratingTo: 1, DrainingFrom: noShard},
+			3: {ShardID: 1, MigratingTo: noShard, DrainingFrom: 2},
+		}},
+	}
+
+	assert.Equal(t, []int{0, 2}, s.shardsForBuckets([]int{1, 0, 2}))
+	assert.Equal(t, []int{1}, s.shardsForBuckets([]int{3}))
+}
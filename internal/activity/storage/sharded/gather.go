@@ -0,0 +1,97 @@
+package sharded
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+type shardQuery func(ctx context.Context, shardID int) (shardPage, error)
+
+// gather runs query on every shard concurrently. Each shard gets its own
+// timeout and the whole fan-out is bounded by the overall deadline.
+//
+// Without allowPartial the first failing shard cancels the others and fails
+// the read. With it, failed shards are left out and returned as unavailable;
+// the read only fails if no shard answered.
+func (s *ShardedStorage) gather(ctx context.Context, name string, shardIDs []int, allowPartial bool, query shardQuery) ([]shardPage, []int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryDeadline)
+	defer cancel()
+
+	g, gctx := errgroup.WithContext(ctx)
+	if allowPartial {
+		g, gctx = &errgroup.Group{}, ctx
+	}
+
+	pages := make([]shardPage, len(shardIDs))
+	errs := make([]error, len(shardIDs))
+
+	for i, shardID := range shardIDs {
+		g.Go(func() error {
+			pages[i], errs[i] = s.queryShardTimed(gctx, name, shardID, query)
+			if errs[i] != nil && !allowPartial {
+				return errors.Wrapf(errs[i], "shard %d", shardID)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		available   []shardPage
+		unavailable []int
+		firstErr    error
+	)
+	for i, shardID := range shardIDs {
+		if errs[i] != nil {
+			unavailable = append(unavailable, shardID)
+			if firstErr == nil {
+				firstErr = errors.Wrapf(errs[i], "shard %d", shardID)
+			}
+			logger.Warn("activity shard unavailable, returning partial results",
+				zap.Int("shard", shardID), zap.String("query", name), zap.Error(errs[i]))
+			continue
+		}
+		available = append(available, pages[i])
+	}
+
+	if len(available) == 0 {
+		return nil, nil, firstErr
+	}
+	if len(unavailable) > 0 {
+		sort.Ints(unavailable)
+		partialResultsTotal.WithLabelValues(name).Inc()
+	}
+
+	return available, unavailable, nil
+}
+
+func (s *ShardedStorage) queryShardTimed(ctx context.Context, name string, shardID int, query shardQuery) (shardPage, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	shard := strconv.Itoa(shardID)
+	start := time.Now()
+
+	page, err := query(ctx, shardID)
+
+	shardQueryDuration.WithLabelValues(shard, name).Observe(time.Since(start).Seconds())
+	if err != nil {
+		reason := "error"
+		if errors.Is(err, context.DeadlineExceeded) || ctx.Err() == context.DeadlineExceeded {
+			reason = "timeout"
+		}
+		shardQueryErrorsTotal.WithLabelValues(shard, name, reason).Inc()
+	}
+
+	return page, err
+}
@@ -0,0 +1,85 @@
+package sharded
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+func newGatherStorage() *ShardedStorage {
+	_ = logger.Init("error")
+	return &ShardedStorage{queryTimeout: 50 * time.Millisecond, queryDeadline: 200 * time.Millisecond}
+}
+
+// shardResponses answers from canned pages; shards missing from pages fail,
+// shards listed in slow block until their context is done.
+func shardResponses(pages map[int]shardPage, slow ...int) shardQuery {
+	return func(ctx context.Context, shardID int) (shardPage, error) {
+		for _, id := range slow {
+			if id == shardID {
+				<-ctx.Done()
+				return shardPage{}, ctx.Err()
+			}
+		}
+		page, ok := pages[shardID]
+		if !ok {
+			return shardPage{}, errors.New("connection refused")
+		}
+		return page, nil
+	}
+}
+
+func TestGather_AllShards(t *testing.T) {
+	s := newGatherStorage()
+	query := shardResponses(map[int]shardPage{
+		0: {shardID: 0, total: 1, activities: []*domain.Activity{activityAt("a", 1)}},
+		1: {shardID: 1, total: 2},
+	})
+
+	pages, unavailable, err := s.gather(context.Background(), "test", []int{0, 1}, false, query)
+
+	require.NoError(t, err)
+	assert.Len(t, pages, 2)
+	assert.Empty(t, unavailable)
+}
+
+func TestGather_FailsWithoutPartial(t *testing.T) {
+	s := newGatherStorage()
+	query := shardResponses(map[int]shardPage{0: {shardID: 0}})
+
+	_, _, err := s.gather(context.Background(), "test", []int{0, 1}, false, query)
+
+	assert.Error(t, err)
+}
+
+func TestGather_PartialSkipsFailedAndSlowShards(t *testing.T) {
+	s := newGatherStorage()
+	query := shardResponses(map[int]shardPage{
+		0: {shardID: 0, total: 1},
+		2: {shardID: 2, total: 1},
+	}, 2)
+
+	start := time.Now()
+	pages, unavailable, err := s.gather(context.Background(), "test", []int{0, 1, 2}, true, query)
+
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), s.queryDeadline)
+	require.Len(t, pages, 1)
+	assert.Equal(t, 0, pages[0].shardID)
+	assert.Equal(t, []int{1, 2}, unavailable)
+}
+
+func TestGather_PartialFailsWhenNoShardAnswers(t *testing.T) {
+	s := newGatherStorage()
+
+	_, _, err := s.gather(context.Background(), "test", []int{0, 1}, true, shardResponses(nil))
+
+	assert.Error(t, err)
+}
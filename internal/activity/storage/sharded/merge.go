@@ -0,0 +1,121 @@
+package sharded
+
+import (
+	"container/heap"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+)
+
+// shardPage is what one shard returned for a scatter-gather read, newest first.
+type shardPage struct {
+	shardID    int
+	activities []*domain.Activity
+	// full means the shard returned as many rows as were asked for and may
+	// hold more beyond them.
+	full  bool
+	total int
+}
+
+type pageHead struct {
+	page  int
+	index int
+}
+
+// pageHeap orders the next unread activity of every shard page newest first.
+type pageHeap struct {
+	pages []shardPage
+	heads []pageHead
+}
+
+func (h *pageHeap) Len() int { return len(h.heads) }
+
+func (h *pageHeap) Less(i, j int) bool {
+	return positionOf(h.activity(h.heads[i])).before(positionOf(h.activity(h.heads[j])))
+}
+
+func (h *pageHeap) Swap(i, j int) { h.heads[i], h.heads[j] = h.heads[j], h.heads[i] }
+
+func (h *pageHeap) Push(x interface{}) { h.heads = append(h.heads, x.(pageHead)) }
+
+func (h *pageHeap) Pop() interface{} {
+	last := h.heads[len(h.heads)-1]
+	h.heads = h.heads[:len(h.heads)-1]
+	return last
+}
+
+func (h *pageHeap) activity(head pageHead) *domain.Activity {
+	return h.pages[head.page].activities[head.index]
+}
+
+// mergePages k-way merges shard pages by (created_at, id) descending, skips
+// offset rows and returns at most limit rows (all of them if limit is 0).
+// Copies of one row found on two shards while its bucket is being moved sort
+// next to each other and are returned once.
+//
+// The returned cursor continues after the last row consumed from every shard
+// and is nil when no shard has anything left.
+func mergePages(pages []shardPage, prev *cursor, offset, limit int) ([]*domain.Activity, *cursor) {
+	h := &pageHeap{pages: pages}
+	for i, page := range pages {
+		if len(page.activities) > 0 {
+			h.heads = append(h.heads, pageHead{page: i})
+		}
+	}
+	heap.Init(h)
+
+	positions := make(map[int]position)
+	if prev != nil {
+		for shardID, pos := range prev.Shards {
+			positions[shardID] = pos
+		}
+	}
+
+	var (
+		result  []*domain.Activity
+		lastID  string
+		skipped int
+	)
+	next := func() *domain.Activity {
+		head := heap.Pop(h).(pageHead)
+		a := h.activity(head)
+		positions[pages[head.page].shardID] = positionOf(a)
+		if head.index+1 < len(pages[head.page].activities) {
+			heap.Push(h, pageHead{page: head.page, index: head.index + 1})
+		}
+		return a
+	}
+
+	for h.Len() > 0 && (limit <= 0 || len(result) < limit) {
+		a := next()
+		if a.ID == lastID {
+			continue
+		}
+		lastID = a.ID
+
+		if skipped < offset {
+			skipped++
+			continue
+		}
+		result = append(result, a)
+	}
+	for h.Len() > 0 && h.activity(h.heads[0]).ID == lastID {
+		next()
+	}
+
+	if limit <= 0 || !hasMore(h, pages) {
+		return result, nil
+	}
+	return result, &cursor{Shards: positions}
+}
+
+func hasMore(h *pageHeap, pages []shardPage) bool {
+	if h.Len() > 0 {
+		return true
+	}
+	for _, page := range pages {
+		if page.full {
+			return true
+		}
+	}
+	return false
+}
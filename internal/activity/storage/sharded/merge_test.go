@@ -0,0 +1,140 @@
+package sharded
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+)
+
+var base = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func activityAt(id string, minute int) *domain.Activity {
+	return &domain.Activity{ID: id, CreatedAt: base.Add(time.Duration(minute) * time.Minute)}
+}
+
+func ids(activities []*domain.Activity) []string {
+	result := make([]string, 0, len(activities))
+	for _, a := range activities {
+		result = append(result, a.ID)
+	}
+	return result
+}
+
+func TestMergePages_OrdersAcrossShards(t *testing.T) {
+	pages := []shardPage{
+		{shardID: 0, activities: []*domain.Activity{activityAt("a5", 5), activityAt("a3", 3), activityAt("a1", 1)}},
+		{shardID: 1, activities: []*domain.Activity{activityAt("b4", 4), activityAt("b2", 2)}},
+	}
+
+	result, next := mergePages(pages, nil, 0, 3)
+
+	assert.Equal(t, []string{"a5", "b4", "a3"}, ids(result))
+	require.NotNil(t, next)
+	assert.Equal(t, position{CreatedAt: base.Add(3 * time.Minute), ID: "a3"}, next.Shards[0])
+	assert.Equal(t, position{CreatedAt: base.Add(4 * time.Minute), ID: "b4"}, next.Shards[1])
+}
+
+func TestMergePages_TiesBrokenByID(t *testing.T) {
+	pages := []shardPage{
+		{shardID: 0, activities: []*domain.Activity{activityAt("a", 1)}},
+		{shardID: 1, activities: []*domain.Activity{activityAt("c", 1), activityAt("b", 1)}},
+	}
+
+	result, next := mergePages(pages, nil, 0, 10)
+
+	assert.Equal(t, []string{"c", "b", "a"}, ids(result))
+	assert.Nil(t, next)
+}
+
+func TestMergePages_Offset(t *testing.T) {
+	pages := []shardPage{
+		{shardID: 0, activities: []*domain.Activity{activityAt("a5", 5), activityAt("a3", 3), activityAt("a1", 1)}},
+		{shardID: 1, activities: []*domain.Activity{activityAt("b4", 4), activityAt("b2", 2)}},
+	}
+
+	result, next := mergePages(pages, nil, 2, 2)
+
+	assert.Equal(t, []string{"a3", "b2"}, ids(result))
+	require.NotNil(t, next)
+}
+
+func TestMergePages_DedupesMigratingRows(t *testing.T) {
+	pages := []shardPage{
+		{shardID: 0, activities: []*domain.Activity{activityAt("x", 3), activityAt("y", 2)}},
+		{shardID: 1, activities: []*domain.Activity{activityAt("x", 3), activityAt("y", 2)}},
+	}
+
+	result, next := mergePages(pages, nil, 0, 1)
+
+	assert.Equal(t, []string{"x"}, ids(result))
+	require.NotNil(t, next)
+	assert.Equal(t, "x", next.Shards[0].ID)
+	assert.Equal(t, "x", next.Shards[1].ID)
+}
+
+func TestMergePages_LastPage(t *testing.T) {
+	pages := []shardPage{
+		{shardID: 0, activities: []*domain.Activity{activityAt("a", 2)}},
+		{shardID: 1},
+	}
+
+	result, next := mergePages(pages, nil, 0, 1)
+
+	assert.Equal(t, []string{"a"}, ids(result))
+	assert.Nil(t, next)
+}
+
+func TestMergePages_ExhaustedFullShardHasMore(t *testing.T) {
+	pages := []shardPage{
+		{shardID: 0, activities: []*domain.Activity{activityAt("x", 2)}, full: true},
+		{shardID: 1, activities: []*domain.Activity{activityAt("x", 2)}},
+	}
+
+	_, next := mergePages(pages, nil, 0, 1)
+
+	assert.NotNil(t, next)
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	c := &cursor{Shards: map[int]position{
+		0: {CreatedAt: base, ID: "a"},
+		2: {CreatedAt: base.Add(time.Minute), ID: "b"},
+	}}
+
+	decoded, err := decodeCursor(c.encode())
+
+	require.NoError(t, err)
+	assert.True(t, decoded.Shards[0].CreatedAt.Equal(base))
+	assert.Equal(t, "b", decoded.Shards[2].ID)
+}
+
+func TestCursor_Invalid(t *testing.T) {
+	for _, token := range []string{"%%%", "bm90IGpzb24", "e30"} {
+		_, err := decodeCursor(token)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidCursor, token)
+	}
+}
+
+func TestCursor_AfterFallsBackToOldestPosition(t *testing.T) {
+	c := &cursor{Shards: map[int]position{
+		0: {CreatedAt: base.Add(time.Minute), ID: "a"},
+		1: {CreatedAt: base, ID: "b"},
+	}}
+
+	pos, ok := c.after(0)
+	assert.True(t, ok)
+	assert.Equal(t, "a", pos.ID)
+
+	pos, ok = c.after(2)
+	assert.True(t, ok)
+	assert.Equal(t, "b", pos.ID)
+
+	var none *cursor
+	_, ok = none.after(0)
+	assert.False(t, ok)
+}
@@ -0,0 +1,24 @@
+package sharded
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	shardQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "activity_shard_query_duration_seconds",
+		Help:    "Latency of one shard's part of an activity read.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"shard", "query"})
+
+	shardQueryErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "activity_shard_query_errors_total",
+		Help: "Failed shard reads, by reason (timeout or error).",
+	}, []string{"shard", "query", "reason"})
+
+	partialResultsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "activity_partial_results_total",
+		Help: "Activity reads answered without one or more unavailable shards.",
+	}, []string{"query"})
+)
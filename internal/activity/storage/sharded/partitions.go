@@ -0,0 +1,204 @@
+package sharded
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+// activities is range-partitioned by month on created_at. Partitions are
+// created ahead of time, partitions older than the retention period are
+// detached and moved to archiveSchema, and rows outside every monthly
+// partition land in activities_default.
+
+const archiveSchema = "activity_archive"
+
+const (
+	defaultPremakeMonths  = 3
+	defaultPartitionCheck = time.Hour
+)
+
+var partitionNamePattern = regexp.MustCompile(`^activities_([0-9]{4})_([0-9]{2})$`)
+
+type partitionPolicy struct {
+	premakeMonths   int
+	retentionMonths int
+	checkInterval   time.Duration
+}
+
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func partitionName(month time.Time) string {
+	return fmt.Sprintf("activities_%04d_%02d", month.Year(), int(month.Month()))
+}
+
+func parsePartitionMonth(name string) (time.Time, bool) {
+	match := partitionNamePattern.FindStringSubmatch(name)
+	if match == nil {
+		return time.Time{}, false
+	}
+	year, _ := strconv.Atoi(match[1])
+	month, _ := strconv.Atoi(match[2])
+	if month < 1 || month > 12 {
+		return time.Time{}, false
+	}
+	return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC), true
+}
+
+// partitionsToCreate returns the current month and the premake months after it.
+func partitionsToCreate(now time.Time, premake int) []time.Time {
+	current := monthStart(now)
+	months := make([]time.Time, 0, premake+1)
+	for i := 0; i <= premake; i++ {
+		months = append(months, current.AddDate(0, i, 0))
+	}
+	return months
+}
+
+// expiredPartitions returns the monthly partitions that ended more than
+// retention months before the current month; 0 keeps everything.
+func expiredPartitions(names []string, now time.Time, retention int) []string {
+	if retention <= 0 {
+		return nil
+	}
+
+	cutoff := monthStart(now).AddDate(0, -retention, 0)
+
+	var expired []string
+	for _, name := range names {
+		month, ok := parsePartitionMonth(name)
+		if ok && !month.AddDate(0, 1, 0).After(cutoff) {
+			expired = append(expired, name)
+		}
+	}
+	sort.Strings(expired)
+	return expired
+}
+
+// EnsurePartitions creates the partitions for the current month and the
+// configured number of months ahead on every shard.
+func (s *ShardedStorage) EnsurePartitions(ctx context.Context, now time.Time) error {
+	for shardID, shard := range s.shards {
+		for _, month := range partitionsToCreate(now, s.partitions.premakeMonths) {
+			if err := createPartition(ctx, shard, month); err != nil {
+				return errors.Wrapf(err, "shard %d", shardID)
+			}
+		}
+	}
+	return nil
+}
+
+func createPartition(ctx context.Context, shard *pgxpool.Pool, month time.Time) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s PARTITION OF activities FOR VALUES FROM ('%s') TO ('%s')`,
+		pgx.Identifier{partitionName(month)}.Sanitize(),
+		month.Format(time.DateOnly), month.AddDate(0, 1, 0).Format(time.DateOnly))
+	if _, err := shard.Exec(ctx, query); err != nil {
+		return errors.Wrapf(err, "failed to create partition %s", partitionName(month))
+	}
+	return nil
+}
+
+// ArchiveExpiredPartitions detaches the partitions past the retention period
+// and moves them into the archive schema, where they can be exported and
+// dropped. It returns the archived partitions per shard.
+func (s *ShardedStorage) ArchiveExpiredPartitions(ctx context.Context, now time.Time) (map[int][]string, error) {
+	archived := make(map[int][]string)
+	if s.partitions.retentionMonths <= 0 {
+		return archived, nil
+	}
+
+	for shardID, shard := range s.shards {
+		names, err := listPartitions(ctx, shard)
+		if err != nil {
+			return archived, errors.Wrapf(err, "shard %d", shardID)
+		}
+
+		for _, name := range expiredPartitions(names, now, s.partitions.retentionMonths) {
+			if err := archivePartition(ctx, shard, name); err != nil {
+				return archived, errors.Wrapf(err, "failed to archive partition %s on shard %d", name, shardID)
+			}
+			archived[shardID] = append(archived[shardID], name)
+			logger.Info("activity partition archived", zap.Int("shard", shardID), zap.String("partition", name))
+		}
+	}
+
+	return archived, nil
+}
+
+// RunPartitionMaintenance creates upcoming partitions and archives expired
+// ones now and then on every check interval until ctx is cancelled.
+func (s *ShardedStorage) RunPartitionMaintenance(ctx context.Context) {
+	ticker := time.NewTicker(s.partitions.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		if err := s.EnsurePartitions(ctx, now); err != nil && ctx.Err() == nil {
+			logger.Error("failed to create activity partitions", zap.Error(err))
+		}
+		if _, err := s.ArchiveExpiredPartitions(ctx, now); err != nil && ctx.Err() == nil {
+			logger.Error("failed to archive activity partitions", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func listPartitions(ctx context.Context, shard *pgxpool.Pool) ([]string, error) {
+	rows, err := shard.Query(ctx, `
+		SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = 'activities'::regclass`)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list partitions")
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, errors.Wrap(err, "failed to scan partition")
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
+func archivePartition(ctx context.Context, shard *pgxpool.Pool, name string) error {
+	table := pgx.Identifier{name}.Sanitize()
+	schema := pgx.Identifier{archiveSchema}.Sanitize()
+
+	return pgx.BeginFunc(ctx, shard, func(tx pgx.Tx) error {
+		statements := []string{
+			`CREATE SCHEMA IF NOT EXISTS ` + schema,
+			`ALTER TABLE activities DETACH PARTITION ` + table,
+			`ALTER TABLE ` + table + ` SET SCHEMA ` + schema,
+		}
+		for _, statement := range statements {
+			if _, err := tx.Exec(ctx, statement); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
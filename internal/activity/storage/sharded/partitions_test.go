@@ -0,0 +1,53 @@
+package sharded
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitionName_RoundTrip(t *testing.T) {
+	month := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	name := partitionName(month)
+	parsed, ok := parsePartitionMonth(name)
+
+	assert.Equal(t, "activities_2026_03", name)
+	assert.True(t, ok)
+	assert.Equal(t, month, parsed)
+}
+
+func TestParsePartitionMonth_Rejects(t *testing.T) {
+	for _, name := range []string{"activities_default", "activities_2026_13", "activities_2026_3", "tasks_2026_03"} {
+		_, ok := parsePartitionMonth(name)
+
+		assert.False(t, ok, name)
+	}
+}
+
+func TestPartitionsToCreate(t *testing.T) {
+	now := time.Date(2026, time.November, 18, 15, 4, 5, 0, time.UTC)
+
+	months := partitionsToCreate(now, 2)
+
+	assert.Equal(t, []time.Time{
+		time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}, months)
+}
+
+func TestExpiredPartitions(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	names := []string{
+		"activities_default",
+		"activities_2026_10",
+		"activities_2025_10",
+		"activities_2025_09",
+		"activities_2024_01",
+	}
+
+	assert.Equal(t, []string{"activities_2024_01", "activities_2025_09"}, expiredPartitions(names, now, 12))
+	assert.Nil(t, expiredPartitions(names, now, 0))
+}
@@ -0,0 +1,390 @@
+package sharded
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+const defaultRebalanceBatch = 1000
+
+var activityColumns = []string{"id", "user_id", "entity_type", "entity_id", "action", "metadata", "created_at"}
+
+type RebalanceOptions struct {
+	// Settle is how long to wait after each bucket map change so that every
+	// running service has reloaded it. It must exceed the refresh interval.
+	Settle time.Duration
+	// BatchSize is the number of rows copied per insert and deleted per
+	// statement.
+	BatchSize int
+}
+
+type RebalanceResult struct {
+	Bucket  int
+	From    int
+	To      int
+	Copied  int64
+	Deleted int64
+	// IndexCopied and IndexDeleted count entity index rows placed in the bucket.
+	IndexCopied  int64
+	IndexDeleted int64
+}
+
+// MoveBucket moves a bucket to the target shard without downtime:
+//
+//  1. mark the bucket as migrating, so new writes go to both shards;
+//  2. copy the bucket's existing rows and entity index rows to the target;
+//  3. flip the owner to the target in the bucket map;
+//  4. delete the rows left on the source shard.
+//
+// Every step is idempotent, so an interrupted move is resumed by running
+// MoveBucket again with the same target.
+func (s *ShardedStorage) MoveBucket(ctx context.Context, bucketID, target int, opts RebalanceOptions) (RebalanceResult, error) {
+	result := RebalanceResult{Bucket: bucketID, From: noShard, To: target}
+
+	if bucketID < 0 || bucketID >= s.bucketCount {
+		return result, errors.Errorf("invalid bucket %d: must be in range [0, %d)", bucketID, s.bucketCount)
+	}
+	if target < 0 || target >= len(s.shards) {
+		return result, errors.Errorf("invalid target shard %d: must be in range [0, %d)", target, len(s.shards))
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultRebalanceBatch
+	}
+
+	states, err := s.BucketMap(ctx)
+	if err != nil {
+		return result, err
+	}
+	state := states[bucketID]
+
+	switch {
+	case state.Migrating() && state.MigratingTo != target:
+		return result, errors.Errorf("bucket %d is already migrating to shard %d", bucketID, state.MigratingTo)
+	case state.Draining() && state.ShardID != target:
+		return result, errors.Errorf("bucket %d is still draining from shard %d", bucketID, state.DrainingFrom)
+	case state.ShardID == target && !state.Draining():
+		result.From = target
+		return result, nil
+	}
+
+	source := state.ShardID
+	if source == target {
+		source = state.DrainingFrom
+	}
+	if err := requireBucketIDs(ctx, s.shards[source], source); err != nil {
+		return result, err
+	}
+
+	if state.ShardID != target {
+		result.From = state.ShardID
+
+		if !state.Migrating() {
+			if err := s.updateBucketState(ctx, bucketID, state, BucketState{ShardID: state.ShardID, MigratingTo: target, DrainingFrom: noShard}); err != nil {
+				return result, err
+			}
+			logger.Info("bucket marked as migrating, waiting for services to double-write",
+				zap.Int("bucket", bucketID), zap.Int("from", result.From), zap.Int("to", target))
+			if !sleepCtx(ctx, opts.Settle) {
+				return result, ctx.Err()
+			}
+		}
+
+		result.Copied, err = s.copyBucket(ctx, bucketID, result.From, target, opts.BatchSize)
+		if err != nil {
+			return result, err
+		}
+		result.IndexCopied, err = s.copyEntityIndex(ctx, bucketID, result.From, target)
+		if err != nil {
+			return result, err
+		}
+		logger.Info("bucket rows copied", zap.Int("bucket", bucketID), zap.Int64("rows", result.Copied),
+			zap.Int64("index_rows", result.IndexCopied))
+
+		current := BucketState{ShardID: result.From, MigratingTo: target, DrainingFrom: noShard}
+		if err := s.updateBucketState(ctx, bucketID, current, BucketState{ShardID: target, MigratingTo: noShard, DrainingFrom: result.From}); err != nil {
+			return result, err
+		}
+		if err := s.loadBucketMap(ctx); err != nil {
+			return result, err
+		}
+		logger.Info("bucket map flipped, waiting for services to switch reads",
+			zap.Int("bucket", bucketID), zap.Int("shard", target))
+		if !sleepCtx(ctx, opts.Settle) {
+			return result, ctx.Err()
+		}
+	} else {
+		result.From = state.DrainingFrom
+	}
+
+	result.Deleted, err = s.deleteBucket(ctx, bucketID, result.From, opts.BatchSize)
+	if err != nil {
+		return result, err
+	}
+	result.IndexDeleted, err = s.deleteEntityIndex(ctx, bucketID, result.From)
+	if err != nil {
+		return result, err
+	}
+
+	current := BucketState{ShardID: target, MigratingTo: noShard, DrainingFrom: result.From}
+	if err := s.updateBucketState(ctx, bucketID, current, BucketState{ShardID: target, MigratingTo: noShard, DrainingFrom: noShard}); err != nil {
+		return result, err
+	}
+	logger.Info("bucket moved", zap.Int("bucket", bucketID), zap.Int("from", result.From),
+		zap.Int("to", target), zap.Int64("deleted", result.Deleted))
+
+	return result, s.loadBucketMap(ctx)
+}
+
+// updateBucketState is a compare-and-set on one row of the bucket map, so two
+// concurrent rebalancers cannot both move the same bucket.
+func (s *ShardedStorage) updateBucketState(ctx context.Context, bucketID int, current, next BucketState) error {
+	query := squirrel.Update("activity_bucket_map").
+		Set("shard_id", next.ShardID).
+		Set("migrating_to", nullableShard(next.MigratingTo)).
+		Set("draining_from", nullableShard(next.DrainingFrom)).
+		Set("updated_at", time.Now()).
+		Where(squirrel.Eq{
+			"bucket_id":                   bucketID,
+			"shard_id":                    current.ShardID,
+			"COALESCE(migrating_to, -1)":  current.MigratingTo,
+			"COALESCE(draining_from, -1)": current.DrainingFrom,
+		}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	tag, err := s.shards[metadataShard].Exec(ctx, sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to update bucket map")
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.Errorf("bucket %d changed concurrently", bucketID)
+	}
+
+	return nil
+}
+
+func nullableShard(shardID int) interface{} {
+	if shardID == noShard {
+		return nil
+	}
+	return shardID
+}
+
+// requireBucketIDs fails if the shard holds activities written before
+// bucket_id was stored, which a move would otherwise leave behind.
+func requireBucketIDs(ctx context.Context, shard *pgxpool.Pool, shardID int) error {
+	var missing bool
+	err := shard.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM activities WHERE bucket_id IS NULL)`).Scan(&missing)
+	if err != nil {
+		return errors.Wrap(err, "failed to check activity buckets")
+	}
+	if missing {
+		return errors.Errorf("shard %d has activities without bucket_id: run activity-admin bucket-backfill first", shardID)
+	}
+	return nil
+}
+
+func (s *ShardedStorage) copyBucket(ctx context.Context, bucketID, from, to, batchSize int) (int64, error) {
+	source, target := s.shards[from], s.shards[to]
+
+	var (
+		copied    int64
+		afterTime time.Time
+		afterID   string
+	)
+	for {
+		query := squirrel.Select(activityColumns...).
+			From("activities").
+			Where(squirrel.Eq{"bucket_id": bucketID}).
+			OrderBy("created_at", "id").
+			Limit(uint64(batchSize)).
+			PlaceholderFormat(squirrel.Dollar)
+		if afterID != "" {
+			query = query.Where(squirrel.GtOrEq{"created_at": afterTime}).
+				Where("(created_at, id) > (?, ?)", afterTime, afterID)
+		}
+
+		activities, err := selectActivities(ctx, source, query)
+		if err != nil {
+			return copied, err
+		}
+		if len(activities) == 0 {
+			break
+		}
+
+		n, err := s.insertActivities(ctx, target, activities)
+		if err != nil {
+			return copied, err
+		}
+		copied += n
+
+		last := activities[len(activities)-1]
+		afterTime, afterID = last.CreatedAt, last.ID
+	}
+
+	return copied, nil
+}
+
+// deleteBucket deletes the bucket's rows from the source shard in batches,
+// so no single statement holds locks on the whole bucket.
+func (s *ShardedStorage) deleteBucket(ctx context.Context, bucketID, from, batchSize int) (int64, error) {
+	source := s.shards[from]
+
+	var deleted int64
+	for {
+		tag, err := source.Exec(ctx, `DELETE FROM activities WHERE (id, created_at) IN (
+			SELECT id, created_at FROM activities WHERE bucket_id = $1 LIMIT $2)`, bucketID, batchSize)
+		if err != nil {
+			return deleted, errors.Wrap(err, "failed to delete moved activities")
+		}
+		deleted += tag.RowsAffected()
+		if tag.RowsAffected() < int64(batchSize) {
+			return deleted, nil
+		}
+	}
+}
+
+// BackfillBuckets stores bucket_id on the activities written before it
+// existed. It is idempotent and safe to run while the service is writing.
+func (s *ShardedStorage) BackfillBuckets(ctx context.Context, batchSize int) (int64, error) {
+	if batchSize <= 0 {
+		batchSize = defaultRebalanceBatch
+	}
+
+	var updated int64
+	for shardID, shard := range s.shards {
+		n, err := s.backfillShardBuckets(ctx, shard, batchSize)
+		updated += n
+		if err != nil {
+			return updated, errors.Wrapf(err, "shard %d", shardID)
+		}
+		logger.Info("activity buckets backfilled", zap.Int("shard", shardID), zap.Int64("rows", n))
+	}
+
+	return updated, nil
+}
+
+// backfillShardBuckets takes up to batchSize users with unassigned rows at a
+// time and sets the bucket on all of their rows.
+func (s *ShardedStorage) backfillShardBuckets(ctx context.Context, shard *pgxpool.Pool, batchSize int) (int64, error) {
+	var updated int64
+	for {
+		users, err := usersWithoutBucket(ctx, shard, batchSize)
+		if err != nil {
+			return updated, err
+		}
+		if len(users) == 0 {
+			return updated, nil
+		}
+
+		byBucket := make(map[int][]string)
+		for _, userID := range users {
+			bucketID := s.getBucketForUser(userID)
+			byBucket[bucketID] = append(byBucket[bucketID], userID)
+		}
+
+		for bucketID, userIDs := range byBucket {
+			tag, err := shard.Exec(ctx, `UPDATE activities SET bucket_id = $1 WHERE bucket_id IS NULL AND user_id = ANY($2)`,
+				bucketID, userIDs)
+			if err != nil {
+				return updated, errors.Wrap(err, "failed to set activity buckets")
+			}
+			updated += tag.RowsAffected()
+		}
+	}
+}
+
+func usersWithoutBucket(ctx context.Context, shard *pgxpool.Pool, limit int) ([]string, error) {
+	rows, err := shard.Query(ctx, `SELECT DISTINCT user_id FROM activities WHERE bucket_id IS NULL LIMIT $1`, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list users")
+	}
+	defer rows.Close()
+
+	var users []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, errors.Wrap(err, "failed to scan user")
+		}
+		users = append(users, userID)
+	}
+
+	return users, rows.Err()
+}
+
+func selectActivities(ctx context.Context, shard *pgxpool.Pool, query squirrel.SelectBuilder) ([]*domain.Activity, error) {
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	rows, err := shard.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query activities")
+	}
+	defer rows.Close()
+
+	var activities []*domain.Activity
+	for rows.Next() {
+		var a domain.Activity
+		if err := rows.Scan(&a.ID, &a.UserID, &a.EntityType, &a.EntityID, &a.Action, &a.Metadata, &a.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan activity")
+		}
+		activities = append(activities, &a)
+	}
+
+	return activities, rows.Err()
+}
+
+// insertActivities writes activities with the buckets of their users.
+func (s *ShardedStorage) insertActivities(ctx context.Context, shard *pgxpool.Pool, activities []*domain.Activity) (int64, error) {
+	query := squirrel.Insert("activities").
+		Columns(activityColumns...).
+		Columns("bucket_id").
+		Suffix("ON CONFLICT (id, created_at) DO NOTHING").
+		PlaceholderFormat(squirrel.Dollar)
+	for _, a := range activities {
+		query = query.Values(a.ID, a.UserID, a.EntityType, a.EntityID, a.Action, a.Metadata, a.CreatedAt, s.getBucketForUser(a.UserID))
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to build query")
+	}
+
+	tag, err := shard.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to copy activities")
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
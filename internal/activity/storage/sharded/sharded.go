@@ -4,18 +4,32 @@ import (
 	"context"
 	"fmt"
 	"hash/fnv"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pkg/errors"
+	"github.com/Sol1tud9/taskflow/migrations"
 	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/migrate"
 )
 
 
+const (
+	defaultQueryTimeout  = 2 * time.Second
+	defaultQueryDeadline = 3 * time.Second
+)
+
 type ShardedStorage struct {
-	shards        []*pgxpool.Pool
-	shardCount    int
-	bucketCount   int
-	bucketToShard map[int]int 
+	shards      []*pgxpool.Pool
+	shardCount  int
+	bucketCount int
+	buckets     *bucketMap
+	refresh     time.Duration
+	// queryTimeout bounds each shard's part of a cross-shard read and
+	// queryDeadline the read as a whole.
+	queryTimeout  time.Duration
+	queryDeadline time.Duration
+	partitions    partitionPolicy
 }
 
 func NewShardedStorage(cfg config.ShardingConfig) (*ShardedStorage, error) {
@@ -69,55 +83,82 @@ func NewShardedStorage(cfg config.ShardingConfig) (*ShardedStorage, error) {
 		}
 	}
 
+	refresh := time.Duration(cfg.MappingRefreshMs) * time.Millisecond
+	if refresh <= 0 {
+		refresh = defaultMappingRefresh
+	}
+
+	queryTimeout := time.Duration(cfg.QueryTimeoutMs) * time.Millisecond
+	if queryTimeout <= 0 {
+		queryTimeout = defaultQueryTimeout
+	}
+
+	queryDeadline := time.Duration(cfg.QueryDeadlineMs) * time.Millisecond
+	if queryDeadline <= 0 {
+		queryDeadline = defaultQueryDeadline
+	}
+
+	partitions := partitionPolicy{
+		premakeMonths:   cfg.Partitioning.PremakeMonths,
+		retentionMonths: cfg.Partitioning.RetentionMonths,
+		checkInterval:   time.Duration(cfg.Partitioning.CheckIntervalMinutes) * time.Minute,
+	}
+	if partitions.premakeMonths <= 0 {
+		partitions.premakeMonths = defaultPremakeMonths
+	}
+	if partitions.checkInterval <= 0 {
+		partitions.checkInterval = defaultPartitionCheck
+	}
+
 	storage := &ShardedStorage{
 		shards:        shards,
 		shardCount:    cfg.ShardCount,
 		bucketCount:   bucketCount,
-		bucketToShard: bucketToShard,
+		buckets:       &bucketMap{},
+		refresh:       refresh,
+		queryTimeout:  queryTimeout,
+		queryDeadline: queryDeadline,
+		partitions:    partitions,
 	}
 
-	if err := storage.initTables(); err != nil {
+	ctx := context.Background()
+	for i, shard := range shards {
+		if err := migrate.Up(ctx, shard, migrations.Activity); err != nil {
+			return nil, errors.Wrapf(err, "failed to migrate shard %d", i)
+		}
+	}
+	if err := storage.EnsurePartitions(ctx, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := storage.seedBucketMap(ctx, bucketToShard); err != nil {
+		return nil, err
+	}
+	if err := storage.loadBucketMap(ctx); err != nil {
 		return nil, err
 	}
 
 	return storage, nil
 }
 
-func (s *ShardedStorage) initTables() error {
-	query := `CREATE TABLE IF NOT EXISTS activities (
-		id VARCHAR(36) PRIMARY KEY,
-		user_id VARCHAR(36) NOT NULL,
-		entity_type VARCHAR(50) NOT NULL,
-		entity_id VARCHAR(36) NOT NULL,
-		action VARCHAR(50) NOT NULL,
-		metadata TEXT,
-		created_at TIMESTAMP NOT NULL
-	)`
-
-	indexQueries := []string{
-		`CREATE INDEX IF NOT EXISTS idx_activities_user_id ON activities(user_id)`,
-		`CREATE INDEX IF NOT EXISTS idx_activities_entity ON activities(entity_type, entity_id)`,
-		`CREATE INDEX IF NOT EXISTS idx_activities_created_at ON activities(created_at)`,
-	}
-
-	for i, shard := range s.shards {
-		if _, err := shard.Exec(context.Background(), query); err != nil {
-			return errors.Wrapf(err, "failed to create table on shard %d", i)
-		}
-		for _, indexQuery := range indexQueries {
-			if _, err := shard.Exec(context.Background(), indexQuery); err != nil {
-				return errors.Wrapf(err, "failed to create index on shard %d", i)
-			}
-		}
-	}
-
-	return nil
+func (s *ShardedStorage) GetShardForUser(userID string) *pgxpool.Pool {
+	return s.shards[s.getShardIDForUser(userID)]
 }
 
-func (s *ShardedStorage) GetShardForUser(userID string) *pgxpool.Pool {
+func (s *ShardedStorage) getShardIDForUser(userID string) int {
 	bucketID := s.getBucketForUser(userID)
-	shardID := s.bucketToShard[bucketID]
-	return s.shards[shardID]
+	return s.buckets.get(bucketID).ShardID
+}
+
+// getWriteShardsForUser returns the shard owning the user's bucket and, while
+// the bucket is being moved, the shard it is moving to.
+func (s *ShardedStorage) getWriteShardsForUser(userID string) []*pgxpool.Pool {
+	state := s.buckets.get(s.getBucketForUser(userID))
+
+	pools := make([]*pgxpool.Pool, 0, 2)
+	for _, shardID := range state.writeShards() {
+		pools = append(pools, s.shards[shardID])
+	}
+	return pools
 }
 
 func (s *ShardedStorage) getBucketForUser(userID string) int {
@@ -140,6 +181,18 @@ func (s *ShardedStorage) GetAllShards() []*pgxpool.Pool {
 	return s.shards
 }
 
+func (s *ShardedStorage) shardIDs() []int {
+	ids := make([]int, len(s.shards))
+	for i := range s.shards {
+		ids[i] = i
+	}
+	return ids
+}
+
+func (s *ShardedStorage) ShardCount() int {
+	return len(s.shards)
+}
+
 func (s *ShardedStorage) Close() {
 	for _, shard := range s.shards {
 		shard.Close()
@@ -3,7 +3,6 @@ package usecase
 import (
 	"context"
 	"encoding/json"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/Sol1tud9/taskflow/internal/domain"
@@ -11,10 +10,12 @@ import (
 
 
 type ActivityRepository interface {
+	// Create stores activity on its owning shard; storing an ID that already
+	// exists is a no-op, which makes redelivered events harmless.
 	Create(ctx context.Context, activity *domain.Activity) error
-	GetByUserID(ctx context.Context, userID string, filter ActivityFilter) ([]*domain.Activity, int, error)
-	GetByEntity(ctx context.Context, entityType, entityID string, filter ActivityFilter) ([]*domain.Activity, int, error)
-	GetAll(ctx context.Context, filter ActivityFilter) ([]*domain.Activity, int, error)
+	GetByUserID(ctx context.Context, userID string, filter ActivityFilter) (*domain.ActivityPage, error)
+	GetByEntity(ctx context.Context, entityType, entityID string, filter ActivityFilter) (*domain.ActivityPage, error)
+	GetAll(ctx context.Context, filter ActivityFilter) (*domain.ActivityPage, error)
 }
 
 type ActivityFilter struct {
@@ -22,6 +23,11 @@ type ActivityFilter struct {
 	ToTimestamp   int64
 	Limit         int
 	Offset        int
+	// Cursor is the NextCursor of the previous page; it replaces Offset.
+	Cursor string
+	// AllowPartial returns the rows of the shards that answered instead of
+	// failing when some shard is down or too slow.
+	AllowPartial bool
 }
 
 type ActivityUseCase struct {
@@ -37,7 +43,7 @@ func NewActivityUseCase(activityRepo ActivityRepository) *ActivityUseCase {
 func (uc *ActivityUseCase) RecordUserCreated(ctx context.Context, event domain.UserCreatedEvent) error {
 	metadata, _ := json.Marshal(event)
 	activity := &domain.Activity{
-		ID:         uuid.New().String(),
+		ID:         activityID(event.EventID, metadata),
 		UserID:     event.UserID,
 		EntityType: domain.EntityTypeUser,
 		EntityID:   event.UserID,
@@ -51,7 +57,7 @@ func (uc *ActivityUseCase) RecordUserCreated(ctx context.Context, event domain.U
 func (uc *ActivityUseCase) RecordUserUpdated(ctx context.Context, event domain.UserUpdatedEvent) error {
 	metadata, _ := json.Marshal(event)
 	activity := &domain.Activity{
-		ID:         uuid.New().String(),
+		ID:         activityID(event.EventID, metadata),
 		UserID:     event.UserID,
 		EntityType: domain.EntityTypeUser,
 		EntityID:   event.UserID,
@@ -62,10 +68,66 @@ func (uc *ActivityUseCase) RecordUserUpdated(ctx context.Context, event domain.U
 	return uc.activityRepo.Create(ctx, activity)
 }
 
+func (uc *ActivityUseCase) RecordTeamCreated(ctx context.Context, event domain.TeamCreatedEvent) error {
+	metadata, _ := json.Marshal(event)
+	activity := &domain.Activity{
+		ID:         activityID(event.EventID, metadata),
+		UserID:     actorOr(event.ActorID, event.OwnerID),
+		EntityType: domain.EntityTypeTeam,
+		EntityID:   event.TeamID,
+		Action:     domain.ActionTypeCreated,
+		Metadata:   string(metadata),
+		CreatedAt:  event.CreatedAt,
+	}
+	return uc.activityRepo.Create(ctx, activity)
+}
+
+func (uc *ActivityUseCase) RecordTeamUpdated(ctx context.Context, event domain.TeamUpdatedEvent) error {
+	metadata, _ := json.Marshal(event)
+	activity := &domain.Activity{
+		ID:         activityID(event.EventID, metadata),
+		UserID:     actorOr(event.ActorID, event.OwnerID),
+		EntityType: domain.EntityTypeTeam,
+		EntityID:   event.TeamID,
+		Action:     domain.ActionTypeUpdated,
+		Metadata:   string(metadata),
+		CreatedAt:  event.UpdatedAt,
+	}
+	return uc.activityRepo.Create(ctx, activity)
+}
+
+func (uc *ActivityUseCase) RecordTeamMemberAdded(ctx context.Context, event domain.TeamMemberAddedEvent) error {
+	metadata, _ := json.Marshal(event)
+	activity := &domain.Activity{
+		ID:         activityID(event.EventID, metadata),
+		UserID:     actorOr(event.ActorID, event.UserID),
+		EntityType: domain.EntityTypeTeam,
+		EntityID:   event.TeamID,
+		Action:     domain.ActionTypeMemberAdded,
+		Metadata:   string(metadata),
+		CreatedAt:  event.JoinedAt,
+	}
+	return uc.activityRepo.Create(ctx, activity)
+}
+
+func (uc *ActivityUseCase) RecordTeamMemberRemoved(ctx context.Context, event domain.TeamMemberRemovedEvent) error {
+	metadata, _ := json.Marshal(event)
+	activity := &domain.Activity{
+		ID:         activityID(event.EventID, metadata),
+		UserID:     actorOr(event.ActorID, event.UserID),
+		EntityType: domain.EntityTypeTeam,
+		EntityID:   event.TeamID,
+		Action:     domain.ActionTypeMemberRemoved,
+		Metadata:   string(metadata),
+		CreatedAt:  event.RemovedAt,
+	}
+	return uc.activityRepo.Create(ctx, activity)
+}
+
 func (uc *ActivityUseCase) RecordTaskCreated(ctx context.Context, event domain.TaskCreatedEvent) error {
 	metadata, _ := json.Marshal(event)
 	activity := &domain.Activity{
-		ID:         uuid.New().String(),
+		ID:         activityID(event.EventID, metadata),
 		UserID:     event.CreatorID,
 		EntityType: domain.EntityTypeTask,
 		EntityID:   event.TaskID,
@@ -79,7 +141,7 @@ func (uc *ActivityUseCase) RecordTaskCreated(ctx context.Context, event domain.T
 func (uc *ActivityUseCase) RecordTaskUpdated(ctx context.Context, event domain.TaskUpdatedEvent) error {
 	metadata, _ := json.Marshal(event)
 	activity := &domain.Activity{
-		ID:         uuid.New().String(),
+		ID:         activityID(event.EventID, metadata),
 		UserID:     event.UserID,
 		EntityType: domain.EntityTypeTask,
 		EntityID:   event.TaskID,
@@ -90,24 +152,25 @@ func (uc *ActivityUseCase) RecordTaskUpdated(ctx context.Context, event domain.T
 	return uc.activityRepo.Create(ctx, activity)
 }
 
-func (uc *ActivityUseCase) GetUserActivities(ctx context.Context, userID string, from, to int64, limit, offset int) ([]*domain.Activity, int, error) {
-	filter := ActivityFilter{
-		FromTimestamp: from,
-		ToTimestamp:   to,
-		Limit:         limit,
-		Offset:        offset,
+func (uc *ActivityUseCase) RecordTaskDeleted(ctx context.Context, event domain.TaskDeletedEvent) error {
+	metadata, _ := json.Marshal(event)
+	activity := &domain.Activity{
+		ID:         activityID(event.EventID, metadata),
+		UserID:     event.UserID,
+		EntityType: domain.EntityTypeTask,
+		EntityID:   event.TaskID,
+		Action:     domain.ActionTypeDeleted,
+		Metadata:   string(metadata),
+		CreatedAt:  event.DeletedAt,
 	}
-	return uc.activityRepo.GetByUserID(ctx, userID, filter)
+	return uc.activityRepo.Create(ctx, activity)
 }
 
-func (uc *ActivityUseCase) GetActivities(ctx context.Context, entityType, entityID string, from, to int64, limit, offset int) ([]*domain.Activity, int, error) {
-	filter := ActivityFilter{
-		FromTimestamp: from,
-		ToTimestamp:   to,
-		Limit:         limit,
-		Offset:        offset,
-	}
+func (uc *ActivityUseCase) GetUserActivities(ctx context.Context, userID string, filter ActivityFilter) (*domain.ActivityPage, error) {
+	return uc.activityRepo.GetByUserID(ctx, userID, filter)
+}
 
+func (uc *ActivityUseCase) GetActivities(ctx context.Context, entityType, entityID string, filter ActivityFilter) (*domain.ActivityPage, error) {
 	if entityType != "" && entityID != "" {
 		return uc.activityRepo.GetByEntity(ctx, entityType, entityID, filter)
 	}
@@ -115,16 +178,21 @@ func (uc *ActivityUseCase) GetActivities(ctx context.Context, entityType, entity
 	return uc.activityRepo.GetAll(ctx, filter)
 }
 
-func (uc *ActivityUseCase) RecordActivity(ctx context.Context, userID string, entityType domain.EntityType, entityID string, action domain.ActionType, metadata string) error {
-	activity := &domain.Activity{
-		ID:         uuid.New().String(),
-		UserID:     userID,
-		EntityType: entityType,
-		EntityID:   entityID,
-		Action:     action,
-		Metadata:   metadata,
-		CreatedAt:  time.Now(),
+// actorOr returns the user who made a change. Team events name them since
+// v2; v1 events fall back to the user the event is about.
+func actorOr(actorID, fallback string) string {
+	if actorID != "" {
+		return actorID
 	}
-	return uc.activityRepo.Create(ctx, activity)
+	return fallback
 }
 
+// activityID keys an activity by the event it was recorded from. Events
+// published before event IDs existed fall back to a name-based UUID of their
+// payload, which is just as stable across redeliveries.
+func activityID(eventID string, payload []byte) string {
+	if eventID != "" {
+		return eventID
+	}
+	return uuid.NewSHA1(uuid.NameSpaceOID, payload).String()
+}
@@ -63,6 +63,144 @@ func (s *ActivityUseCaseSuite) TestRecordTaskCreated_Success() {
 	assert.NoError(s.T(), err)
 }
 
+func (s *ActivityUseCaseSuite) TestRecordTaskUpdated_KeyedByEventID() {
+	event := domain.TaskUpdatedEvent{
+		EventID:   uuid.New().String(),
+		TaskID:    uuid.New().String(),
+		UserID:    uuid.New().String(),
+		Changes: []domain.TaskFieldChange{
+			{Field: "status", OldValue: "todo", NewValue: "done"},
+			{Field: "priority", OldValue: "low", NewValue: "high"},
+		},
+		UpdatedAt: time.Now(),
+	}
+
+	s.activityRepo.On("Create", s.ctx, mock.MatchedBy(func(a *domain.Activity) bool {
+		return a.ID == event.EventID && a.EntityID == event.TaskID && a.Action == domain.ActionTypeUpdated
+	})).Return(nil)
+
+	err := s.activityUseCase.RecordTaskUpdated(s.ctx, event)
+
+	assert.NoError(s.T(), err)
+}
+
+func (s *ActivityUseCaseSuite) TestRecordTeamCreated_Success() {
+	event := domain.TeamCreatedEvent{
+		EventID:   uuid.New().String(),
+		TeamID:    uuid.New().String(),
+		Name:      "Test Team",
+		OwnerID:   uuid.New().String(),
+		CreatedAt: time.Now(),
+	}
+
+	s.activityRepo.On("Create", s.ctx, mock.MatchedBy(func(a *domain.Activity) bool {
+		return a.ID == event.EventID && a.UserID == event.OwnerID &&
+			a.EntityType == domain.EntityTypeTeam && a.Action == domain.ActionTypeCreated
+	})).Return(nil)
+
+	err := s.activityUseCase.RecordTeamCreated(s.ctx, event)
+
+	assert.NoError(s.T(), err)
+}
+
+func (s *ActivityUseCaseSuite) TestRecordTeamUpdated_Success() {
+	event := domain.TeamUpdatedEvent{
+		EventID:   uuid.New().String(),
+		ActorID:   uuid.New().String(),
+		TeamID:    uuid.New().String(),
+		Name:      "Renamed Team",
+		OwnerID:   uuid.New().String(),
+		UpdatedAt: time.Now(),
+	}
+
+	s.activityRepo.On("Create", s.ctx, mock.MatchedBy(func(a *domain.Activity) bool {
+		return a.ID == event.EventID && a.UserID == event.ActorID && a.EntityID == event.TeamID &&
+			a.EntityType == domain.EntityTypeTeam && a.Action == domain.ActionTypeUpdated
+	})).Return(nil)
+
+	err := s.activityUseCase.RecordTeamUpdated(s.ctx, event)
+
+	assert.NoError(s.T(), err)
+}
+
+func (s *ActivityUseCaseSuite) TestRecordTeamMemberAdded_Success() {
+	event := domain.TeamMemberAddedEvent{
+		EventID:  uuid.New().String(),
+		ActorID:  uuid.New().String(),
+		TeamID:   uuid.New().String(),
+		UserID:   uuid.New().String(),
+		Role:     "member",
+		JoinedAt: time.Now(),
+	}
+
+	s.activityRepo.On("Create", s.ctx, mock.MatchedBy(func(a *domain.Activity) bool {
+		return a.UserID == event.ActorID && a.EntityID == event.TeamID &&
+			a.EntityType == domain.EntityTypeTeam && a.Action == domain.ActionTypeMemberAdded
+	})).Return(nil)
+
+	err := s.activityUseCase.RecordTeamMemberAdded(s.ctx, event)
+
+	assert.NoError(s.T(), err)
+}
+
+func (s *ActivityUseCaseSuite) TestRecordTeamMemberRemoved_V1AttributedToMember() {
+	event := domain.TeamMemberRemovedEvent{
+		EventID:   uuid.New().String(),
+		TeamID:    uuid.New().String(),
+		UserID:    uuid.New().String(),
+		RemovedAt: time.Now(),
+	}
+
+	s.activityRepo.On("Create", s.ctx, mock.MatchedBy(func(a *domain.Activity) bool {
+		return a.UserID == event.UserID && a.EntityID == event.TeamID &&
+			a.EntityType == domain.EntityTypeTeam && a.Action == domain.ActionTypeMemberRemoved
+	})).Return(nil)
+
+	err := s.activityUseCase.RecordTeamMemberRemoved(s.ctx, event)
+
+	assert.NoError(s.T(), err)
+}
+
+func (s *ActivityUseCaseSuite) TestRecordTaskDeleted_Success() {
+	event := domain.TaskDeletedEvent{
+		EventID:   uuid.New().String(),
+		TaskID:    uuid.New().String(),
+		UserID:    uuid.New().String(),
+		Title:     "Test Task",
+		DeletedAt: time.Now(),
+	}
+
+	s.activityRepo.On("Create", s.ctx, mock.MatchedBy(func(a *domain.Activity) bool {
+		return a.ID == event.EventID && a.UserID == event.UserID &&
+			a.EntityType == domain.EntityTypeTask && a.Action == domain.ActionTypeDeleted
+	})).Return(nil)
+
+	err := s.activityUseCase.RecordTaskDeleted(s.ctx, event)
+
+	assert.NoError(s.T(), err)
+}
+
+func (s *ActivityUseCaseSuite) TestRecordUserCreated_RedeliveryWithoutEventID() {
+	event := domain.UserCreatedEvent{
+		UserID:    uuid.New().String(),
+		Email:     "test@example.com",
+		Name:      "Test User",
+		CreatedAt: time.Now(),
+	}
+
+	var ids []string
+	s.activityRepo.On("Create", s.ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		ids = append(ids, args.Get(1).(*domain.Activity).ID)
+	})
+
+	s.Require().NoError(s.activityUseCase.RecordUserCreated(s.ctx, event))
+	s.Require().NoError(s.activityUseCase.RecordUserCreated(s.ctx, event))
+
+	s.Require().Len(ids, 2)
+	assert.NotEmpty(s.T(), ids[0])
+	assert.Equal(s.T(), ids[0], ids[1])
+}
+
 func (s *ActivityUseCaseSuite) TestGetUserActivities_Success() {
 	userID := uuid.New().String()
 	expectedActivities := []*domain.Activity{
@@ -80,14 +218,14 @@ func (s *ActivityUseCaseSuite) TestGetUserActivities_Success() {
 		Offset: 0,
 	}
 
-	s.activityRepo.On("GetByUserID", s.ctx, userID, filter).Return(expectedActivities, 1, nil)
+	s.activityRepo.On("GetByUserID", s.ctx, userID, filter).Return(&domain.ActivityPage{Activities: expectedActivities, Total: 1}, nil)
 
-	result, total, err := s.activityUseCase.GetUserActivities(s.ctx, userID, 0, 0, 10, 0)
+	result, err := s.activityUseCase.GetUserActivities(s.ctx, userID, filter)
 
 	assert.NoError(s.T(), err)
 	assert.NotNil(s.T(), result)
-	assert.Equal(s.T(), 1, total)
-	assert.Len(s.T(), result, 1)
+	assert.Equal(s.T(), 1, result.Total)
+	assert.Len(s.T(), result.Activities, 1)
 }
 
 func (s *ActivityUseCaseSuite) TestGetActivities_Success() {
@@ -109,13 +247,24 @@ func (s *ActivityUseCaseSuite) TestGetActivities_Success() {
 		Offset: 0,
 	}
 
-	s.activityRepo.On("GetByEntity", s.ctx, entityType, entityID, filter).Return(expectedActivities, 1, nil)
+	s.activityRepo.On("GetByEntity", s.ctx, entityType, entityID, filter).Return(&domain.ActivityPage{Activities: expectedActivities, Total: 1}, nil)
 
-	result, total, err := s.activityUseCase.GetActivities(s.ctx, entityType, entityID, 0, 0, 10, 0)
+	result, err := s.activityUseCase.GetActivities(s.ctx, entityType, entityID, filter)
 
 	assert.NoError(s.T(), err)
 	assert.NotNil(s.T(), result)
-	assert.Equal(s.T(), 1, total)
+	assert.Equal(s.T(), 1, result.Total)
+}
+
+func (s *ActivityUseCaseSuite) TestGetActivities_AllWithCursor() {
+	filter := activityUsecase.ActivityFilter{Limit: 10, Cursor: "next-page"}
+
+	s.activityRepo.On("GetAll", s.ctx, filter).Return(&domain.ActivityPage{NextCursor: "after-next-page"}, nil)
+
+	result, err := s.activityUseCase.GetActivities(s.ctx, "", "", filter)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "after-next-page", result.NextCursor)
 }
 
 func TestActivityUseCaseSuite(t *testing.T) {
@@ -16,6 +16,9 @@ const (
 	ActionTypeCreated ActionType = "created"
 	ActionTypeUpdated ActionType = "updated"
 	ActionTypeDeleted ActionType = "deleted"
+
+	ActionTypeMemberAdded   ActionType = "member_added"
+	ActionTypeMemberRemoved ActionType = "member_removed"
 )
 
 type Activity struct {
@@ -27,3 +30,15 @@ type Activity struct {
 	Metadata   string     `json:"metadata"`
 	CreatedAt  time.Time  `json:"created_at"`
 }
+
+// ActivityPage is one page of activities, newest first.
+type ActivityPage struct {
+	Activities []*Activity `json:"activities"`
+	// Total counts the matching activities on the shards that answered, so
+	// it leaves out the rows of UnavailableShards.
+	Total int `json:"total"`
+	// NextCursor fetches the following page; empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+	// UnavailableShards lists the shards left out of a partial page.
+	UnavailableShards []int `json:"unavailable_shards,omitempty"`
+}
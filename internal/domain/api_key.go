@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// APIKey lets a service account call the API. Only the key's hash is
+// stored; the key itself is shown once, when it is created.
+type APIKey struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+	// Prefix is the public part of the key that identifies it in lookups
+	// and listings.
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+
+	// Hash is the hex SHA-256 of the key. It is never serialized.
+	Hash string `json:"-"`
+}
+
+// API key scopes limit what a key may be used for, on top of the service
+// account's team roles.
+const (
+	ScopeTasksRead      = "tasks:read"
+	ScopeTasksWrite     = "tasks:write"
+	ScopeTeamsRead      = "teams:read"
+	ScopeActivitiesRead = "activities:read"
+)
+
+// APIKeyScopes lists the scopes a key can be granted.
+var APIKeyScopes = []string{ScopeTasksRead, ScopeTasksWrite, ScopeTeamsRead, ScopeActivitiesRead}
+
+// HasScope reports whether the key grants scope.
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
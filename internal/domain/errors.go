@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	// ErrVersionConflict means the task changed since the caller read it.
+	ErrVersionConflict = errors.New("version conflict")
+
+	// ErrInvalidCredentials means the email/password pair did not match.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+
+	// ErrUnauthenticated means the request carries no acting user.
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrForbidden means the acting user's team role does not allow the
+	// operation.
+	ErrForbidden = errors.New("forbidden")
+)
+
+// FieldError describes why a single input field was rejected.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned for malformed input, such as a value outside
+// its enum.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, f.Field+": "+f.Message)
+	}
+	return "invalid input: " + strings.Join(parts, "; ")
+}
+
+// Add records a rejected field.
+func (e *ValidationError) Add(field, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+}
+
+// Err returns e if any field was rejected and nil otherwise.
+func (e *ValidationError) Err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+// TransitionError is returned when the task's workflow does not allow the
+// requested status change.
+type TransitionError struct {
+	From TaskStatus
+	To   TaskStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("status cannot change from %q to %q", e.From, e.To)
+}
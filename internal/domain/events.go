@@ -2,7 +2,22 @@ package domain
 
 import "time"
 
+// Event types, as registered in api/events. Each event is published in an
+// envelope that names its type and schema version.
+const (
+	EventTypeUserCreated       = "user.created"
+	EventTypeUserUpdated       = "user.updated"
+	EventTypeTeamCreated       = "team.created"
+	EventTypeTeamUpdated       = "team.updated"
+	EventTypeTeamMemberAdded   = "team.member-added"
+	EventTypeTeamMemberRemoved = "team.member-removed"
+	EventTypeTaskCreated       = "task.created"
+	EventTypeTaskUpdated       = "task.updated"
+	EventTypeTaskDeleted       = "task.deleted"
+)
+
 type UserCreatedEvent struct {
+	EventID   string    `json:"event_id"`
 	UserID    string    `json:"user_id"`
 	Email     string    `json:"email"`
 	Name      string    `json:"name"`
@@ -10,20 +25,52 @@ type UserCreatedEvent struct {
 }
 
 type UserUpdatedEvent struct {
+	EventID   string    `json:"event_id"`
 	UserID    string    `json:"user_id"`
 	Email     string    `json:"email"`
 	Name      string    `json:"name"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// Team events are v2: ActorID is the user who made the change. It is empty
+// in v1 events, which predate it.
+type TeamCreatedEvent struct {
+	EventID   string    `json:"event_id"`
+	ActorID   string    `json:"actor_id"`
+	TeamID    string    `json:"team_id"`
+	Name      string    `json:"name"`
+	OwnerID   string    `json:"owner_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type TeamUpdatedEvent struct {
+	EventID   string    `json:"event_id"`
+	ActorID   string    `json:"actor_id"`
 	TeamID    string    `json:"team_id"`
 	Name      string    `json:"name"`
 	OwnerID   string    `json:"owner_id"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+type TeamMemberAddedEvent struct {
+	EventID  string    `json:"event_id"`
+	ActorID  string    `json:"actor_id"`
+	TeamID   string    `json:"team_id"`
+	UserID   string    `json:"user_id"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type TeamMemberRemovedEvent struct {
+	EventID   string    `json:"event_id"`
+	ActorID   string    `json:"actor_id"`
+	TeamID    string    `json:"team_id"`
+	UserID    string    `json:"user_id"`
+	RemovedAt time.Time `json:"removed_at"`
+}
+
 type TaskCreatedEvent struct {
+	EventID    string    `json:"event_id"`
 	TaskID     string    `json:"task_id"`
 	Title      string    `json:"title"`
 	CreatorID  string    `json:"creator_id"`
@@ -32,7 +79,25 @@ type TaskCreatedEvent struct {
 	CreatedAt  time.Time `json:"created_at"`
 }
 
+// TaskUpdatedEvent is task.updated v2: every field changed by one update.
 type TaskUpdatedEvent struct {
+	EventID   string            `json:"event_id"`
+	TaskID    string            `json:"task_id"`
+	UserID    string            `json:"user_id"`
+	Changes   []TaskFieldChange `json:"changes"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+type TaskFieldChange struct {
+	Field    string `json:"field"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+// TaskUpdatedEventV1 is the original task.updated payload, one event per
+// changed field. It is no longer published but still consumed.
+type TaskUpdatedEventV1 struct {
+	EventID   string    `json:"event_id"`
 	TaskID    string    `json:"task_id"`
 	UserID    string    `json:"user_id"`
 	Field     string    `json:"field"`
@@ -41,3 +106,11 @@ type TaskUpdatedEvent struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+type TaskDeletedEvent struct {
+	EventID   string    `json:"event_id"`
+	TaskID    string    `json:"task_id"`
+	UserID    string    `json:"user_id"`
+	Title     string    `json:"title"`
+	TeamID    string    `json:"team_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
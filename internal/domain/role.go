@@ -0,0 +1,71 @@
+package domain
+
+// TeamRole is a member's role in a team. It decides what the member may do
+// with the team and its tasks.
+type TeamRole string
+
+const (
+	// RoleOwner is the team's creator. There is one owner per team and the
+	// role cannot be granted or removed.
+	RoleOwner  TeamRole = "owner"
+	RoleAdmin  TeamRole = "admin"
+	RoleMember TeamRole = "member"
+	RoleViewer TeamRole = "viewer"
+)
+
+// TeamRoles lists the roles from most to least privileged.
+var TeamRoles = []TeamRole{RoleOwner, RoleAdmin, RoleMember, RoleViewer}
+
+func (r TeamRole) Valid() bool {
+	switch r {
+	case RoleOwner, RoleAdmin, RoleMember, RoleViewer:
+		return true
+	}
+	return false
+}
+
+// Permission is an action on a team or on the team's tasks.
+type Permission string
+
+const (
+	PermissionViewTeam Permission = "team:view"
+	// PermissionManageTeam covers renaming the team.
+	PermissionManageTeam Permission = "team:manage"
+	// PermissionManageMembers covers adding and removing members and viewers.
+	PermissionManageMembers Permission = "team:members"
+	// PermissionManageAdmins covers adding and removing admins.
+	PermissionManageAdmins Permission = "team:admins"
+	PermissionViewTasks    Permission = "tasks:view"
+	// PermissionEditTasks covers creating and updating tasks, and deleting
+	// the tasks the member created.
+	PermissionEditTasks Permission = "tasks:edit"
+	// PermissionDeleteTasks covers deleting any of the team's tasks.
+	PermissionDeleteTasks Permission = "tasks:delete"
+)
+
+var rolePermissions = map[TeamRole][]Permission{
+	RoleOwner: {
+		PermissionViewTeam, PermissionManageTeam, PermissionManageMembers, PermissionManageAdmins,
+		PermissionViewTasks, PermissionEditTasks, PermissionDeleteTasks,
+	},
+	RoleAdmin: {
+		PermissionViewTeam, PermissionManageTeam, PermissionManageMembers,
+		PermissionViewTasks, PermissionEditTasks, PermissionDeleteTasks,
+	},
+	RoleMember: {
+		PermissionViewTeam, PermissionViewTasks, PermissionEditTasks,
+	},
+	RoleViewer: {
+		PermissionViewTeam, PermissionViewTasks,
+	},
+}
+
+// Can reports whether the role grants p. Unknown roles grant nothing.
+func (r TeamRole) Can(p Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
@@ -11,6 +11,18 @@ const (
 	TaskStatusCancelled  TaskStatus = "cancelled"
 )
 
+// TaskStatuses lists the statuses in workflow order, which is also the order
+// tasks are sorted in by status.
+var TaskStatuses = []TaskStatus{TaskStatusTodo, TaskStatusInProgress, TaskStatusDone, TaskStatusCancelled}
+
+func (s TaskStatus) Valid() bool {
+	switch s {
+	case TaskStatusTodo, TaskStatusInProgress, TaskStatusDone, TaskStatusCancelled:
+		return true
+	}
+	return false
+}
+
 type TaskPriority string
 
 const (
@@ -19,6 +31,17 @@ const (
 	TaskPriorityHigh   TaskPriority = "high"
 )
 
+// TaskPriorities lists the priorities from lowest to highest.
+var TaskPriorities = []TaskPriority{TaskPriorityLow, TaskPriorityMedium, TaskPriorityHigh}
+
+func (p TaskPriority) Valid() bool {
+	switch p {
+	case TaskPriorityLow, TaskPriorityMedium, TaskPriorityHigh:
+		return true
+	}
+	return false
+}
+
 type Task struct {
 	ID          string       `json:"id"`
 	Title       string       `json:"title"`
@@ -31,6 +54,8 @@ type Task struct {
 	DueDate     time.Time    `json:"due_date"`
 	CreatedAt   time.Time    `json:"created_at"`
 	UpdatedAt   time.Time    `json:"updated_at"`
+	// Version is incremented by every update.
+	Version int64 `json:"version"`
 }
 
 type TaskHistory struct {
@@ -42,3 +67,25 @@ type TaskHistory struct {
 	NewValue  string    `json:"new_value"`
 	ChangedAt time.Time `json:"changed_at"`
 }
+
+// TaskPage is one page of a task listing. Total is nil when the caller chose
+// not to count the matching tasks.
+type TaskPage struct {
+	Tasks      []*Task `json:"tasks"`
+	Total      *int    `json:"total,omitempty"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+// TaskSearchHit is a task matched by a full-text search.
+type TaskSearchHit struct {
+	Task       *Task          `json:"task"`
+	Rank       float64        `json:"rank"`
+	Highlights TaskHighlights `json:"highlights"`
+}
+
+// TaskHighlights are HTML snippets of the matched task: the task text is
+// escaped and the matched words are wrapped in <mark></mark>.
+type TaskHighlights struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
@@ -8,6 +8,18 @@ type User struct {
 	Name      string    `json:"name"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// OwnerID is set for service accounts: the user who manages them.
+	OwnerID string `json:"owner_id,omitempty"`
+
+	// PasswordHash is the bcrypt hash of the user's password. It is only
+	// loaded by UserRepository.GetByEmail and is never serialized.
+	PasswordHash string `json:"-"`
+}
+
+// IsServiceAccount reports whether the user is a service account, an
+// account for automation that signs in with API keys instead of a password.
+func (u *User) IsServiceAccount() bool {
+	return u.OwnerID != ""
 }
 
 type Team struct {
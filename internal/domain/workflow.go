@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Workflow lists, for each status, the statuses a task may move to next.
+// A status without an entry is terminal.
+type Workflow map[TaskStatus][]TaskStatus
+
+// DefaultWorkflow applies to teams without a workflow of their own: work
+// moves from todo through in_progress to done, finished tasks can be
+// reopened, and cancelled is terminal.
+var DefaultWorkflow = Workflow{
+	TaskStatusTodo:       {TaskStatusInProgress, TaskStatusCancelled},
+	TaskStatusInProgress: {TaskStatusTodo, TaskStatusDone, TaskStatusCancelled},
+	TaskStatusDone:       {TaskStatusInProgress},
+}
+
+// Allows reports whether a task may move from one status to another.
+// Keeping the current status is always allowed.
+func (w Workflow) Allows(from, to TaskStatus) bool {
+	return from == to || slices.Contains(w[from], to)
+}
+
+// Validate checks that the workflow only mentions known statuses.
+func (w Workflow) Validate() error {
+	for from, targets := range w {
+		if !from.Valid() {
+			return fmt.Errorf("unknown status %q", from)
+		}
+		for _, to := range targets {
+			if !to.Valid() {
+				return fmt.Errorf("unknown status %q in transitions from %q", to, from)
+			}
+		}
+	}
+	return nil
+}
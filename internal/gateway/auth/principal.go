@@ -0,0 +1,38 @@
+package auth
+
+import "context"
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	UserID string
+	// APIKeyID is set when the caller is a service account authenticated
+	// with an API key; the key's Scopes then limit what it may call.
+	APIKeyID string
+	Scopes   []string
+}
+
+// Allows reports whether the caller may use routes that need scope. Users
+// signed in with a token are not limited by scopes.
+func (p Principal) Allows(scope string) bool {
+	if p.APIKeyID == "" {
+		return true
+	}
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFrom returns the caller stored by WithPrincipal.
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
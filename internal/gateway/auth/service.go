@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/pkg/config"
+)
+
+const (
+	defaultAccessTTL  = 15 * time.Minute
+	defaultRefreshTTL = 30 * 24 * time.Hour
+)
+
+// ErrInvalidAPIKey is returned for API keys that are malformed, unknown or
+// revoked.
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// CredentialsVerifier checks a user's email and password and resolves
+// service accounts' API keys; it is implemented by the user-service client.
+type CredentialsVerifier interface {
+	Authenticate(ctx context.Context, email, password string) (*domain.User, error)
+	VerifyAPIKey(ctx context.Context, key string) (*domain.APIKey, error)
+}
+
+// TokenPair is returned by login and refresh.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// Service issues and verifies the gateway's tokens. Access tokens are
+// verified by signature alone; refresh tokens are also checked against the
+// session store, which lets a refresh token be used once and lets logout
+// end the session.
+type Service struct {
+	users      CredentialsVerifier
+	keys       *KeySet
+	sessions   SessionStore
+	issuer     string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	now        func() time.Time
+}
+
+func NewService(users CredentialsVerifier, sessions SessionStore, cfg config.AuthConfig) (*Service, error) {
+	keys, err := NewKeySetFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	accessTTL := time.Duration(cfg.AccessTTL) * time.Second
+	if accessTTL == 0 {
+		accessTTL = defaultAccessTTL
+	}
+	refreshTTL := time.Duration(cfg.RefreshTTL) * time.Second
+	if refreshTTL == 0 {
+		refreshTTL = defaultRefreshTTL
+	}
+
+	return &Service{
+		users:      users,
+		keys:       keys,
+		sessions:   sessions,
+		issuer:     cfg.Issuer,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+		now:        time.Now,
+	}, nil
+}
+
+// Login checks the credentials and starts a new session.
+func (s *Service) Login(ctx context.Context, email, password string) (*TokenPair, error) {
+	user, err := s.users.Authenticate(ctx, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID := uuid.New().String()
+	tokenID := uuid.New().String()
+	if err := s.sessions.Create(ctx, sessionID, user.ID, tokenID, s.refreshTTL); err != nil {
+		return nil, err
+	}
+
+	return s.issue(user.ID, sessionID, tokenID)
+}
+
+// Refresh exchanges a refresh token for a new pair in the same session.
+// Presenting a refresh token that was already exchanged ends the session.
+func (s *Service) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	claims, err := s.parse(refreshToken, TokenRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenID := uuid.New().String()
+	err = s.sessions.Rotate(ctx, claims.SessionID, claims.Subject, claims.ID, tokenID, s.refreshTTL)
+	switch {
+	case errors.Is(err, ErrTokenReused):
+		if delErr := s.sessions.Delete(ctx, claims.SessionID); delErr != nil {
+			return nil, delErr
+		}
+		return nil, ErrInvalidToken
+	case errors.Is(err, ErrSessionNotFound):
+		return nil, ErrInvalidToken
+	case err != nil:
+		return nil, err
+	}
+
+	return s.issue(claims.Subject, claims.SessionID, tokenID)
+}
+
+// Logout ends the session of refreshToken. Access tokens already issued
+// stay valid until they expire.
+func (s *Service) Logout(ctx context.Context, refreshToken string) error {
+	claims, err := s.parse(refreshToken, TokenRefresh)
+	if err != nil {
+		return err
+	}
+	return s.sessions.Delete(ctx, claims.SessionID)
+}
+
+// VerifyAccessToken returns the caller identified by an access token.
+func (s *Service) VerifyAccessToken(token string) (Principal, error) {
+	claims, err := s.parse(token, TokenAccess)
+	if err != nil {
+		return Principal{}, err
+	}
+	return Principal{UserID: claims.Subject}, nil
+}
+
+// VerifyAPIKey returns the service account an API key belongs to, limited
+// to the key's scopes. Keys are checked by user-service on every call, so a
+// revoked key stops working at once.
+func (s *Service) VerifyAPIKey(ctx context.Context, key string) (Principal, error) {
+	apiKey, err := s.users.VerifyAPIKey(ctx, key)
+	if status.Code(err) == codes.Unauthenticated {
+		return Principal{}, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return Principal{}, err
+	}
+	return Principal{UserID: apiKey.UserID, APIKeyID: apiKey.ID, Scopes: apiKey.Scopes}, nil
+}
+
+func (s *Service) parse(token, tokenType string) (*Claims, error) {
+	claims, err := s.keys.Parse(token, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if claims.Type != tokenType || claims.Issuer != s.issuer || claims.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+func (s *Service) issue(userID, sessionID, refreshTokenID string) (*TokenPair, error) {
+	now := s.now()
+
+	access, err := s.keys.Sign(Claims{
+		Issuer:    s.issuer,
+		Subject:   userID,
+		Type:      TokenAccess,
+		SessionID: sessionID,
+		ID:        uuid.New().String(),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(s.accessTTL).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	refresh, err := s.keys.Sign(Claims{
+		Issuer:    s.issuer,
+		Subject:   userID,
+		Type:      TokenRefresh,
+		SessionID: sessionID,
+		ID:        refreshTokenID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(s.refreshTTL).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(s.accessTTL / time.Second),
+	}, nil
+}
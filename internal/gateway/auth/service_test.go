@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/pkg/config"
+)
+
+type stubUsers struct{}
+
+func (stubUsers) Authenticate(_ context.Context, email, password string) (*domain.User, error) {
+	if email == "test@example.com" && password == "secret password" {
+		return &domain.User{ID: "user-1", Email: email}, nil
+	}
+	return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+}
+
+func (stubUsers) VerifyAPIKey(_ context.Context, key string) (*domain.APIKey, error) {
+	if key == "tf_abc_secret" {
+		return &domain.APIKey{ID: "key-1", UserID: "bot-1", Scopes: []string{domain.ScopeTasksRead}}, nil
+	}
+	return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+}
+
+// memorySessions is an in-memory SessionStore with the same semantics as
+// session.RedisStore, without expiry.
+type memorySessions struct {
+	mu       sync.Mutex
+	sessions map[string]string
+}
+
+func (m *memorySessions) Create(_ context.Context, sessionID, _, tokenID string, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[sessionID] = tokenID
+	return nil
+}
+
+func (m *memorySessions) Rotate(_ context.Context, sessionID, _, oldTokenID, newTokenID string, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current, ok := m.sessions[sessionID]
+	if !ok {
+		return ErrSessionNotFound
+	}
+	if current != oldTokenID {
+		return ErrTokenReused
+	}
+	m.sessions[sessionID] = newTokenID
+	return nil
+}
+
+func (m *memorySessions) Delete(_ context.Context, sessionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, sessionID)
+	return nil
+}
+
+type ServiceSuite struct {
+	suite.Suite
+	ctx      context.Context
+	now      time.Time
+	sessions *memorySessions
+	service  *Service
+}
+
+func (s *ServiceSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.now = time.Unix(1_700_000_000, 0)
+	s.sessions = &memorySessions{sessions: map[string]string{}}
+	s.service = s.newService(newKey.ID, oldKey, newKey)
+}
+
+func (s *ServiceSuite) newService(active string, keys ...Key) *Service {
+	cfg := config.AuthConfig{Issuer: "taskflow", AccessTTL: 60, RefreshTTL: 3600, ActiveKey: active}
+	for _, k := range keys {
+		cfg.Keys = append(cfg.Keys, config.SigningKeyConfig{ID: k.ID, Secret: string(k.Secret)})
+	}
+
+	svc, err := NewService(stubUsers{}, s.sessions, cfg)
+	s.Require().NoError(err)
+	svc.now = func() time.Time { return s.now }
+	return svc
+}
+
+func (s *ServiceSuite) login() *TokenPair {
+	tokens, err := s.service.Login(s.ctx, "test@example.com", "secret password")
+	s.Require().NoError(err)
+	return tokens
+}
+
+func (s *ServiceSuite) TestLogin() {
+	tokens := s.login()
+
+	assert.Equal(s.T(), "Bearer", tokens.TokenType)
+	assert.EqualValues(s.T(), 60, tokens.ExpiresIn)
+
+	principal, err := s.service.VerifyAccessToken(tokens.AccessToken)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "user-1", principal.UserID)
+}
+
+func (s *ServiceSuite) TestLogin_WrongPassword() {
+	_, err := s.service.Login(s.ctx, "test@example.com", "wrong")
+
+	assert.Equal(s.T(), codes.Unauthenticated, status.Code(err))
+	assert.Empty(s.T(), s.sessions.sessions)
+}
+
+func (s *ServiceSuite) TestVerifyAccessToken_RejectsRefreshToken() {
+	tokens := s.login()
+
+	_, err := s.service.VerifyAccessToken(tokens.RefreshToken)
+
+	assert.ErrorIs(s.T(), err, ErrInvalidToken)
+}
+
+func (s *ServiceSuite) TestVerifyAccessToken_Expired() {
+	tokens := s.login()
+	s.now = s.now.Add(time.Minute)
+
+	_, err := s.service.VerifyAccessToken(tokens.AccessToken)
+
+	assert.ErrorIs(s.T(), err, ErrExpiredToken)
+}
+
+func (s *ServiceSuite) TestVerifyAccessToken_AfterKeyRotation() {
+	tokens := s.login()
+
+	rotated := s.newService(oldKey.ID, oldKey, newKey)
+	_, err := rotated.VerifyAccessToken(tokens.AccessToken)
+
+	assert.NoError(s.T(), err)
+}
+
+func (s *ServiceSuite) TestRefresh_RotatesToken() {
+	first := s.login()
+
+	second, err := s.service.Refresh(s.ctx, first.RefreshToken)
+	s.Require().NoError(err)
+	assert.NotEqual(s.T(), first.RefreshToken, second.RefreshToken)
+
+	_, err = s.service.Refresh(s.ctx, second.RefreshToken)
+	assert.NoError(s.T(), err)
+}
+
+func (s *ServiceSuite) TestRefresh_ReuseRevokesSession() {
+	first := s.login()
+	second, err := s.service.Refresh(s.ctx, first.RefreshToken)
+	s.Require().NoError(err)
+
+	_, err = s.service.Refresh(s.ctx, first.RefreshToken)
+	assert.ErrorIs(s.T(), err, ErrInvalidToken)
+
+	_, err = s.service.Refresh(s.ctx, second.RefreshToken)
+	assert.ErrorIs(s.T(), err, ErrInvalidToken)
+}
+
+func (s *ServiceSuite) TestRefresh_RejectsAccessToken() {
+	tokens := s.login()
+
+	_, err := s.service.Refresh(s.ctx, tokens.AccessToken)
+
+	assert.ErrorIs(s.T(), err, ErrInvalidToken)
+}
+
+func (s *ServiceSuite) TestLogout_EndsSession() {
+	tokens := s.login()
+
+	s.Require().NoError(s.service.Logout(s.ctx, tokens.RefreshToken))
+
+	_, err := s.service.Refresh(s.ctx, tokens.RefreshToken)
+	assert.ErrorIs(s.T(), err, ErrInvalidToken)
+}
+
+func (s *ServiceSuite) TestVerifyAPIKey() {
+	principal, err := s.service.VerifyAPIKey(s.ctx, "tf_abc_secret")
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), "bot-1", principal.UserID)
+	assert.True(s.T(), principal.Allows(domain.ScopeTasksRead))
+	assert.False(s.T(), principal.Allows(domain.ScopeTasksWrite))
+}
+
+func (s *ServiceSuite) TestVerifyAPIKey_Invalid() {
+	_, err := s.service.VerifyAPIKey(s.ctx, "tf_abc_wrong")
+
+	assert.ErrorIs(s.T(), err, ErrInvalidAPIKey)
+}
+
+func TestServiceSuite(t *testing.T) {
+	suite.Run(t, new(ServiceSuite))
+}
@@ -0,0 +1,24 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/Sol1tud9/taskflow/pkg/session"
+)
+
+var (
+	ErrSessionNotFound = session.ErrNotFound
+	ErrTokenReused     = session.ErrTokenReused
+)
+
+// SessionStore tracks the refresh token that is currently valid for each
+// login session. Every refresh replaces it, so each refresh token can be
+// exchanged only once. It is implemented by session.RedisStore.
+type SessionStore interface {
+	Create(ctx context.Context, sessionID, userID, tokenID string, ttl time.Duration) error
+	// Rotate replaces the session's current token ID with newTokenID if it
+	// is still oldTokenID.
+	Rotate(ctx context.Context, sessionID, userID, oldTokenID, newTokenID string, ttl time.Duration) error
+	Delete(ctx context.Context, sessionID string) error
+}
@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Sol1tud9/taskflow/pkg/config"
+)
+
+// Token types, carried in the "typ" claim so that a refresh token cannot be
+// used as an access token and vice versa.
+const (
+	TokenAccess  = "access"
+	TokenRefresh = "refresh"
+)
+
+const (
+	algorithm = "HS256"
+	// minSecretLength is the HS256 key size recommended by RFC 7518.
+	minSecretLength = 32
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token expired")
+)
+
+// Claims is the payload of the JWTs issued by the gateway.
+type Claims struct {
+	Issuer    string `json:"iss,omitempty"`
+	Subject   string `json:"sub"`
+	Type      string `json:"typ"`
+	SessionID string `json:"sid,omitempty"`
+	ID        string `json:"jti,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+type header struct {
+	Alg   string `json:"alg"`
+	Typ   string `json:"typ"`
+	KeyID string `json:"kid"`
+}
+
+// Key is an HMAC signing key identified by the "kid" header of the tokens
+// it signs.
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// KeySet signs tokens with its active key and verifies tokens signed with
+// any of its keys.
+type KeySet struct {
+	active Key
+	keys   map[string]Key
+}
+
+func NewKeySet(activeID string, keys []Key) (*KeySet, error) {
+	set := &KeySet{keys: make(map[string]Key, len(keys))}
+	for _, k := range keys {
+		if k.ID == "" {
+			return nil, errors.New("signing key id is required")
+		}
+		if len(k.Secret) < minSecretLength {
+			return nil, fmt.Errorf("signing key %q: secret must be at least %d bytes", k.ID, minSecretLength)
+		}
+		if _, ok := set.keys[k.ID]; ok {
+			return nil, fmt.Errorf("signing key %q is listed twice", k.ID)
+		}
+		set.keys[k.ID] = k
+	}
+
+	active, ok := set.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("active signing key %q is not configured", activeID)
+	}
+	set.active = active
+
+	return set, nil
+}
+
+// NewKeySetFromConfig builds the key set described by the auth config.
+func NewKeySetFromConfig(cfg config.AuthConfig) (*KeySet, error) {
+	keys := make([]Key, 0, len(cfg.Keys))
+	for _, k := range cfg.Keys {
+		keys = append(keys, Key{ID: k.ID, Secret: []byte(k.Secret)})
+	}
+	return NewKeySet(cfg.ActiveKey, keys)
+}
+
+// Sign encodes claims as a JWT signed with the active key.
+func (ks *KeySet) Sign(claims Claims) (string, error) {
+	h, err := json.Marshal(header{Alg: algorithm, Typ: "JWT", KeyID: ks.active.ID})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encodeSegment(h) + "." + encodeSegment(payload)
+	return signingInput + "." + encodeSegment(sign(ks.active.Secret, signingInput)), nil
+}
+
+// Parse verifies token's signature and expiry at now and returns its
+// claims. Any malformed or forged token yields ErrInvalidToken.
+func (ks *KeySet) Parse(token string, now time.Time) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil || h.Alg != algorithm {
+		return nil, ErrInvalidToken
+	}
+	key, ok := ks.keys[h.KeyID]
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, sign(key.Secret, parts[0]+"."+parts[1])) {
+		return nil, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+
+	return &claims, nil
+}
+
+func sign(secret []byte, input string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(input))
+	return mac.Sum(nil)
+}
+
+func encodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
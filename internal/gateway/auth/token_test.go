@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	oldKey = Key{ID: "2024-01", Secret: []byte("old-secret-old-secret-old-secret-0")}
+	newKey = Key{ID: "2024-02", Secret: []byte("new-secret-new-secret-new-secret-0")}
+)
+
+type KeySetSuite struct {
+	suite.Suite
+	now    time.Time
+	claims Claims
+}
+
+func (s *KeySetSuite) SetupTest() {
+	s.now = time.Unix(1_700_000_000, 0)
+	s.claims = Claims{
+		Issuer:    "taskflow",
+		Subject:   "user-1",
+		Type:      TokenAccess,
+		IssuedAt:  s.now.Unix(),
+		ExpiresAt: s.now.Add(time.Minute).Unix(),
+	}
+}
+
+func (s *KeySetSuite) keySet(active string, keys ...Key) *KeySet {
+	ks, err := NewKeySet(active, keys)
+	s.Require().NoError(err)
+	return ks
+}
+
+func (s *KeySetSuite) TestSignAndParse() {
+	ks := s.keySet(newKey.ID, newKey)
+
+	token, err := ks.Sign(s.claims)
+	s.Require().NoError(err)
+
+	claims, err := ks.Parse(token, s.now)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), s.claims, *claims)
+}
+
+func (s *KeySetSuite) TestParse_RotatedKey() {
+	token, err := s.keySet(oldKey.ID, oldKey).Sign(s.claims)
+	s.Require().NoError(err)
+
+	rotated := s.keySet(newKey.ID, oldKey, newKey)
+	_, err = rotated.Parse(token, s.now)
+	assert.NoError(s.T(), err)
+
+	retired := s.keySet(newKey.ID, newKey)
+	_, err = retired.Parse(token, s.now)
+	assert.ErrorIs(s.T(), err, ErrInvalidToken)
+}
+
+func (s *KeySetSuite) TestParse_Expired() {
+	ks := s.keySet(newKey.ID, newKey)
+	token, err := ks.Sign(s.claims)
+	s.Require().NoError(err)
+
+	_, err = ks.Parse(token, s.now.Add(time.Minute))
+
+	assert.ErrorIs(s.T(), err, ErrExpiredToken)
+}
+
+func (s *KeySetSuite) TestParse_Rejects() {
+	ks := s.keySet(newKey.ID, newKey)
+	token, err := ks.Sign(s.claims)
+	s.Require().NoError(err)
+	parts := strings.Split(token, ".")
+
+	forged, err := s.keySet(newKey.ID, Key{ID: newKey.ID, Secret: oldKey.Secret}).Sign(s.claims)
+	s.Require().NoError(err)
+
+	tests := map[string]string{
+		"malformed":        "not-a-token",
+		"tampered payload": parts[0] + "." + encodeSegment([]byte(`{"sub":"admin","typ":"access","exp":9999999999}`)) + "." + parts[2],
+		"alg none":         encodeSegment([]byte(`{"alg":"none","kid":"2024-02"}`)) + "." + parts[1] + ".",
+		"wrong secret":     forged,
+		"unknown key":      encodeSegment([]byte(`{"alg":"HS256","kid":"missing"}`)) + "." + parts[1] + "." + parts[2],
+	}
+
+	for name, token := range tests {
+		s.Run(name, func() {
+			_, err := ks.Parse(token, s.now)
+			assert.ErrorIs(s.T(), err, ErrInvalidToken)
+		})
+	}
+}
+
+func (s *KeySetSuite) TestNewKeySet_Invalid() {
+	_, err := NewKeySet("missing", []Key{newKey})
+	assert.Error(s.T(), err)
+
+	_, err = NewKeySet("short", []Key{{ID: "short", Secret: []byte("too short")}})
+	assert.Error(s.T(), err)
+
+	_, err = NewKeySet(newKey.ID, []Key{newKey, newKey})
+	assert.Error(s.T(), err)
+}
+
+func TestKeySetSuite(t *testing.T) {
+	suite.Run(t, new(KeySetSuite))
+}
@@ -2,145 +2,98 @@ package bootstrap
 
 import (
 	"context"
+	"net/http"
+	"time"
 
-	activityStorage "github.com/Sol1tud9/taskflow/internal/activity/storage/sharded"
-	activityUsecase "github.com/Sol1tud9/taskflow/internal/activity/usecase"
-	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/gateway/auth"
 	"github.com/Sol1tud9/taskflow/internal/gateway/cache"
+	"github.com/Sol1tud9/taskflow/internal/gateway/client"
 	"github.com/Sol1tud9/taskflow/internal/gateway/handler"
-	taskPublisher "github.com/Sol1tud9/taskflow/internal/task/publisher"
-	taskStorage "github.com/Sol1tud9/taskflow/internal/task/storage/postgres"
-	taskUsecase "github.com/Sol1tud9/taskflow/internal/task/usecase"
-	userPublisher "github.com/Sol1tud9/taskflow/internal/user/publisher"
-	userStorage "github.com/Sol1tud9/taskflow/internal/user/storage/postgres"
-	userUsecase "github.com/Sol1tud9/taskflow/internal/user/usecase"
+	"github.com/Sol1tud9/taskflow/internal/gateway/ratelimit"
 	"github.com/Sol1tud9/taskflow/pkg/config"
 	"github.com/Sol1tud9/taskflow/pkg/logger"
+	"github.com/Sol1tud9/taskflow/pkg/metrics"
+	"github.com/Sol1tud9/taskflow/pkg/session"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
+const httpShutdownTimeout = 10 * time.Second
+
 type App struct {
-	Config          *config.GatewayConfig
-	Cache           *cache.RedisCache
-	Handler         *handler.Handler
-	UserStorage     *userStorage.Storage
-	TaskStorage     *taskStorage.Storage
-	ActivityStorage *activityStorage.ShardedStorage
-	UserPublisher   *userPublisher.Publisher
-	TaskPublisher   *taskPublisher.Publisher
+	Config       *config.GatewayConfig
+	Cache        *cache.RedisCache
+	Handler      *handler.Handler
+	UserConn     *grpc.ClientConn
+	TaskConn     *grpc.ClientConn
+	ActivityConn *grpc.ClientConn
+	// MetricsServer serves /metrics on server.metrics_port, which is not
+	// published outside the internal network.
+	MetricsServer *http.Server
 }
 
 func NewApp(cfg *config.GatewayConfig) (*App, error) {
 	redisCache := cache.NewRedisCache(cfg.Redis)
 
-	userStore, err := userStorage.NewStorage(cfg.UserDB)
+	userConn, err := client.NewConn(cfg.Services.User)
 	if err != nil {
-		logger.Error("failed to init user storage", zap.Error(err))
+		logger.Error("failed to init user-service client", zap.Error(err))
 		return nil, err
 	}
 
-	taskStore, err := taskStorage.NewStorage(cfg.TaskDB)
+	taskConn, err := client.NewConn(cfg.Services.Task)
 	if err != nil {
-		logger.Error("failed to init task storage", zap.Error(err))
+		logger.Error("failed to init task-service client", zap.Error(err))
 		return nil, err
 	}
 
-	activityStore, err := activityStorage.NewShardedStorage(cfg.ActivityDB)
+	activityConn, err := client.NewConn(cfg.Services.Activity)
 	if err != nil {
-		logger.Error("failed to init activity storage", zap.Error(err))
+		logger.Error("failed to init activity-service client", zap.Error(err))
 		return nil, err
 	}
 
-	userTopics := map[string]string{
-		"user_created": cfg.Kafka.Topics["user_created"],
-		"user_updated": cfg.Kafka.Topics["user_updated"],
-		"team_updated": cfg.Kafka.Topics["team_updated"],
-	}
-	taskTopics := map[string]string{
-		"task_created": cfg.Kafka.Topics["task_created"],
-		"task_updated": cfg.Kafka.Topics["task_updated"],
-	}
-
-	userPub := userPublisher.NewPublisher(cfg.Kafka.Brokers, userTopics)
-	taskPub := taskPublisher.NewPublisher(cfg.Kafka.Brokers, taskTopics)
-
-	userUC := userUsecase.NewUserUseCase(userStore, userPub)
-	teamRepoAdapter := &teamRepoAdapter{storage: userStore}
-	teamMemberRepoAdapter := &teamMemberRepoAdapter{storage: userStore}
-	teamUC := userUsecase.NewTeamUseCase(teamRepoAdapter, teamMemberRepoAdapter, userPub)
+	userClient := client.NewUserClient(userConn)
+	teamClient := client.NewTeamClient(userConn)
+	taskClient := client.NewTaskClient(taskConn)
+	activityClient := client.NewActivityClient(activityConn)
+	accountClient := client.NewServiceAccountClient(userConn)
 
-	historyRepoAdapter := &historyRepoAdapter{storage: taskStore}
-	taskUC := taskUsecase.NewTaskUseCase(taskStore, historyRepoAdapter, taskPub)
+	authService, err := auth.NewService(userClient, session.NewRedisStore(redisCache.Client()), cfg.Auth)
+	if err != nil {
+		logger.Error("failed to init auth", zap.Error(err))
+		return nil, err
+	}
 
-	activityUC := activityUsecase.NewActivityUseCase(activityStore)
+	store := ratelimit.NewFallbackStore(ratelimit.NewRedisStore(redisCache.Client()), ratelimit.NewMemoryStore())
+	limiter, err := ratelimit.New(cfg.RateLimit, store)
+	if err != nil {
+		logger.Error("failed to init rate limiter", zap.Error(err))
+		return nil, err
+	}
 
-	h := handler.NewHandler(redisCache, userUC, teamUC, taskUC, activityUC, userStore, userStore)
+	h := handler.NewHandler(redisCache, userClient, teamClient, taskClient, activityClient, userClient, teamClient, authService, accountClient, limiter)
 
 	return &App{
-		Config:          cfg,
-		Cache:           redisCache,
-		Handler:         h,
-		UserStorage:     userStore,
-		TaskStorage:     taskStore,
-		ActivityStorage: activityStore,
-		UserPublisher:   userPub,
-		TaskPublisher:   taskPub,
+		Config:        cfg,
+		Cache:         redisCache,
+		Handler:       h,
+		UserConn:      userConn,
+		TaskConn:      taskConn,
+		ActivityConn:  activityConn,
+		MetricsServer: metrics.NewServer(cfg.Server.MetricsPort),
 	}, nil
 }
 
 func (a *App) Close() {
-	_ = a.Cache.Close()
-	_ = a.UserPublisher.Close()
-	_ = a.TaskPublisher.Close()
-	a.UserStorage.Close()
-	a.TaskStorage.Close()
-	a.ActivityStorage.Close()
-}
-
-type teamRepoAdapter struct {
-	storage *userStorage.Storage
-}
-
-func (a *teamRepoAdapter) Create(ctx context.Context, team *domain.Team) error {
-	return a.storage.CreateTeam(ctx, team)
-}
-
-func (a *teamRepoAdapter) GetByID(ctx context.Context, id string) (*domain.Team, error) {
-	return a.storage.GetTeamByID(ctx, id)
-}
-
-func (a *teamRepoAdapter) Update(ctx context.Context, team *domain.Team) error {
-	return a.storage.UpdateTeam(ctx, team)
-}
-
-func (a *teamRepoAdapter) Delete(ctx context.Context, id string) error {
-	return a.storage.DeleteTeam(ctx, id)
-}
-
-type teamMemberRepoAdapter struct {
-	storage *userStorage.Storage
-}
-
-func (a *teamMemberRepoAdapter) Add(ctx context.Context, member *domain.TeamMember) error {
-	return a.storage.AddTeamMember(ctx, member)
-}
-
-func (a *teamMemberRepoAdapter) GetByTeamID(ctx context.Context, teamID string) ([]*domain.TeamMember, error) {
-	return a.storage.GetTeamMembersByTeamID(ctx, teamID)
-}
-
-func (a *teamMemberRepoAdapter) Remove(ctx context.Context, teamID, userID string) error {
-	return a.storage.RemoveTeamMember(ctx, teamID, userID)
-}
-
-type historyRepoAdapter struct {
-	storage *taskStorage.Storage
-}
-
-func (a *historyRepoAdapter) Create(ctx context.Context, history *domain.TaskHistory) error {
-	return a.storage.CreateHistory(ctx, history)
-}
+	ctx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+	defer cancel()
+	if err := a.MetricsServer.Shutdown(ctx); err != nil {
+		logger.Error("failed to shutdown metrics server", zap.Error(err))
+	}
 
-func (a *historyRepoAdapter) GetByTaskID(ctx context.Context, taskID string) ([]*domain.TaskHistory, error) {
-	return a.storage.GetHistoryByTaskID(ctx, taskID)
+	_ = a.Cache.Close()
+	_ = a.UserConn.Close()
+	_ = a.TaskConn.Close()
+	_ = a.ActivityConn.Close()
 }
@@ -0,0 +1,11 @@
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var lookupsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "gateway_cache_lookups_total",
+	Help: "Cache reads by key kind and result (hit, miss or error).",
+}, []string{"kind", "result"})
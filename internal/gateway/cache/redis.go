@@ -3,7 +3,9 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -35,12 +37,25 @@ func NewRedisCache(cfg config.RedisConfig) *RedisCache {
 
 func (c *RedisCache) Get(ctx context.Context, key string, dest interface{}) error {
 	val, err := c.client.Get(ctx, key).Result()
-	if err != nil {
+	switch {
+	case errors.Is(err, redis.Nil):
+		lookupsTotal.WithLabelValues(keyKind(key), "miss").Inc()
+		return err
+	case err != nil:
+		lookupsTotal.WithLabelValues(keyKind(key), "error").Inc()
 		return err
 	}
+	lookupsTotal.WithLabelValues(keyKind(key), "hit").Inc()
 	return json.Unmarshal([]byte(val), dest)
 }
 
+// keyKind returns the part of key before the first ":", such as "user" for
+// "user:<id>", to label metrics without a series per key.
+func keyKind(key string) string {
+	kind, _, _ := strings.Cut(key, ":")
+	return kind
+}
+
 func (c *RedisCache) Set(ctx context.Context, key string, value interface{}) error {
 	data, err := json.Marshal(value)
 	if err != nil {
@@ -53,6 +68,12 @@ func (c *RedisCache) Delete(ctx context.Context, key string) error {
 	return c.client.Del(ctx, key).Err()
 }
 
+// Client returns the underlying Redis client, for gateway components that
+// keep their own state next to the cache.
+func (c *RedisCache) Client() *redis.Client {
+	return c.client
+}
+
 func (c *RedisCache) Close() error {
 	return c.client.Close()
 }
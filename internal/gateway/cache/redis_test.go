@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/go-redis/redismock/v8"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"github.com/Sol1tud9/taskflow/pkg/config"
@@ -42,10 +43,13 @@ func (s *RedisCacheSuite) TestGet_Success() {
 
 	s.client.ExpectGet(key).SetVal(value)
 
+	hits := testutil.ToFloat64(lookupsTotal.WithLabelValues("test", "hit"))
+
 	var result map[string]string
 	err := s.cache.Get(s.ctx, key, &result)
 
 	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), hits+1, testutil.ToFloat64(lookupsTotal.WithLabelValues("test", "hit")))
 	assert.Equal(s.T(), "123", result["id"])
 	assert.Equal(s.T(), "test", result["name"])
 }
@@ -54,11 +58,13 @@ func (s *RedisCacheSuite) TestGet_NotFound() {
 	key := "test:key"
 
 	s.client.ExpectGet(key).RedisNil()
+	misses := testutil.ToFloat64(lookupsTotal.WithLabelValues("test", "miss"))
 
 	var result map[string]string
 	err := s.cache.Get(s.ctx, key, &result)
 
 	assert.Error(s.T(), err)
+	assert.Equal(s.T(), misses+1, testutil.ToFloat64(lookupsTotal.WithLabelValues("test", "miss")))
 }
 
 func (s *RedisCacheSuite) TestSet_Success() {
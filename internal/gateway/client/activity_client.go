@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+
+	activityUsecase "github.com/Sol1tud9/taskflow/internal/activity/usecase"
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/pb/activity_api"
+	"github.com/Sol1tud9/taskflow/internal/pb/models"
+	"google.golang.org/grpc"
+)
+
+// ActivityClient implements the gateway's ActivityUseCase over activity-service.
+type ActivityClient struct {
+	client activity_api.ActivityServiceClient
+}
+
+func NewActivityClient(conn *grpc.ClientConn) *ActivityClient {
+	return &ActivityClient{
+		client: activity_api.NewActivityServiceClient(conn),
+	}
+}
+
+func (c *ActivityClient) GetUserActivities(ctx context.Context, userID string, filter activityUsecase.ActivityFilter) (*domain.ActivityPage, error) {
+	resp, err := c.client.GetUserActivities(ctx, &activity_api.GetUserActivitiesRequest{
+		UserId:        userID,
+		FromTimestamp: filter.FromTimestamp,
+		ToTimestamp:   filter.ToTimestamp,
+		Limit:         int32(filter.Limit),
+		Offset:        int32(filter.Offset),
+		Cursor:        filter.Cursor,
+		AllowPartial:  filter.AllowPartial,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toActivityPage(resp.GetActivities(), resp.GetTotal(), resp.GetNextCursor(), resp.GetUnavailableShards()), nil
+}
+
+func (c *ActivityClient) GetActivities(ctx context.Context, entityType, entityID string, filter activityUsecase.ActivityFilter) (*domain.ActivityPage, error) {
+	resp, err := c.client.GetActivities(ctx, &activity_api.GetActivitiesRequest{
+		EntityType:    entityType,
+		EntityId:      entityID,
+		FromTimestamp: filter.FromTimestamp,
+		ToTimestamp:   filter.ToTimestamp,
+		Limit:         int32(filter.Limit),
+		Offset:        int32(filter.Offset),
+		Cursor:        filter.Cursor,
+		AllowPartial:  filter.AllowPartial,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toActivityPage(resp.GetActivities(), resp.GetTotal(), resp.GetNextCursor(), resp.GetUnavailableShards()), nil
+}
+
+func toActivityPage(activities []*models.Activity, total int32, nextCursor string, unavailable []int32) *domain.ActivityPage {
+	page := &domain.ActivityPage{
+		Activities: toActivities(activities),
+		Total:      int(total),
+		NextCursor: nextCursor,
+	}
+	for _, shardID := range unavailable {
+		page.UnavailableShards = append(page.UnavailableShards, int(shardID))
+	}
+	return page
+}
+
+func toActivities(activities []*models.Activity) []*domain.Activity {
+	result := make([]*domain.Activity, 0, len(activities))
+	for _, a := range activities {
+		result = append(result, toActivity(a))
+	}
+	return result
+}
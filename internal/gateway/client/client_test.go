@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/Sol1tud9/taskflow/internal/pb/models"
+	"github.com/Sol1tud9/taskflow/internal/pb/user_api"
+	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+type fakeUserService struct {
+	user_api.UnimplementedUserServiceServer
+	calls       int32
+	failures    int32
+	failureCode codes.Code
+	delay       time.Duration
+}
+
+func (f *fakeUserService) GetUser(ctx context.Context, req *user_api.GetUserRequest) (*user_api.GetUserResponse, error) {
+	call := atomic.AddInt32(&f.calls, 1)
+	if call <= f.failures {
+		return nil, status.Error(f.failureCode, "backend failure")
+	}
+
+	if f.delay > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(f.delay):
+		}
+	}
+
+	return &user_api.GetUserResponse{User: &models.User{
+		Id:        req.GetId(),
+		Email:     "test@example.com",
+		Name:      "Test User",
+		CreatedAt: 1700000000,
+	}}, nil
+}
+
+func (f *fakeUserService) UpdateUser(ctx context.Context, req *user_api.UpdateUserRequest) (*user_api.UpdateUserResponse, error) {
+	call := atomic.AddInt32(&f.calls, 1)
+	if call <= f.failures {
+		return nil, status.Error(f.failureCode, "backend failure")
+	}
+
+	return &user_api.UpdateUserResponse{User: &models.User{Id: req.GetId(), Name: req.GetName()}}, nil
+}
+
+type ClientSuite struct {
+	suite.Suite
+	ctx        context.Context
+	service    *fakeUserService
+	grpcServer *grpc.Server
+	conn       *grpc.ClientConn
+	userClient *UserClient
+}
+
+func (s *ClientSuite) SetupSuite() {
+	_ = logger.Init("error")
+}
+
+func (s *ClientSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.service = &fakeUserService{}
+
+	lis := bufconn.Listen(1024 * 1024)
+	s.grpcServer = grpc.NewServer()
+	user_api.RegisterUserServiceServer(s.grpcServer, s.service)
+	go func() {
+		_ = s.grpcServer.Serve(lis)
+	}()
+
+	conn, err := NewConn(
+		config.ServiceEndpoint{GRPCAddr: "passthrough:///bufnet", TimeoutMs: 1000, MaxRetries: 2},
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+	)
+	s.Require().NoError(err)
+	s.conn = conn
+	s.userClient = NewUserClient(conn)
+}
+
+func (s *ClientSuite) TearDownTest() {
+	_ = s.conn.Close()
+	s.grpcServer.Stop()
+}
+
+func (s *ClientSuite) TestGetUser_MapsResponse() {
+	user, err := s.userClient.GetUser(s.ctx, "user-1")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "user-1", user.ID)
+	assert.Equal(s.T(), "test@example.com", user.Email)
+	assert.Equal(s.T(), int64(1700000000), user.CreatedAt.Unix())
+	assert.True(s.T(), user.UpdatedAt.IsZero())
+}
+
+func (s *ClientSuite) TestGetUser_RetriesUnavailable() {
+	s.service.failures = 2
+	s.service.failureCode = codes.Unavailable
+
+	user, err := s.userClient.GetUser(s.ctx, "user-1")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "user-1", user.ID)
+	assert.Equal(s.T(), int32(3), atomic.LoadInt32(&s.service.calls))
+}
+
+func (s *ClientSuite) TestGetUser_GivesUpAfterMaxRetries() {
+	s.service.failures = 10
+	s.service.failureCode = codes.Unavailable
+
+	_, err := s.userClient.GetUser(s.ctx, "user-1")
+
+	assert.Equal(s.T(), codes.Unavailable, status.Code(err))
+	assert.Equal(s.T(), int32(3), atomic.LoadInt32(&s.service.calls))
+}
+
+func (s *ClientSuite) TestGetUser_DoesNotRetryNotFound() {
+	s.service.failures = 1
+	s.service.failureCode = codes.NotFound
+
+	_, err := s.userClient.GetUser(s.ctx, "user-1")
+
+	assert.Equal(s.T(), codes.NotFound, status.Code(err))
+	assert.Equal(s.T(), int32(1), atomic.LoadInt32(&s.service.calls))
+}
+
+func (s *ClientSuite) TestUpdateUser_DoesNotRetryUnavailable() {
+	s.service.failures = 1
+	s.service.failureCode = codes.Unavailable
+
+	_, err := s.userClient.UpdateUser(s.ctx, "user-1", "", "New Name", "", "")
+
+	assert.Equal(s.T(), codes.Unavailable, status.Code(err))
+	assert.Equal(s.T(), int32(1), atomic.LoadInt32(&s.service.calls))
+}
+
+func (s *ClientSuite) TestGetUser_AppliesDeadline() {
+	s.service.delay = 3 * time.Second
+
+	_, err := s.userClient.GetUser(s.ctx, "user-1")
+
+	assert.Equal(s.T(), codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestClientSuite(t *testing.T) {
+	suite.Run(t, new(ClientSuite))
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/Sol1tud9/taskflow/internal/pb/activity_api"
+	"github.com/Sol1tud9/taskflow/internal/pb/task_api"
+	"github.com/Sol1tud9/taskflow/internal/pb/user_api"
+	"github.com/Sol1tud9/taskflow/pkg/actor"
+	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/events"
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultTimeout    = 5 * time.Second
+	defaultMaxRetries = 3
+	retryBaseDelay    = 100 * time.Millisecond
+)
+
+// retryableMethods are the read-only RPCs. Only they are retried: a write
+// that failed with UNAVAILABLE may still have been applied, and repeating it
+// could create a task or a team twice.
+var retryableMethods = map[string]bool{
+	user_api.UserService_GetUser_FullMethodName:                   true,
+	user_api.UserService_ListUsers_FullMethodName:                 true,
+	user_api.UserService_GetTeam_FullMethodName:                   true,
+	user_api.UserService_ListTeams_FullMethodName:                 true,
+	user_api.UserService_GetTeamMembers_FullMethodName:            true,
+	user_api.UserService_ListUserMemberships_FullMethodName:       true,
+	user_api.UserService_ListServiceAccounts_FullMethodName:       true,
+	user_api.UserService_ListAPIKeys_FullMethodName:               true,
+	task_api.TaskService_GetTask_FullMethodName:                   true,
+	task_api.TaskService_ListTasks_FullMethodName:                 true,
+	task_api.TaskService_SearchTasks_FullMethodName:               true,
+	task_api.TaskService_GetTaskHistory_FullMethodName:            true,
+	activity_api.ActivityService_GetActivities_FullMethodName:     true,
+	activity_api.ActivityService_GetUserActivities_FullMethodName: true,
+}
+
+// NewConn opens a client connection to a backend service. Every unary call
+// gets the endpoint's deadline, and read-only calls are retried with
+// exponential backoff while the backend answers UNAVAILABLE. The acting user
+// travels with each call.
+func NewConn(endpoint config.ServiceEndpoint, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	timeout := time.Duration(endpoint.TimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	maxRetries := endpoint.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultMaxRetries
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(
+			events.UnaryClientInterceptor(),
+			actor.UnaryClientInterceptor(),
+			timeoutInterceptor(timeout),
+			retryInterceptor(maxRetries, retryBaseDelay),
+		),
+	}
+	dialOpts = append(dialOpts, opts...)
+
+	return grpc.NewClient(endpoint.GRPCAddr, dialOpts...)
+}
+
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > timeout {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func retryInterceptor(maxRetries int, baseDelay time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var err error
+		for attempt := 0; ; attempt++ {
+			err = invoker(ctx, method, req, reply, cc, opts...)
+			if status.Code(err) != codes.Unavailable || !retryableMethods[method] || attempt >= maxRetries {
+				return err
+			}
+
+			delay := baseDelay << attempt
+			logger.Warn("backend unavailable, retrying",
+				zap.String("method", method),
+				zap.Int("attempt", attempt+1),
+				zap.Duration("delay", delay),
+			)
+
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(delay):
+			}
+		}
+	}
+}
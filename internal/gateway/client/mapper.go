@@ -0,0 +1,134 @@
+package client
+
+import (
+	"time"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/pb/models"
+	"github.com/Sol1tud9/taskflow/internal/pb/task_api"
+)
+
+func toUser(u *models.User) *domain.User {
+	return &domain.User{
+		ID:        u.GetId(),
+		Email:     u.GetEmail(),
+		Name:      u.GetName(),
+		CreatedAt: fromUnix(u.GetCreatedAt()),
+		UpdatedAt: fromUnix(u.GetUpdatedAt()),
+		OwnerID:   u.GetOwnerId(),
+	}
+}
+
+func toTeam(t *models.Team) *domain.Team {
+	return &domain.Team{
+		ID:        t.GetId(),
+		Name:      t.GetName(),
+		OwnerID:   t.GetOwnerId(),
+		CreatedAt: fromUnix(t.GetCreatedAt()),
+		UpdatedAt: fromUnix(t.GetUpdatedAt()),
+	}
+}
+
+func toTeamMember(m *models.TeamMember) *domain.TeamMember {
+	return &domain.TeamMember{
+		ID:       m.GetId(),
+		TeamID:   m.GetTeamId(),
+		UserID:   m.GetUserId(),
+		Role:     m.GetRole(),
+		JoinedAt: fromUnix(m.GetJoinedAt()),
+	}
+}
+
+func toAPIKey(k *models.APIKey) *domain.APIKey {
+	key := &domain.APIKey{
+		ID:        k.GetId(),
+		UserID:    k.GetUserId(),
+		Name:      k.GetName(),
+		Prefix:    k.GetPrefix(),
+		Scopes:    k.GetScopes(),
+		CreatedAt: fromUnix(k.GetCreatedAt()),
+	}
+	if k.GetLastUsedAt() != 0 {
+		lastUsedAt := fromUnix(k.GetLastUsedAt())
+		key.LastUsedAt = &lastUsedAt
+	}
+	if k.GetRevokedAt() != 0 {
+		revokedAt := fromUnix(k.GetRevokedAt())
+		key.RevokedAt = &revokedAt
+	}
+	return key
+}
+
+func toTask(t *models.Task) *domain.Task {
+	return &domain.Task{
+		ID:          t.GetId(),
+		Title:       t.GetTitle(),
+		Description: t.GetDescription(),
+		Status:      domain.TaskStatus(t.GetStatus()),
+		Priority:    domain.TaskPriority(t.GetPriority()),
+		AssigneeID:  t.GetAssigneeId(),
+		CreatorID:   t.GetCreatorId(),
+		TeamID:      t.GetTeamId(),
+		DueDate:     fromUnix(t.GetDueDate()),
+		CreatedAt:   fromUnix(t.GetCreatedAt()),
+		UpdatedAt:   fromUnix(t.GetUpdatedAt()),
+		Version:     t.GetVersion(),
+	}
+}
+
+func toTaskPage(resp *task_api.ListTasksResponse) *domain.TaskPage {
+	page := &domain.TaskPage{
+		Tasks:      make([]*domain.Task, 0, len(resp.GetTasks())),
+		NextCursor: resp.GetNextCursor(),
+	}
+	for _, t := range resp.GetTasks() {
+		page.Tasks = append(page.Tasks, toTask(t))
+	}
+	if resp.Total != nil {
+		total := int(resp.GetTotal())
+		page.Total = &total
+	}
+	return page
+}
+
+func toTaskSearchHit(h *task_api.TaskSearchHit) *domain.TaskSearchHit {
+	return &domain.TaskSearchHit{
+		Task: toTask(h.GetTask()),
+		Rank: h.GetRank(),
+		Highlights: domain.TaskHighlights{
+			Title:       h.GetTitleHighlight(),
+			Description: h.GetDescriptionHighlight(),
+		},
+	}
+}
+
+func toTaskHistory(h *models.TaskHistory) *domain.TaskHistory {
+	return &domain.TaskHistory{
+		ID:        h.GetId(),
+		TaskID:    h.GetTaskId(),
+		UserID:    h.GetUserId(),
+		Field:     h.GetField(),
+		OldValue:  h.GetOldValue(),
+		NewValue:  h.GetNewValue(),
+		ChangedAt: fromUnix(h.GetChangedAt()),
+	}
+}
+
+func toActivity(a *models.Activity) *domain.Activity {
+	return &domain.Activity{
+		ID:         a.GetId(),
+		UserID:     a.GetUserId(),
+		EntityType: domain.EntityType(a.GetEntityType()),
+		EntityID:   a.GetEntityId(),
+		Action:     domain.ActionType(a.GetAction()),
+		Metadata:   a.GetMetadata(),
+		CreatedAt:  fromUnix(a.GetCreatedAt()),
+	}
+}
+
+func fromUnix(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
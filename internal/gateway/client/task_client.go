@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/pb/task_api"
+	taskUsecase "github.com/Sol1tud9/taskflow/internal/task/usecase"
+	"google.golang.org/grpc"
+)
+
+// TaskClient implements the gateway's TaskUseCase over task-service.
+type TaskClient struct {
+	client task_api.TaskServiceClient
+}
+
+func NewTaskClient(conn *grpc.ClientConn) *TaskClient {
+	return &TaskClient{
+		client: task_api.NewTaskServiceClient(conn),
+	}
+}
+
+func (c *TaskClient) CreateTask(ctx context.Context, input taskUsecase.CreateTaskInput) (*domain.Task, error) {
+	resp, err := c.client.CreateTask(ctx, &task_api.CreateTaskRequest{
+		Title:       input.Title,
+		Description: input.Description,
+		Priority:    input.Priority,
+		AssigneeId:  input.AssigneeID,
+		CreatorId:   input.CreatorID,
+		TeamId:      input.TeamID,
+		DueDate:     input.DueDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTask(resp.GetTask()), nil
+}
+
+func (c *TaskClient) GetTask(ctx context.Context, id string) (*domain.Task, error) {
+	resp, err := c.client.GetTask(ctx, &task_api.GetTaskRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return toTask(resp.GetTask()), nil
+}
+
+func (c *TaskClient) ListTasks(ctx context.Context, filter taskUsecase.TaskFilter) (*domain.TaskPage, error) {
+	resp, err := c.client.ListTasks(ctx, &task_api.ListTasksRequest{
+		TeamId:      filter.TeamID,
+		AssigneeId:  filter.AssigneeID,
+		CreatorId:   filter.CreatorID,
+		Statuses:    filter.Statuses,
+		Priority:    filter.Priority,
+		DueFrom:     filter.DueFrom,
+		DueTo:       filter.DueTo,
+		CreatedFrom: filter.CreatedFrom,
+		CreatedTo:   filter.CreatedTo,
+		UpdatedFrom: filter.UpdatedFrom,
+		UpdatedTo:   filter.UpdatedTo,
+		Overdue:     filter.Overdue,
+		Sort:        filter.Sort,
+		Limit:       int32(filter.Limit),
+		Offset:      int32(filter.Offset),
+		Cursor:      filter.Cursor,
+		Count:       string(filter.Count),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toTaskPage(resp), nil
+}
+
+func (c *TaskClient) SearchTasks(ctx context.Context, query string, filter taskUsecase.TaskFilter) ([]*domain.TaskSearchHit, int, error) {
+	resp, err := c.client.SearchTasks(ctx, &task_api.SearchTasksRequest{
+		Query:       query,
+		TeamId:      filter.TeamID,
+		AssigneeId:  filter.AssigneeID,
+		CreatorId:   filter.CreatorID,
+		Statuses:    filter.Statuses,
+		Priority:    filter.Priority,
+		DueFrom:     filter.DueFrom,
+		DueTo:       filter.DueTo,
+		CreatedFrom: filter.CreatedFrom,
+		CreatedTo:   filter.CreatedTo,
+		UpdatedFrom: filter.UpdatedFrom,
+		UpdatedTo:   filter.UpdatedTo,
+		Overdue:     filter.Overdue,
+		Limit:       int32(filter.Limit),
+		Offset:      int32(filter.Offset),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]*domain.TaskSearchHit, 0, len(resp.GetHits()))
+	for _, h := range resp.GetHits() {
+		hits = append(hits, toTaskSearchHit(h))
+	}
+	return hits, int(resp.GetTotal()), nil
+}
+
+func (c *TaskClient) UpdateTask(ctx context.Context, id string, input taskUsecase.UpdateTaskInput) (*domain.Task, error) {
+	resp, err := c.client.UpdateTask(ctx, &task_api.UpdateTaskRequest{
+		Id:          id,
+		Title:       input.Title,
+		Description: input.Description,
+		Status:      input.Status,
+		Priority:    input.Priority,
+		AssigneeId:  input.AssigneeID,
+		DueDate:     input.DueDate,
+		UserId:      input.UserID,
+
+		ExpectedVersion: input.ExpectedVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTask(resp.GetTask()), nil
+}
+
+func (c *TaskClient) DeleteTask(ctx context.Context, id string) error {
+	_, err := c.client.DeleteTask(ctx, &task_api.DeleteTaskRequest{Id: id})
+	return err
+}
+
+func (c *TaskClient) GetTaskHistory(ctx context.Context, taskID string) ([]*domain.TaskHistory, error) {
+	resp, err := c.client.GetTaskHistory(ctx, &task_api.GetTaskHistoryRequest{TaskId: taskID})
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]*domain.TaskHistory, 0, len(resp.GetHistory()))
+	for _, h := range resp.GetHistory() {
+		history = append(history, toTaskHistory(h))
+	}
+	return history, nil
+}
@@ -0,0 +1,234 @@
+package client
+
+import (
+	"context"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/pb/user_api"
+	"google.golang.org/grpc"
+)
+
+// UserClient implements the gateway's UserUseCase and UserLister over user-service.
+type UserClient struct {
+	client user_api.UserServiceClient
+}
+
+func NewUserClient(conn *grpc.ClientConn) *UserClient {
+	return &UserClient{
+		client: user_api.NewUserServiceClient(conn),
+	}
+}
+
+func (c *UserClient) CreateUser(ctx context.Context, email, name, password string) (*domain.User, error) {
+	resp, err := c.client.CreateUser(ctx, &user_api.CreateUserRequest{
+		Email:    email,
+		Name:     name,
+		Password: password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toUser(resp.GetUser()), nil
+}
+
+// Authenticate implements auth.CredentialsVerifier.
+func (c *UserClient) Authenticate(ctx context.Context, email, password string) (*domain.User, error) {
+	resp, err := c.client.Authenticate(ctx, &user_api.AuthenticateRequest{
+		Email:    email,
+		Password: password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toUser(resp.GetUser()), nil
+}
+
+// VerifyAPIKey implements auth.CredentialsVerifier.
+func (c *UserClient) VerifyAPIKey(ctx context.Context, key string) (*domain.APIKey, error) {
+	resp, err := c.client.VerifyAPIKey(ctx, &user_api.VerifyAPIKeyRequest{Key: key})
+	if err != nil {
+		return nil, err
+	}
+	return toAPIKey(resp.GetApiKey()), nil
+}
+
+func (c *UserClient) GetUser(ctx context.Context, id string) (*domain.User, error) {
+	resp, err := c.client.GetUser(ctx, &user_api.GetUserRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return toUser(resp.GetUser()), nil
+}
+
+func (c *UserClient) UpdateUser(ctx context.Context, id, email, name, password, currentPassword string) (*domain.User, error) {
+	resp, err := c.client.UpdateUser(ctx, &user_api.UpdateUserRequest{
+		Id:              id,
+		Email:           email,
+		Name:            name,
+		Password:        password,
+		CurrentPassword: currentPassword,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toUser(resp.GetUser()), nil
+}
+
+func (c *UserClient) List(ctx context.Context) ([]*domain.User, error) {
+	resp, err := c.client.ListUsers(ctx, &user_api.ListUsersRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*domain.User, 0, len(resp.GetUsers()))
+	for _, u := range resp.GetUsers() {
+		users = append(users, toUser(u))
+	}
+	return users, nil
+}
+
+// TeamClient implements the gateway's TeamUseCase and TeamLister over user-service.
+type TeamClient struct {
+	client user_api.UserServiceClient
+}
+
+func NewTeamClient(conn *grpc.ClientConn) *TeamClient {
+	return &TeamClient{
+		client: user_api.NewUserServiceClient(conn),
+	}
+}
+
+func (c *TeamClient) CreateTeam(ctx context.Context, name, ownerID string) (*domain.Team, error) {
+	resp, err := c.client.CreateTeam(ctx, &user_api.CreateTeamRequest{
+		Name:    name,
+		OwnerId: ownerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTeam(resp.GetTeam()), nil
+}
+
+func (c *TeamClient) GetTeam(ctx context.Context, id string) (*domain.Team, error) {
+	resp, err := c.client.GetTeam(ctx, &user_api.GetTeamRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return toTeam(resp.GetTeam()), nil
+}
+
+func (c *TeamClient) ListTeams(ctx context.Context) ([]*domain.Team, error) {
+	resp, err := c.client.ListTeams(ctx, &user_api.ListTeamsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make([]*domain.Team, 0, len(resp.GetTeams()))
+	for _, t := range resp.GetTeams() {
+		teams = append(teams, toTeam(t))
+	}
+	return teams, nil
+}
+
+func (c *TeamClient) UpdateTeam(ctx context.Context, id, name string) (*domain.Team, error) {
+	resp, err := c.client.UpdateTeam(ctx, &user_api.UpdateTeamRequest{Id: id, Name: name})
+	if err != nil {
+		return nil, err
+	}
+	return toTeam(resp.GetTeam()), nil
+}
+
+func (c *TeamClient) AddTeamMember(ctx context.Context, teamID, userID, role string) (*domain.TeamMember, error) {
+	resp, err := c.client.AddTeamMember(ctx, &user_api.AddTeamMemberRequest{
+		TeamId: teamID,
+		UserId: userID,
+		Role:   role,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTeamMember(resp.GetMember()), nil
+}
+
+func (c *TeamClient) GetTeamMembers(ctx context.Context, teamID string) ([]*domain.TeamMember, error) {
+	resp, err := c.client.GetTeamMembers(ctx, &user_api.GetTeamMembersRequest{TeamId: teamID})
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*domain.TeamMember, 0, len(resp.GetMembers()))
+	for _, m := range resp.GetMembers() {
+		members = append(members, toTeamMember(m))
+	}
+	return members, nil
+}
+
+func (c *TeamClient) RemoveTeamMember(ctx context.Context, teamID, userID string) error {
+	_, err := c.client.RemoveTeamMember(ctx, &user_api.RemoveTeamMemberRequest{TeamId: teamID, UserId: userID})
+	return err
+}
+
+// ServiceAccountClient implements the gateway's ServiceAccountUseCase over user-service.
+type ServiceAccountClient struct {
+	client user_api.UserServiceClient
+}
+
+func NewServiceAccountClient(conn *grpc.ClientConn) *ServiceAccountClient {
+	return &ServiceAccountClient{
+		client: user_api.NewUserServiceClient(conn),
+	}
+}
+
+func (c *ServiceAccountClient) CreateServiceAccount(ctx context.Context, name string) (*domain.User, error) {
+	resp, err := c.client.CreateServiceAccount(ctx, &user_api.CreateServiceAccountRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	return toUser(resp.GetServiceAccount()), nil
+}
+
+func (c *ServiceAccountClient) ListServiceAccounts(ctx context.Context) ([]*domain.User, error) {
+	resp, err := c.client.ListServiceAccounts(ctx, &user_api.ListServiceAccountsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*domain.User, 0, len(resp.GetServiceAccounts()))
+	for _, a := range resp.GetServiceAccounts() {
+		accounts = append(accounts, toUser(a))
+	}
+	return accounts, nil
+}
+
+func (c *ServiceAccountClient) CreateAPIKey(ctx context.Context, serviceAccountID, name string, scopes []string) (*domain.APIKey, string, error) {
+	resp, err := c.client.CreateAPIKey(ctx, &user_api.CreateAPIKeyRequest{
+		ServiceAccountId: serviceAccountID,
+		Name:             name,
+		Scopes:           scopes,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return toAPIKey(resp.GetApiKey()), resp.GetKey(), nil
+}
+
+func (c *ServiceAccountClient) ListAPIKeys(ctx context.Context, serviceAccountID string) ([]*domain.APIKey, error) {
+	resp, err := c.client.ListAPIKeys(ctx, &user_api.ListAPIKeysRequest{ServiceAccountId: serviceAccountID})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*domain.APIKey, 0, len(resp.GetApiKeys()))
+	for _, k := range resp.GetApiKeys() {
+		keys = append(keys, toAPIKey(k))
+	}
+	return keys, nil
+}
+
+func (c *ServiceAccountClient) RevokeAPIKey(ctx context.Context, serviceAccountID, keyID string) error {
+	_, err := c.client.RevokeAPIKey(ctx, &user_api.RevokeAPIKeyRequest{
+		ServiceAccountId: serviceAccountID,
+		Id:               keyID,
+	})
+	return err
+}
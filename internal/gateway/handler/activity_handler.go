@@ -2,37 +2,96 @@ package handler
 
 import (
 	"net/http"
+	"net/url"
 	"strconv"
 
+	activityUsecase "github.com/Sol1tud9/taskflow/internal/activity/usecase"
 	"github.com/Sol1tud9/taskflow/internal/domain"
 )
 
+// GetActivities returns the history of one entity the caller can see: their
+// own user, a team they belong to or a task they can view. Without an entity
+// it returns the caller's own activity.
 func (h *Handler) GetActivities(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
+	entityType, entityID := query.Get("entity_type"), query.Get("entity_id")
 
-	entityType := query.Get("entity_type")
-	entityID := query.Get("entity_id")
+	if entityType == "" && entityID == "" {
+		page, err := h.activityUC.GetUserActivities(r.Context(), callerID(r), activityFilter(query))
+		if err != nil {
+			respondServiceError(w, err)
+			return
+		}
+		respondActivities(w, page)
+		return
+	}
+
+	if !h.canViewEntity(w, r, domain.EntityType(entityType), entityID) {
+		return
+	}
+
+	page, err := h.activityUC.GetActivities(r.Context(), entityType, entityID, activityFilter(query))
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondActivities(w, page)
+}
+
+// canViewEntity reports whether the caller may read the entity's history and
+// responds with the error if not. Team and task access is checked by the
+// services that own them, with the caller forwarded as the actor.
+func (h *Handler) canViewEntity(w http.ResponseWriter, r *http.Request, entityType domain.EntityType, entityID string) bool {
+	var err error
+	switch entityType {
+	case domain.EntityTypeUser:
+		if entityID != callerID(r) {
+			respondError(w, http.StatusForbidden, "users can only read their own activity")
+			return false
+		}
+		return true
+	case domain.EntityTypeTeam:
+		_, err = h.teamUC.GetTeam(r.Context(), entityID)
+	case domain.EntityTypeTask:
+		_, err = h.taskUC.GetTask(r.Context(), entityID)
+	default:
+		respondError(w, http.StatusBadRequest, "entity_type must be one of user, team, task")
+		return false
+	}
+
+	if err != nil {
+		respondServiceError(w, err)
+		return false
+	}
+	return true
+}
+
+func activityFilter(query url.Values) activityUsecase.ActivityFilter {
 	limit, _ := strconv.Atoi(query.Get("limit"))
 	offset, _ := strconv.Atoi(query.Get("offset"))
 	from, _ := strconv.ParseInt(query.Get("from"), 10, 64)
 	to, _ := strconv.ParseInt(query.Get("to"), 10, 64)
+	allowPartial, _ := strconv.ParseBool(query.Get("allow_partial"))
 
 	if limit <= 0 {
 		limit = 20
 	}
 
-	activities, total, err := h.activityUC.GetActivities(r.Context(), entityType, entityID, from, to, limit, offset)
-	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
-		return
+	return activityUsecase.ActivityFilter{
+		FromTimestamp: from,
+		ToTimestamp:   to,
+		Limit:         limit,
+		Offset:        offset,
+		Cursor:        query.Get("cursor"),
+		AllowPartial:  allowPartial,
 	}
+}
 
-	if activities == nil {
-		activities = []*domain.Activity{}
+func respondActivities(w http.ResponseWriter, page *domain.ActivityPage) {
+	if page.Activities == nil {
+		page.Activities = []*domain.Activity{}
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"activities": activities,
-		"total":      total,
-	})
+	respondJSON(w, http.StatusOK, page)
 }
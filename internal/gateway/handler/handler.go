@@ -8,37 +8,63 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	activityUsecase "github.com/Sol1tud9/taskflow/internal/activity/usecase"
 	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/gateway/auth"
 	"github.com/Sol1tud9/taskflow/internal/gateway/cache"
+	"github.com/Sol1tud9/taskflow/internal/gateway/ratelimit"
 	taskUsecase "github.com/Sol1tud9/taskflow/internal/task/usecase"
+	"github.com/Sol1tud9/taskflow/pkg/events"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type UserUseCase interface {
-	CreateUser(ctx context.Context, email, name string) (*domain.User, error)
+	CreateUser(ctx context.Context, email, name, password string) (*domain.User, error)
 	GetUser(ctx context.Context, id string) (*domain.User, error)
-	UpdateUser(ctx context.Context, id, email, name string) (*domain.User, error)
+	UpdateUser(ctx context.Context, id, email, name, password, currentPassword string) (*domain.User, error)
+}
+
+type AuthService interface {
+	Login(ctx context.Context, email, password string) (*auth.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (*auth.TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
+	VerifyAccessToken(token string) (auth.Principal, error)
+	VerifyAPIKey(ctx context.Context, key string) (auth.Principal, error)
+}
+
+type ServiceAccountUseCase interface {
+	CreateServiceAccount(ctx context.Context, name string) (*domain.User, error)
+	ListServiceAccounts(ctx context.Context) ([]*domain.User, error)
+	CreateAPIKey(ctx context.Context, serviceAccountID, name string, scopes []string) (*domain.APIKey, string, error)
+	ListAPIKeys(ctx context.Context, serviceAccountID string) ([]*domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, serviceAccountID, keyID string) error
 }
 
 type TeamUseCase interface {
 	CreateTeam(ctx context.Context, name, ownerID string) (*domain.Team, error)
 	GetTeam(ctx context.Context, id string) (*domain.Team, error)
+	UpdateTeam(ctx context.Context, id, name string) (*domain.Team, error)
 	AddTeamMember(ctx context.Context, teamID, userID, role string) (*domain.TeamMember, error)
 	GetTeamMembers(ctx context.Context, teamID string) ([]*domain.TeamMember, error)
+	RemoveTeamMember(ctx context.Context, teamID, userID string) error
 }
 
 type TaskUseCase interface {
 	CreateTask(ctx context.Context, input taskUsecase.CreateTaskInput) (*domain.Task, error)
 	GetTask(ctx context.Context, id string) (*domain.Task, error)
-	ListTasks(ctx context.Context, filter taskUsecase.TaskFilter) ([]*domain.Task, int, error)
+	ListTasks(ctx context.Context, filter taskUsecase.TaskFilter) (*domain.TaskPage, error)
+	SearchTasks(ctx context.Context, query string, filter taskUsecase.TaskFilter) ([]*domain.TaskSearchHit, int, error)
 	UpdateTask(ctx context.Context, id string, input taskUsecase.UpdateTaskInput) (*domain.Task, error)
 	DeleteTask(ctx context.Context, id string) error
 	GetTaskHistory(ctx context.Context, taskID string) ([]*domain.TaskHistory, error)
 }
 
 type ActivityUseCase interface {
-	GetUserActivities(ctx context.Context, userID string, from, to int64, limit, offset int) ([]*domain.Activity, int, error)
-	GetActivities(ctx context.Context, entityType, entityID string, from, to int64, limit, offset int) ([]*domain.Activity, int, error)
-	RecordActivity(ctx context.Context, userID string, entityType domain.EntityType, entityID string, action domain.ActionType, metadata string) error
+	GetUserActivities(ctx context.Context, userID string, filter activityUsecase.ActivityFilter) (*domain.ActivityPage, error)
+	GetActivities(ctx context.Context, entityType, entityID string, filter activityUsecase.ActivityFilter) (*domain.ActivityPage, error)
 }
 
 type UserLister interface {
@@ -57,6 +83,9 @@ type Handler struct {
 	activityUC ActivityUseCase
 	userLister UserLister
 	teamLister TeamLister
+	authSvc    AuthService
+	accountUC  ServiceAccountUseCase
+	limiter    *ratelimit.Limiter
 }
 
 func NewHandler(
@@ -67,6 +96,9 @@ func NewHandler(
 	activityUC ActivityUseCase,
 	userLister UserLister,
 	teamLister TeamLister,
+	authSvc AuthService,
+	accountUC ServiceAccountUseCase,
+	limiter *ratelimit.Limiter,
 ) *Handler {
 	return &Handler{
 		cache:      cache,
@@ -76,51 +108,101 @@ func NewHandler(
 		activityUC: activityUC,
 		userLister: userLister,
 		teamLister: teamLister,
+		authSvc:    authSvc,
+		accountUC:  accountUC,
+		limiter:    limiter,
 	}
 }
 
 func (h *Handler) Router() http.Handler {
 	r := chi.NewRouter()
+	// Rate limits count per client, so they apply after authentication;
+	// anonymous routes are limited by IP address. Every API request also
+	// counts against its IP address before authentication, which bounds
+	// attempts with invalid tokens and API keys.
+	limit := h.limiter.Middleware(clientKey)
+	limitIP := h.limiter.ByIP(remoteIP)
 
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "If-Match"},
+		ExposedHeaders:   []string{"ETag", "Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
 	r.Use(middleware.Logger)
+	r.Use(httpMetrics)
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.RequestID)
+	r.Use(traceContext)
 
 	r.Route("/api/v1", func(r chi.Router) {
-		r.Route("/users", func(r chi.Router) {
-			r.Post("/", h.CreateUser)
-			r.Get("/", h.ListUsers)
-			r.Get("/{id}", h.GetUser)
-			r.Patch("/{id}", h.UpdateUser)
-			r.Get("/{user_id}/activities", h.GetUserActivities)
-		})
+		r.Use(limitIP)
 
-		r.Route("/teams", func(r chi.Router) {
-			r.Post("/", h.CreateTeam)
-			r.Get("/", h.ListTeams)
-			r.Get("/{id}", h.GetTeam)
-			r.Post("/{team_id}/members", h.AddTeamMember)
-			r.Get("/{team_id}/members", h.GetTeamMembers)
+		r.Route("/auth", func(r chi.Router) {
+			r.Use(limit)
+			r.Post("/login", h.Login)
+			r.Post("/refresh", h.Refresh)
+			r.Post("/logout", h.Logout)
 		})
 
-		r.Route("/tasks", func(r chi.Router) {
-			r.Post("/", h.CreateTask)
-			r.Get("/", h.ListTasks)
-			r.Get("/{id}", h.GetTask)
-			r.Patch("/{id}", h.UpdateTask)
-			r.Delete("/{id}", h.DeleteTask)
-			r.Get("/{task_id}/history", h.GetTaskHistory)
+		r.Route("/users", func(r chi.Router) {
+			r.With(limit).Post("/", h.CreateUser)
+
+			r.Group(func(r chi.Router) {
+				r.Use(h.requireAuth, limit)
+				r.With(requireUser).Get("/", h.ListUsers)
+				r.With(requireUser).Get("/{id}", h.GetUser)
+				r.With(requireUser).Patch("/{id}", h.UpdateUser)
+				r.With(requireScope(domain.ScopeActivitiesRead)).Get("/{user_id}/activities", h.GetUserActivities)
+			})
 		})
 
-		r.Route("/activities", func(r chi.Router) {
-			r.Get("/", h.GetActivities)
+		// Routes open to API keys name the scope they need; the rest are
+		// for signed-in users only.
+		r.Group(func(r chi.Router) {
+			r.Use(h.requireAuth, limit)
+
+			r.Route("/teams", func(r chi.Router) {
+				r.With(requireUser).Post("/", h.CreateTeam)
+				r.With(requireScope(domain.ScopeTeamsRead)).Get("/", h.ListTeams)
+				r.With(requireScope(domain.ScopeTeamsRead)).Get("/{id}", h.GetTeam)
+				r.With(requireUser).Patch("/{id}", h.UpdateTeam)
+				r.With(requireUser).Post("/{team_id}/members", h.AddTeamMember)
+				r.With(requireScope(domain.ScopeTeamsRead)).Get("/{team_id}/members", h.GetTeamMembers)
+				r.With(requireUser).Delete("/{team_id}/members/{user_id}", h.RemoveTeamMember)
+			})
+
+			r.Route("/tasks", func(r chi.Router) {
+				r.Group(func(r chi.Router) {
+					r.Use(requireScope(domain.ScopeTasksRead))
+					r.Get("/", h.ListTasks)
+					r.Get("/search", h.SearchTasks)
+					r.Get("/{id}", h.GetTask)
+					r.Get("/{task_id}/history", h.GetTaskHistory)
+				})
+				r.Group(func(r chi.Router) {
+					r.Use(requireScope(domain.ScopeTasksWrite))
+					r.Post("/", h.CreateTask)
+					r.Patch("/{id}", h.UpdateTask)
+					r.Delete("/{id}", h.DeleteTask)
+				})
+			})
+
+			r.Route("/activities", func(r chi.Router) {
+				r.Use(requireScope(domain.ScopeActivitiesRead))
+				r.Get("/", h.GetActivities)
+			})
+
+			r.Route("/service-accounts", func(r chi.Router) {
+				r.Use(requireUser)
+				r.Post("/", h.CreateServiceAccount)
+				r.Get("/", h.ListServiceAccounts)
+				r.Post("/{id}/keys", h.CreateAPIKey)
+				r.Get("/{id}/keys", h.ListAPIKeys)
+				r.Delete("/{id}/keys/{key_id}", h.RevokeAPIKey)
+			})
 		})
 	})
 
@@ -137,6 +219,19 @@ func (h *Handler) Router() http.Handler {
 	return r
 }
 
+// traceContext hands the request's trace headers and request ID to backend
+// calls, so the events they publish can be traced back to this request.
+func traceContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := events.WithTrace(r.Context(), map[string]string{
+			"traceparent":  r.Header.Get("traceparent"),
+			"tracestate":   r.Header.Get("tracestate"),
+			"x-request-id": middleware.GetReqID(r.Context()),
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -149,6 +244,41 @@ func respondError(w http.ResponseWriter, status int, message string) {
 	respondJSON(w, status, map[string]string{"error": message})
 }
 
+// respondServiceError maps the gRPC status returned by a backend service to the matching HTTP status.
+// Rejected fields reported by the service are listed under "fields", and a
+// rejected precondition on them (such as a disallowed status change) is a 422.
+func respondServiceError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	code := runtime.HTTPStatusFromCode(st.Code())
+
+	fields := fieldErrors(st)
+	if len(fields) == 0 {
+		respondError(w, code, st.Message())
+		return
+	}
+	if st.Code() == codes.FailedPrecondition {
+		code = http.StatusUnprocessableEntity
+	}
+	respondJSON(w, code, map[string]interface{}{
+		"error":  st.Message(),
+		"fields": fields,
+	})
+}
+
+func fieldErrors(st *status.Status) []domain.FieldError {
+	var fields []domain.FieldError
+	for _, detail := range st.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, v := range badRequest.GetFieldViolations() {
+			fields = append(fields, domain.FieldError{Field: v.GetField(), Message: v.GetDescription()})
+		}
+	}
+	return fields
+}
+
 func decodeJSON(r *http.Request, v interface{}) error {
 	return json.NewDecoder(r.Body).Decode(v)
 }
@@ -0,0 +1,73 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	taskUsecase "github.com/Sol1tud9/taskflow/internal/task/usecase"
+)
+
+type TaskUseCase struct {
+	mock.Mock
+}
+
+func NewTaskUseCase(t testing.TB) *TaskUseCase {
+	mock := &TaskUseCase{}
+	mock.Mock.Test(t)
+	return mock
+}
+
+func (m *TaskUseCase) CreateTask(ctx context.Context, input taskUsecase.CreateTaskInput) (*domain.Task, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Task), args.Error(1)
+}
+
+func (m *TaskUseCase) GetTask(ctx context.Context, id string) (*domain.Task, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Task), args.Error(1)
+}
+
+func (m *TaskUseCase) ListTasks(ctx context.Context, filter taskUsecase.TaskFilter) (*domain.TaskPage, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaskPage), args.Error(1)
+}
+
+func (m *TaskUseCase) SearchTasks(ctx context.Context, query string, filter taskUsecase.TaskFilter) ([]*domain.TaskSearchHit, int, error) {
+	args := m.Called(ctx, query, filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*domain.TaskSearchHit), args.Int(1), args.Error(2)
+}
+
+func (m *TaskUseCase) UpdateTask(ctx context.Context, id string, input taskUsecase.UpdateTaskInput) (*domain.Task, error) {
+	args := m.Called(ctx, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Task), args.Error(1)
+}
+
+func (m *TaskUseCase) DeleteTask(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *TaskUseCase) GetTaskHistory(ctx context.Context, taskID string) ([]*domain.TaskHistory, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TaskHistory), args.Error(1)
+}
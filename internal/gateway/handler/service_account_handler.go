@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/Sol1tud9/taskflow/internal/domain"
+)
+
+type CreateServiceAccountRequest struct {
+	Name string `json:"name"`
+}
+
+func (h *Handler) CreateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	var req CreateServiceAccountRequest
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	account, err := h.accountUC.CreateServiceAccount(r.Context(), req.Name)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, account)
+}
+
+func (h *Handler) ListServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.accountUC.ListServiceAccounts(r.Context())
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+	if accounts == nil {
+		accounts = []*domain.User{}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"service_accounts": accounts,
+		"total":            len(accounts),
+	})
+}
+
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// CreateAPIKey returns the new key under "key". It is the only time the key
+// is shown; user-service keeps just its hash.
+func (h *Handler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	accountID := chi.URLParam(r, "id")
+
+	var req CreateAPIKeyRequest
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	apiKey, key, err := h.accountUC.CreateAPIKey(r.Context(), accountID, req.Name, req.Scopes)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, map[string]interface{}{
+		"api_key": apiKey,
+		"key":     key,
+	})
+}
+
+func (h *Handler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	accountID := chi.URLParam(r, "id")
+
+	keys, err := h.accountUC.ListAPIKeys(r.Context(), accountID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+	if keys == nil {
+		keys = []*domain.APIKey{}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"api_keys": keys,
+		"total":    len(keys),
+	})
+}
+
+func (h *Handler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	accountID := chi.URLParam(r, "id")
+	keyID := chi.URLParam(r, "key_id")
+
+	if err := h.accountUC.RevokeAPIKey(r.Context(), accountID, keyID); err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,11 +2,15 @@ package handler
 
 import (
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/Sol1tud9/taskflow/internal/domain"
 	taskUsecase "github.com/Sol1tud9/taskflow/internal/task/usecase"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type CreateTaskRequest struct {
@@ -14,7 +18,6 @@ type CreateTaskRequest struct {
 	Description string `json:"description"`
 	Priority    string `json:"priority"`
 	AssigneeID  string `json:"assignee_id"`
-	CreatorID   string `json:"creator_id"`
 	TeamID      string `json:"team_id"`
 	DueDate     int64  `json:"due_date"`
 }
@@ -31,80 +34,117 @@ func (h *Handler) CreateTask(w http.ResponseWriter, r *http.Request) {
 		Description: req.Description,
 		Priority:    req.Priority,
 		AssigneeID:  req.AssigneeID,
-		CreatorID:   req.CreatorID,
+		CreatorID:   callerID(r),
 		TeamID:      req.TeamID,
 		DueDate:     req.DueDate,
 	}
 
 	task, err := h.taskUC.CreateTask(r.Context(), input)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondServiceError(w, err)
 		return
 	}
 
-	_ = h.activityUC.RecordActivity(r.Context(), req.CreatorID, domain.EntityTypeTask, task.ID, domain.ActionTypeCreated, `{"title":"`+task.Title+`"}`)
-
-	_ = h.cache.Set(r.Context(), "task:"+task.ID, task)
-
+	setTaskETag(w, task)
 	respondJSON(w, http.StatusCreated, task)
 }
 
 func (h *Handler) GetTask(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	cacheKey := "task:" + id
-	var cachedTask domain.Task
-
-	var task *domain.Task
-	if err := h.cache.Get(r.Context(), cacheKey, &cachedTask); err == nil {
-		task = &cachedTask
-	} else {
-		fetchedTask, err := h.taskUC.GetTask(r.Context(), id)
-		if err != nil {
-			respondError(w, http.StatusNotFound, "task not found")
-			return
-		}
-		task = fetchedTask
-		_ = h.cache.Set(r.Context(), cacheKey, task)
+	// Task reads are not cached: task-service checks the caller's team role
+	// on every request.
+	task, err := h.taskUC.GetTask(r.Context(), id)
+	if err != nil {
+		respondServiceError(w, err)
+		return
 	}
 
+	setTaskETag(w, task)
 	respondJSON(w, http.StatusOK, task)
 }
 
 func (h *Handler) ListTasks(w http.ResponseWriter, r *http.Request) {
-	query := r.URL.Query()
-
-	limit, _ := strconv.Atoi(query.Get("limit"))
-	offset, _ := strconv.Atoi(query.Get("offset"))
-
-	if limit <= 0 {
-		limit = 20
+	page, err := h.taskUC.ListTasks(r.Context(), taskFilter(r.URL.Query()))
+	if err != nil {
+		respondServiceError(w, err)
+		return
 	}
 
-	filter := taskUsecase.TaskFilter{
-		TeamID:     query.Get("team_id"),
-		AssigneeID: query.Get("assignee_id"),
-		Status:     query.Get("status"),
-		Limit:      limit,
-		Offset:     offset,
+	if page.Tasks == nil {
+		page.Tasks = []*domain.Task{}
 	}
 
-	tasks, total, err := h.taskUC.ListTasks(r.Context(), filter)
+	respondJSON(w, http.StatusOK, page)
+}
+
+// SearchTasks runs a full-text search for the q parameter, narrowed by the
+// ListTasks filters.
+func (h *Handler) SearchTasks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	hits, total, err := h.taskUC.SearchTasks(r.Context(), query.Get("q"), taskFilter(query))
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondServiceError(w, err)
 		return
 	}
 
-	if tasks == nil {
-		tasks = []*domain.Task{}
+	if hits == nil {
+		hits = []*domain.TaskSearchHit{}
 	}
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"tasks": tasks,
+		"hits":  hits,
 		"total": total,
 	})
 }
 
+// taskFilter reads the task list parameters. status may be repeated or
+// comma-separated; time ranges are unix seconds.
+func taskFilter(query url.Values) taskUsecase.TaskFilter {
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	overdue, _ := strconv.ParseBool(query.Get("overdue"))
+
+	if limit <= 0 {
+		limit = 20
+	}
+
+	var statuses []string
+	for _, value := range query["status"] {
+		for _, status := range strings.Split(value, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				statuses = append(statuses, status)
+			}
+		}
+	}
+
+	return taskUsecase.TaskFilter{
+		TeamID:      query.Get("team_id"),
+		AssigneeID:  query.Get("assignee_id"),
+		CreatorID:   query.Get("creator_id"),
+		Statuses:    statuses,
+		Priority:    query.Get("priority"),
+		DueFrom:     unixParam(query, "due_from"),
+		DueTo:       unixParam(query, "due_to"),
+		CreatedFrom: unixParam(query, "created_from"),
+		CreatedTo:   unixParam(query, "created_to"),
+		UpdatedFrom: unixParam(query, "updated_from"),
+		UpdatedTo:   unixParam(query, "updated_to"),
+		Overdue:     overdue,
+		Sort:        query.Get("sort"),
+		Limit:       limit,
+		Offset:      offset,
+		Cursor:      query.Get("cursor"),
+		Count:       taskUsecase.CountMode(query.Get("count")),
+	}
+}
+
+func unixParam(query url.Values, name string) int64 {
+	value, _ := strconv.ParseInt(query.Get(name), 10, 64)
+	return value
+}
+
 type UpdateTaskRequest struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
@@ -112,12 +152,19 @@ type UpdateTaskRequest struct {
 	Priority    string `json:"priority"`
 	AssigneeID  string `json:"assignee_id"`
 	DueDate     int64  `json:"due_date"`
-	UserID      string `json:"user_id"`
 }
 
+// UpdateTask honours If-Match with the ETag of a previous response: when the
+// task has changed since, it responds 409 with the current task instead.
 func (h *Handler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
+	expectedVersion, err := ifMatchVersion(r.Header.Get("If-Match"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid If-Match header")
+		return
+	}
+
 	var req UpdateTaskRequest
 	if err := decodeJSON(r, &req); err != nil {
 		respondError(w, http.StatusBadRequest, "invalid request body")
@@ -131,30 +178,79 @@ func (h *Handler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 		Priority:    req.Priority,
 		AssigneeID:  req.AssigneeID,
 		DueDate:     req.DueDate,
-		UserID:      req.UserID,
+		UserID:      callerID(r),
+
+		ExpectedVersion: expectedVersion,
 	}
 
 	task, err := h.taskUC.UpdateTask(r.Context(), id, input)
+	if status.Code(err) == codes.Aborted {
+		h.respondTaskConflict(w, r, id, err)
+		return
+	}
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondServiceError(w, err)
 		return
 	}
 
-	_ = h.cache.Set(r.Context(), "task:"+id, task)
-
+	setTaskETag(w, task)
 	respondJSON(w, http.StatusOK, task)
 }
 
+// respondTaskConflict answers a rejected update with the task as it is now,
+// so the client can reapply its change on top of it.
+func (h *Handler) respondTaskConflict(w http.ResponseWriter, r *http.Request, id string, conflict error) {
+	task, err := h.taskUC.GetTask(r.Context(), id)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	setTaskETag(w, task)
+	respondJSON(w, http.StatusConflict, map[string]interface{}{
+		"error": status.Convert(conflict).Message(),
+		"task":  task,
+	})
+}
+
+// setTaskETag tags the response with the task's version. Tasks read before
+// versioning existed (version 0) get no tag.
+func setTaskETag(w http.ResponseWriter, task *domain.Task) {
+	if task.Version > 0 {
+		w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(task.Version, 10)))
+	}
+}
+
+// ifMatchVersion reads the task version from an If-Match header. An empty
+// header or "*" matches any version and yields 0.
+func ifMatchVersion(header string) (int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	tag, err := strconv.Unquote(strings.TrimPrefix(header, "W/"))
+	if err != nil {
+		return 0, err
+	}
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if version <= 0 {
+		return 0, strconv.ErrRange
+	}
+	return version, nil
+}
+
 func (h *Handler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
 	if err := h.taskUC.DeleteTask(r.Context(), id); err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondServiceError(w, err)
 		return
 	}
 
-	_ = h.cache.Delete(r.Context(), "task:"+id)
-
 	respondJSON(w, http.StatusOK, map[string]interface{}{
 		"success": true,
 		"id":      id,
@@ -166,7 +262,7 @@ func (h *Handler) GetTaskHistory(w http.ResponseWriter, r *http.Request) {
 
 	history, err := h.taskUC.GetTaskHistory(r.Context(), taskID)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondServiceError(w, err)
 		return
 	}
 
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/gateway/handler/mocks"
+	taskUsecase "github.com/Sol1tud9/taskflow/internal/task/usecase"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    int64
+		wantErr bool
+	}{
+		{name: "empty", header: "", want: 0},
+		{name: "blank", header: "  ", want: 0},
+		{name: "any", header: "*", want: 0},
+		{name: "strong", header: `"3"`, want: 3},
+		{name: "weak", header: `W/"3"`, want: 3},
+		{name: "surrounding spaces", header: ` "3" `, want: 3},
+		{name: "unquoted", header: "3", wantErr: true},
+		{name: "zero", header: `"0"`, wantErr: true},
+		{name: "negative", header: `"-1"`, wantErr: true},
+		{name: "not a number", header: `"abc"`, wantErr: true},
+		{name: "unterminated", header: `"3`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ifMatchVersion(tt.header)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type TaskHandlerSuite struct {
+	suite.Suite
+	taskUC  *mocks.TaskUseCase
+	handler *Handler
+}
+
+func (s *TaskHandlerSuite) SetupTest() {
+	s.taskUC = mocks.NewTaskUseCase(s.T())
+	s.handler = NewHandler(nil, nil, nil, s.taskUC, nil, nil, nil, nil, nil, nil)
+}
+
+func (s *TaskHandlerSuite) TearDownTest() {
+	s.taskUC.AssertExpectations(s.T())
+}
+
+func (s *TaskHandlerSuite) updateTask(id, ifMatch, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/tasks/"+id, strings.NewReader(body))
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("id", id)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+
+	rec := httptest.NewRecorder()
+	s.handler.UpdateTask(rec, req)
+	return rec
+}
+
+func (s *TaskHandlerSuite) TestUpdateTask_Success() {
+	s.taskUC.On("UpdateTask", mock.Anything, "task-1", mock.MatchedBy(func(input taskUsecase.UpdateTaskInput) bool {
+		return input.ExpectedVersion == 3 && input.Title == "New title"
+	})).Return(&domain.Task{ID: "task-1", Title: "New title", Version: 4}, nil)
+
+	rec := s.updateTask("task-1", `"3"`, `{"title":"New title"}`)
+
+	assert.Equal(s.T(), http.StatusOK, rec.Code)
+	assert.Equal(s.T(), `"4"`, rec.Header().Get("ETag"))
+}
+
+func (s *TaskHandlerSuite) TestUpdateTask_ConflictReturnsCurrentTask() {
+	s.taskUC.On("UpdateTask", mock.Anything, "task-1", mock.MatchedBy(func(input taskUsecase.UpdateTaskInput) bool {
+		return input.ExpectedVersion == 3
+	})).Return(nil, status.Error(codes.Aborted, "task was modified concurrently"))
+	s.taskUC.On("GetTask", mock.Anything, "task-1").
+		Return(&domain.Task{ID: "task-1", Title: "Their title", Version: 5}, nil)
+
+	rec := s.updateTask("task-1", `"3"`, `{"title":"New title"}`)
+
+	assert.Equal(s.T(), http.StatusConflict, rec.Code)
+	assert.Equal(s.T(), `"5"`, rec.Header().Get("ETag"))
+	assert.Contains(s.T(), rec.Body.String(), `"error":"task was modified concurrently"`)
+	assert.Contains(s.T(), rec.Body.String(), `"title":"Their title"`)
+}
+
+func (s *TaskHandlerSuite) TestUpdateTask_InvalidIfMatch() {
+	rec := s.updateTask("task-1", "3", `{"title":"New title"}`)
+
+	assert.Equal(s.T(), http.StatusBadRequest, rec.Code)
+	s.taskUC.AssertNotCalled(s.T(), "UpdateTask", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskHandlerSuite(t *testing.T) {
+	suite.Run(t, new(TaskHandlerSuite))
+}
@@ -2,15 +2,15 @@ package handler
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/Sol1tud9/taskflow/internal/domain"
 )
 
 type CreateUserRequest struct {
-	Email string `json:"email"`
-	Name  string `json:"name"`
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
 }
 
 func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
@@ -19,15 +19,17 @@ func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
+	if req.Password == "" {
+		respondError(w, http.StatusBadRequest, "password is required")
+		return
+	}
 
-	user, err := h.userUC.CreateUser(r.Context(), req.Email, req.Name)
+	user, err := h.userUC.CreateUser(r.Context(), req.Email, req.Name, req.Password)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondServiceError(w, err)
 		return
 	}
 
-	_ = h.activityUC.RecordActivity(r.Context(), user.ID, domain.EntityTypeUser, user.ID, domain.ActionTypeCreated, `{"email":"`+user.Email+`","name":"`+user.Name+`"}`)
-
 	_ = h.cache.Set(r.Context(), "user:"+user.ID, user)
 	_ = h.cache.Delete(r.Context(), "users:list")
 
@@ -46,7 +48,7 @@ func (h *Handler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	} else {
 		listUsers, err := h.userLister.List(r.Context())
 		if err != nil {
-			respondError(w, http.StatusInternalServerError, err.Error())
+			respondServiceError(w, err)
 			return
 		}
 
@@ -79,7 +81,7 @@ func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
 	} else {
 		fetchedUser, err := h.userUC.GetUser(r.Context(), id)
 		if err != nil {
-			respondError(w, http.StatusNotFound, "user not found")
+			respondServiceError(w, err)
 			return
 		}
 		user = fetchedUser
@@ -90,12 +92,20 @@ func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
 }
 
 type UpdateUserRequest struct {
-	Email string `json:"email"`
-	Name  string `json:"name"`
+	Email           string `json:"email"`
+	Name            string `json:"name"`
+	Password        string `json:"password"`
+	CurrentPassword string `json:"current_password"`
 }
 
+// UpdateUser edits the caller's own profile; other users' profiles are
+// read-only.
 func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
+	if id != callerID(r) {
+		respondError(w, http.StatusForbidden, "users can only update their own profile")
+		return
+	}
 
 	var req UpdateUserRequest
 	if err := decodeJSON(r, &req); err != nil {
@@ -103,9 +113,9 @@ func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.userUC.UpdateUser(r.Context(), id, req.Email, req.Name)
+	user, err := h.userUC.UpdateUser(r.Context(), id, req.Email, req.Name, req.Password, req.CurrentPassword)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondServiceError(w, err)
 		return
 	}
 
@@ -116,8 +126,7 @@ func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 }
 
 type CreateTeamRequest struct {
-	Name    string `json:"name"`
-	OwnerID string `json:"owner_id"`
+	Name string `json:"name"`
 }
 
 func (h *Handler) CreateTeam(w http.ResponseWriter, r *http.Request) {
@@ -127,45 +136,25 @@ func (h *Handler) CreateTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	team, err := h.teamUC.CreateTeam(r.Context(), req.Name, req.OwnerID)
+	team, err := h.teamUC.CreateTeam(r.Context(), req.Name, callerID(r))
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondServiceError(w, err)
 		return
 	}
 
-	_ = h.activityUC.RecordActivity(r.Context(), req.OwnerID, domain.EntityTypeTeam, team.ID, domain.ActionTypeCreated, `{"name":"`+team.Name+`"}`)
-
-	_ = h.cache.Set(r.Context(), "team:"+team.ID, team)
-	_ = h.cache.Delete(r.Context(), "teams:list")
-
 	respondJSON(w, http.StatusCreated, team)
 }
 
 func (h *Handler) ListTeams(w http.ResponseWriter, r *http.Request) {
-	var teams []*domain.Team
-	var cached struct {
-		Teams []*domain.Team `json:"teams"`
-		Total int            `json:"total"`
+	// Team reads are not cached: what a caller may see depends on their
+	// memberships, which user-service checks on every request.
+	teams, err := h.teamLister.ListTeams(r.Context())
+	if err != nil {
+		respondServiceError(w, err)
+		return
 	}
-
-	if err := h.cache.Get(r.Context(), "teams:list", &cached); err == nil {
-		teams = cached.Teams
-	} else {
-		listTeams, err := h.teamLister.ListTeams(r.Context())
-		if err != nil {
-			respondError(w, http.StatusInternalServerError, err.Error())
-			return
-		}
-
-		if listTeams == nil {
-			listTeams = []*domain.Team{}
-		}
-
-		teams = listTeams
-		_ = h.cache.Set(r.Context(), "teams:list", map[string]interface{}{
-			"teams": teams,
-			"total": len(teams),
-		})
+	if teams == nil {
+		teams = []*domain.Team{}
 	}
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
@@ -177,20 +166,32 @@ func (h *Handler) ListTeams(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) GetTeam(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	cacheKey := "team:" + id
-	var cachedTeam domain.Team
+	team, err := h.teamUC.GetTeam(r.Context(), id)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
 
-	var team *domain.Team
-	if err := h.cache.Get(r.Context(), cacheKey, &cachedTeam); err == nil {
-		team = &cachedTeam
-	} else {
-		fetchedTeam, err := h.teamUC.GetTeam(r.Context(), id)
-		if err != nil {
-			respondError(w, http.StatusNotFound, "team not found")
-			return
-		}
-		team = fetchedTeam
-		_ = h.cache.Set(r.Context(), cacheKey, team)
+	respondJSON(w, http.StatusOK, team)
+}
+
+type UpdateTeamRequest struct {
+	Name string `json:"name"`
+}
+
+func (h *Handler) UpdateTeam(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req UpdateTeamRequest
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	team, err := h.teamUC.UpdateTeam(r.Context(), id, req.Name)
+	if err != nil {
+		respondServiceError(w, err)
+		return
 	}
 
 	respondJSON(w, http.StatusOK, team)
@@ -212,45 +213,23 @@ func (h *Handler) AddTeamMember(w http.ResponseWriter, r *http.Request) {
 
 	member, err := h.teamUC.AddTeamMember(r.Context(), teamID, req.UserID, req.Role)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondServiceError(w, err)
 		return
 	}
 
-	_ = h.cache.Delete(r.Context(), "team:"+teamID+":members")
-	_ = h.cache.Delete(r.Context(), "team:"+teamID)
-
 	respondJSON(w, http.StatusCreated, member)
 }
 
 func (h *Handler) GetTeamMembers(w http.ResponseWriter, r *http.Request) {
 	teamID := chi.URLParam(r, "team_id")
 
-	var members []*domain.TeamMember
-	cacheKey := "team:" + teamID + ":members"
-
-	var cached struct {
-		Members []*domain.TeamMember `json:"members"`
-		Total   int                  `json:"total"`
+	members, err := h.teamUC.GetTeamMembers(r.Context(), teamID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
 	}
-
-	if err := h.cache.Get(r.Context(), cacheKey, &cached); err == nil {
-		members = cached.Members
-	} else {
-		fetchedMembers, err := h.teamUC.GetTeamMembers(r.Context(), teamID)
-		if err != nil {
-			respondError(w, http.StatusInternalServerError, err.Error())
-			return
-		}
-
-		if fetchedMembers == nil {
-			fetchedMembers = []*domain.TeamMember{}
-		}
-
-		members = fetchedMembers
-		_ = h.cache.Set(r.Context(), cacheKey, map[string]interface{}{
-			"members": members,
-			"total":   len(members),
-		})
+	if members == nil {
+		members = []*domain.TeamMember{}
 	}
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
@@ -259,30 +238,36 @@ func (h *Handler) GetTeamMembers(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-func (h *Handler) GetUserActivities(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) RemoveTeamMember(w http.ResponseWriter, r *http.Request) {
+	teamID := chi.URLParam(r, "team_id")
 	userID := chi.URLParam(r, "user_id")
 
-	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
-	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
-	from, _ := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
-	to, _ := strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
-
-	if limit <= 0 {
-		limit = 20
+	if err := h.teamUC.RemoveTeamMember(r.Context(), teamID, userID); err != nil {
+		respondServiceError(w, err)
+		return
 	}
 
-	activities, total, err := h.activityUC.GetUserActivities(r.Context(), userID, from, to, limit, offset)
-	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"team_id": teamID,
+		"user_id": userID,
+	})
+}
+
+// GetUserActivities returns the caller's own activity; other users' activity
+// is private.
+func (h *Handler) GetUserActivities(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "user_id")
+	if userID != callerID(r) {
+		respondError(w, http.StatusForbidden, "users can only read their own activity")
 		return
 	}
 
-	if activities == nil {
-		activities = []*domain.Activity{}
+	page, err := h.activityUC.GetUserActivities(r.Context(), userID, activityFilter(r.URL.Query()))
+	if err != nil {
+		respondServiceError(w, err)
+		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"activities": activities,
-		"total":      total,
-	})
+	respondActivities(w, page)
 }
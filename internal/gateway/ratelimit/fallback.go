@@ -0,0 +1,37 @@
+package ratelimit
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// FallbackStore takes from primary and, while primary fails, from
+// secondary, so that a Redis outage loosens rate limiting instead of
+// failing or unthrottling every request.
+type FallbackStore struct {
+	primary   Store
+	secondary Store
+	degraded  atomic.Bool
+}
+
+func NewFallbackStore(primary, secondary Store) *FallbackStore {
+	return &FallbackStore{primary: primary, secondary: secondary}
+}
+
+func (s *FallbackStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	res, err := s.primary.Take(ctx, key, limit)
+	if err == nil {
+		if s.degraded.CompareAndSwap(true, false) {
+			logger.Info("rate limit store recovered")
+		}
+		return res, nil
+	}
+
+	if s.degraded.CompareAndSwap(false, true) {
+		logger.Warn("rate limit store unavailable, limiting in memory", zap.Error(err))
+	}
+	return s.secondary.Take(ctx, key, limit)
+}
@@ -0,0 +1,172 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Sol1tud9/taskflow/pkg/config"
+)
+
+const (
+	// defaultRule names the bucket of requests that match no route rule.
+	defaultRule = "default"
+	// ipRule names the per-IP bucket shared by all routes.
+	ipRule = "ip"
+)
+
+type rule struct {
+	name   string
+	method string
+	path   string
+	limit  Limit
+}
+
+// matches reports whether the rule covers the request: its path is the
+// rule's path or lies under it, and the method matches if the rule has one.
+func (r rule) matches(method, path string) bool {
+	if r.method != "" && r.method != method {
+		return false
+	}
+	return path == r.path || strings.HasPrefix(path, strings.TrimSuffix(r.path, "/")+"/")
+}
+
+// Limiter limits how many requests each client makes to each route.
+type Limiter struct {
+	store Store
+	def   Limit
+	rules []rule
+	// ip is the allowance of each IP address; zero when there is none.
+	ip Limit
+}
+
+// New builds a Limiter from the gateway config. It returns nil if rate
+// limiting is disabled; a nil Limiter lets every request through.
+func New(cfg config.RateLimitConfig, store Store) (*Limiter, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	def, err := limitOf(cfg.Requests, cfg.PeriodSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("rate_limit: %w", err)
+	}
+
+	l := &Limiter{store: store, def: def}
+	if cfg.IPRequests != 0 || cfg.IPPeriodSeconds != 0 {
+		if l.ip, err = limitOf(cfg.IPRequests, cfg.IPPeriodSeconds); err != nil {
+			return nil, fmt.Errorf("rate_limit ip: %w", err)
+		}
+	}
+	for _, rc := range cfg.Routes {
+		if !strings.HasPrefix(rc.Path, "/") {
+			return nil, fmt.Errorf("rate_limit route %q: path must start with /", rc.Path)
+		}
+		limit, err := limitOf(rc.Requests, rc.PeriodSeconds)
+		if err != nil {
+			return nil, fmt.Errorf("rate_limit route %s %s: %w", rc.Method, rc.Path, err)
+		}
+		method := strings.ToUpper(rc.Method)
+		l.rules = append(l.rules, rule{
+			name:   strings.TrimSpace(method + " " + rc.Path),
+			method: method,
+			path:   rc.Path,
+			limit:  limit,
+		})
+	}
+
+	return l, nil
+}
+
+func limitOf(requests, periodSeconds int) (Limit, error) {
+	if requests <= 0 || periodSeconds <= 0 {
+		return Limit{}, fmt.Errorf("requests and period_seconds must be positive")
+	}
+	return Limit{Requests: requests, Period: time.Duration(periodSeconds) * time.Second}, nil
+}
+
+// match returns the rule for the request. The rule with the longest path
+// wins, and a rule for the request's method beats one for any method.
+func (l *Limiter) match(method, path string) (string, Limit) {
+	var best *rule
+	for i := range l.rules {
+		r := &l.rules[i]
+		if !r.matches(method, path) {
+			continue
+		}
+		if best == nil || len(r.path) > len(best.path) || (len(r.path) == len(best.path) && r.method != "") {
+			best = r
+		}
+	}
+	if best == nil {
+		return defaultRule, l.def
+	}
+	return best.name, best.limit
+}
+
+// Middleware rejects a client's requests with 429 Too Many Requests once
+// they exceed the limit of the route. client identifies who made the
+// request. Every response carries the X-RateLimit-* headers of the route's
+// bucket. If the store fails the request is let through.
+func (l *Limiter) Middleware(client func(r *http.Request) string) func(http.Handler) http.Handler {
+	if l == nil {
+		return passThrough
+	}
+	return l.middleware(func(r *http.Request) (string, Limit) {
+		return l.match(r.Method, r.URL.Path)
+	}, client)
+}
+
+// ByIP rejects requests once their IP address has used up its allowance,
+// whatever the route. It goes in front of authentication, so that requests
+// with invalid credentials are limited too. ip returns the request's
+// address.
+func (l *Limiter) ByIP(ip func(r *http.Request) string) func(http.Handler) http.Handler {
+	if l == nil || l.ip.Requests == 0 {
+		return passThrough
+	}
+	return l.middleware(func(*http.Request) (string, Limit) {
+		return ipRule, l.ip
+	}, ip)
+}
+
+func passThrough(next http.Handler) http.Handler {
+	return next
+}
+
+func (l *Limiter) middleware(bucket func(r *http.Request) (string, Limit), client func(r *http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			name, limit := bucket(r)
+
+			res, err := l.store.Take(r.Context(), name+":"+client(r), limit)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+			h.Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			h.Set("X-RateLimit-Reset", strconv.Itoa(seconds(res.Reset)))
+			if !res.Allowed {
+				h.Set("Retry-After", strconv.Itoa(seconds(res.RetryAfter)))
+				h.Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write([]byte(`{"error":"rate limit exceeded"}` + "\n"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// seconds rounds d up to whole seconds, so that clients who wait that long
+// are not rejected again.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,185 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+// failingStore is a Store whose backend is down.
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, Limit) (Result, error) {
+	return Result{}, errors.New("connection refused")
+}
+
+type LimiterSuite struct {
+	suite.Suite
+	cfg config.RateLimitConfig
+}
+
+func (s *LimiterSuite) SetupTest() {
+	_ = logger.Init("error")
+	s.cfg = config.RateLimitConfig{
+		Enabled:       true,
+		Requests:      100,
+		PeriodSeconds: 60,
+		Routes: []config.RateLimitRuleConfig{
+			{Method: "post", Path: "/api/v1/auth/login", Requests: 2, PeriodSeconds: 60},
+			{Path: "/api/v1/tasks", Requests: 50, PeriodSeconds: 60},
+			{Path: "/api/v1/tasks/search", Requests: 10, PeriodSeconds: 60},
+			{Method: "GET", Path: "/api/v1/tasks/search", Requests: 20, PeriodSeconds: 60},
+		},
+	}
+}
+
+func (s *LimiterSuite) newLimiter(store Store) *Limiter {
+	l, err := New(s.cfg, store)
+	s.Require().NoError(err)
+	return l
+}
+
+func (s *LimiterSuite) serve(handler http.Handler, method, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func (s *LimiterSuite) TestMatch() {
+	l := s.newLimiter(NewMemoryStore())
+
+	tests := []struct {
+		method, path string
+		want         string
+	}{
+		{"POST", "/api/v1/auth/login", "POST /api/v1/auth/login"},
+		{"GET", "/api/v1/auth/login", defaultRule},
+		{"GET", "/api/v1/tasks", "/api/v1/tasks"},
+		{"PATCH", "/api/v1/tasks/42", "/api/v1/tasks"},
+		{"GET", "/api/v1/tasks/search", "GET /api/v1/tasks/search"},
+		{"POST", "/api/v1/tasks/search", "/api/v1/tasks/search"},
+		{"GET", "/api/v1/tasksearch", defaultRule},
+		{"GET", "/api/v1/teams", defaultRule},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.method+" "+tt.path, func() {
+			name, _ := l.match(tt.method, tt.path)
+			assert.Equal(s.T(), tt.want, name)
+		})
+	}
+}
+
+func (s *LimiterSuite) TestMiddleware_RejectsOverLimit() {
+	l := s.newLimiter(NewMemoryStore())
+	handler := l.Middleware(func(*http.Request) string { return "ip:1.2.3.4" })(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	first := s.serve(handler, "POST", "/api/v1/auth/login")
+	assert.Equal(s.T(), http.StatusNoContent, first.Code)
+	assert.Equal(s.T(), "2", first.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(s.T(), "1", first.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(s.T(), "30", first.Header().Get("X-RateLimit-Reset"))
+
+	s.serve(handler, "POST", "/api/v1/auth/login")
+	rejected := s.serve(handler, "POST", "/api/v1/auth/login")
+
+	assert.Equal(s.T(), http.StatusTooManyRequests, rejected.Code)
+	assert.Equal(s.T(), "30", rejected.Header().Get("Retry-After"))
+	assert.Equal(s.T(), "0", rejected.Header().Get("X-RateLimit-Remaining"))
+	assert.JSONEq(s.T(), `{"error":"rate limit exceeded"}`, rejected.Body.String())
+
+	other := s.serve(handler, "GET", "/api/v1/teams")
+	assert.Equal(s.T(), http.StatusNoContent, other.Code)
+}
+
+func (s *LimiterSuite) TestByIP() {
+	s.cfg.IPRequests = 2
+	s.cfg.IPPeriodSeconds = 60
+	l := s.newLimiter(NewMemoryStore())
+	handler := l.ByIP(func(*http.Request) string { return "1.2.3.4" })(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+
+	s.serve(handler, "GET", "/api/v1/teams")
+	s.serve(handler, "GET", "/api/v1/tasks")
+	rejected := s.serve(handler, "GET", "/api/v1/users")
+
+	assert.Equal(s.T(), http.StatusTooManyRequests, rejected.Code)
+	assert.Equal(s.T(), "2", rejected.Header().Get("X-RateLimit-Limit"))
+}
+
+func (s *LimiterSuite) TestByIP_Disabled() {
+	l := s.newLimiter(NewMemoryStore())
+	handler := l.ByIP(func(*http.Request) string { return "1.2.3.4" })(http.NotFoundHandler())
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(s.T(), http.StatusNotFound, s.serve(handler, "GET", "/api/v1/teams").Code)
+	}
+}
+
+func (s *LimiterSuite) TestFallbackStore() {
+	l := s.newLimiter(NewFallbackStore(failingStore{}, NewMemoryStore()))
+	handler := l.Middleware(func(*http.Request) string { return "user:1" })(http.NotFoundHandler())
+
+	s.serve(handler, "POST", "/api/v1/auth/login")
+	s.serve(handler, "POST", "/api/v1/auth/login")
+	rec := s.serve(handler, "POST", "/api/v1/auth/login")
+
+	assert.Equal(s.T(), http.StatusTooManyRequests, rec.Code)
+}
+
+func (s *LimiterSuite) TestRedisStore() {
+	db, mock := redismock.NewClientMock()
+	store := NewRedisStore(db)
+	limit := Limit{Requests: 2, Period: time.Minute}
+
+	mock.ExpectEvalSha(takeScript.Hash(), []string{"ratelimit:default:user:1"}, 2, int64(60000)).
+		SetVal([]interface{}{int64(0), int64(0), int64(12500), int64(42500)})
+
+	res, err := store.Take(context.Background(), "default:user:1", limit)
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), Result{Limit: 2, RetryAfter: 12500 * time.Millisecond, Reset: 42500 * time.Millisecond}, res)
+	assert.NoError(s.T(), mock.ExpectationsWereMet())
+}
+
+func (s *LimiterSuite) TestNew_Disabled() {
+	s.cfg.Enabled = false
+
+	l, err := New(s.cfg, NewMemoryStore())
+
+	s.Require().NoError(err)
+	next := http.NotFoundHandler()
+	assert.Equal(s.T(), http.StatusNotFound, s.serve(l.Middleware(nil)(next), "GET", "/").Code)
+}
+
+func (s *LimiterSuite) TestNew_InvalidIPLimit() {
+	s.cfg.IPRequests = 100
+
+	_, err := New(s.cfg, NewMemoryStore())
+
+	assert.Error(s.T(), err)
+}
+
+func (s *LimiterSuite) TestNew_InvalidRule() {
+	s.cfg.Routes = append(s.cfg.Routes, config.RateLimitRuleConfig{Path: "/api/v1/teams", Requests: 0, PeriodSeconds: 60})
+
+	_, err := New(s.cfg, NewMemoryStore())
+
+	assert.Error(s.T(), err)
+}
+
+func TestLimiterSuite(t *testing.T) {
+	suite.Run(t, new(LimiterSuite))
+}
@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often MemoryStore drops buckets that have refilled.
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens float64
+	ts     time.Time
+	limit  Limit
+}
+
+// MemoryStore keeps buckets in process memory with the same semantics as
+// RedisStore. Each gateway instance counts on its own, so clients get as
+// many requests as there are instances.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	capacity := float64(limit.Requests)
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, ts: now}
+		s.buckets[key] = b
+	}
+	b.limit = limit
+	b.tokens = refill(b, now)
+	b.ts = now
+
+	res := Result{Limit: limit.Requests}
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = tokenTime(1-b.tokens, limit)
+	}
+	res.Remaining = int(math.Floor(b.tokens))
+	res.Reset = tokenTime(capacity-b.tokens, limit)
+
+	return res, nil
+}
+
+// sweep drops the buckets that are full by now; a missing bucket behaves
+// the same.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if refill(b, now) >= float64(b.limit.Requests) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func refill(b *bucket, now time.Time) float64 {
+	capacity := float64(b.limit.Requests)
+	elapsed := now.Sub(b.ts)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	return math.Min(capacity, b.tokens+float64(elapsed)*capacity/float64(b.limit.Period))
+}
+
+// tokenTime returns how long the bucket takes to gain tokens tokens,
+// rounded up to the millisecond like RedisStore.
+func tokenTime(tokens float64, limit Limit) time.Duration {
+	ms := math.Ceil(tokens * float64(limit.Period.Milliseconds()) / float64(limit.Requests))
+	return time.Duration(ms) * time.Millisecond
+}
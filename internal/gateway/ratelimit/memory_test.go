@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type MemoryStoreSuite struct {
+	suite.Suite
+	ctx   context.Context
+	now   time.Time
+	store *MemoryStore
+	limit Limit
+}
+
+func (s *MemoryStoreSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.store = NewMemoryStore()
+	s.store.now = func() time.Time { return s.now }
+	s.store.lastSweep = s.now
+	s.limit = Limit{Requests: 3, Period: 3 * time.Second}
+}
+
+func (s *MemoryStoreSuite) take(key string) Result {
+	res, err := s.store.Take(s.ctx, key, s.limit)
+	s.Require().NoError(err)
+	return res
+}
+
+func (s *MemoryStoreSuite) TestBurstThenReject() {
+	for want := 2; want >= 0; want-- {
+		res := s.take("client")
+		assert.True(s.T(), res.Allowed)
+		assert.Equal(s.T(), want, res.Remaining)
+	}
+
+	res := s.take("client")
+
+	assert.False(s.T(), res.Allowed)
+	assert.Equal(s.T(), 3, res.Limit)
+	assert.Equal(s.T(), time.Second, res.RetryAfter)
+	assert.Equal(s.T(), 3*time.Second, res.Reset)
+}
+
+func (s *MemoryStoreSuite) TestRefillsOverPeriod() {
+	for i := 0; i < 3; i++ {
+		s.take("client")
+	}
+
+	s.now = s.now.Add(1500 * time.Millisecond)
+	res := s.take("client")
+
+	assert.True(s.T(), res.Allowed)
+	assert.Equal(s.T(), 0, res.Remaining)
+	assert.Equal(s.T(), 2500*time.Millisecond, res.Reset)
+}
+
+func (s *MemoryStoreSuite) TestKeysAreIndependent() {
+	for i := 0; i < 3; i++ {
+		s.take("first")
+	}
+
+	assert.True(s.T(), s.take("second").Allowed)
+}
+
+func (s *MemoryStoreSuite) TestSweepDropsFullBuckets() {
+	s.take("idle")
+	s.now = s.now.Add(sweepInterval)
+	s.take("active")
+
+	assert.NotContains(s.T(), s.store.buckets, "idle")
+	assert.Contains(s.T(), s.store.buckets, "active")
+}
+
+func TestMemoryStoreSuite(t *testing.T) {
+	suite.Run(t, new(MemoryStoreSuite))
+}
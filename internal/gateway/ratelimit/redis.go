@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// takeScript refills the bucket in KEYS[1] for the time since it was last
+// used and takes a token from it if one is left. ARGV holds the capacity and
+// the refill period in milliseconds. The server clock is used so that every
+// gateway instance sees the same time. It returns whether the request is
+// allowed, the tokens left and the milliseconds until the next token and
+// until the bucket is full.
+var takeScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000 + math.floor(tonumber(clock[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or capacity
+local ts = tonumber(state[2]) or now
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * capacity / period)
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) * period / capacity)
+end
+
+local reset = math.ceil((capacity - tokens) * period / capacity)
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.max(reset, 1))
+return {allowed, math.floor(tokens), wait, reset}
+`)
+
+// RedisStore keeps buckets in Redis, so that all gateway instances share
+// them. Buckets expire once they have refilled.
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	res, err := takeScript.Run(ctx, s.client, []string{bucketKey(key)}, limit.Requests, limit.Period.Milliseconds()).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Allowed:    res[0] == 1,
+		Limit:      limit.Requests,
+		Remaining:  int(res[1]),
+		RetryAfter: time.Duration(res[2]) * time.Millisecond,
+		Reset:      time.Duration(res[3]) * time.Millisecond,
+	}, nil
+}
+
+func bucketKey(key string) string {
+	return "ratelimit:" + key
+}
@@ -0,0 +1,33 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit lets a client make Requests requests per Period. Requests are
+// spread out by a token bucket: a client that has been idle may burst up to
+// Requests at once, and the allowance then refills evenly over Period.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Result is the outcome of taking a request from a bucket.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is how long a rejected client must wait for its next
+	// request to be allowed.
+	RetryAfter time.Duration
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+}
+
+// Store keeps token buckets by key.
+type Store interface {
+	// Take takes one request from the bucket at key, creating it full if it
+	// does not exist.
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
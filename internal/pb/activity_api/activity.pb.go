@@ -30,7 +30,13 @@ type GetActivitiesRequest struct {
 	FromTimestamp int64                  `protobuf:"varint,3,opt,name=from_timestamp,json=fromTimestamp,proto3" json:"from_timestamp,omitempty"`
 	ToTimestamp   int64                  `protobuf:"varint,4,opt,name=to_timestamp,json=toTimestamp,proto3" json:"to_timestamp,omitempty"`
 	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	// offset is ignored when cursor is set.
+	Offset int32 `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	// cursor is the next_cursor of the previous page.
+	Cursor string `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// allow_partial returns what the available shards have instead of
+	// failing when a shard is down or too slow.
+	AllowPartial  bool `protobuf:"varint,8,opt,name=allow_partial,json=allowPartial,proto3" json:"allow_partial,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -107,12 +113,32 @@ func (x *GetActivitiesRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *GetActivitiesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetActivitiesRequest) GetAllowPartial() bool {
+	if x != nil {
+		return x.AllowPartial
+	}
+	return false
+}
+
 type GetActivitiesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Activities    []*models.Activity     `protobuf:"bytes,1,rep,name=activities,proto3" json:"activities,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Activities []*models.Activity     `protobuf:"bytes,1,rep,name=activities,proto3" json:"activities,omitempty"`
+	// total counts the matching activities on the shards that answered. In a
+	// partial response it leaves out the rows of unavailable_shards.
+	Total int32 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// next_cursor fetches the following page; empty on the last page.
+	NextCursor string `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// unavailable_shards lists the shards missing from a partial response.
+	UnavailableShards []int32 `protobuf:"varint,4,rep,packed,name=unavailable_shards,json=unavailableShards,proto3" json:"unavailable_shards,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *GetActivitiesResponse) Reset() {
@@ -159,13 +185,33 @@ func (x *GetActivitiesResponse) GetTotal() int32 {
 	return 0
 }
 
+func (x *GetActivitiesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *GetActivitiesResponse) GetUnavailableShards() []int32 {
+	if x != nil {
+		return x.UnavailableShards
+	}
+	return nil
+}
+
 type GetUserActivitiesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	FromTimestamp int64                  `protobuf:"varint,2,opt,name=from_timestamp,json=fromTimestamp,proto3" json:"from_timestamp,omitempty"`
 	ToTimestamp   int64                  `protobuf:"varint,3,opt,name=to_timestamp,json=toTimestamp,proto3" json:"to_timestamp,omitempty"`
 	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	// offset is ignored when cursor is set.
+	Offset int32 `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	// cursor is the next_cursor of the previous page.
+	Cursor string `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// allow_partial returns what the available shards have instead of
+	// failing when a shard is down or too slow.
+	AllowPartial  bool `protobuf:"varint,7,opt,name=allow_partial,json=allowPartial,proto3" json:"allow_partial,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -235,12 +281,32 @@ func (x *GetUserActivitiesRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *GetUserActivitiesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetUserActivitiesRequest) GetAllowPartial() bool {
+	if x != nil {
+		return x.AllowPartial
+	}
+	return false
+}
+
 type GetUserActivitiesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Activities    []*models.Activity     `protobuf:"bytes,1,rep,name=activities,proto3" json:"activities,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Activities []*models.Activity     `protobuf:"bytes,1,rep,name=activities,proto3" json:"activities,omitempty"`
+	// total counts the matching activities on the shards that answered. In a
+	// partial response it leaves out the rows of unavailable_shards.
+	Total int32 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// next_cursor fetches the following page; empty on the last page.
+	NextCursor string `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// unavailable_shards lists the shards missing from a partial response.
+	UnavailableShards []int32 `protobuf:"varint,4,rep,packed,name=unavailable_shards,json=unavailableShards,proto3" json:"unavailable_shards,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *GetUserActivitiesResponse) Reset() {
@@ -287,11 +353,25 @@ func (x *GetUserActivitiesResponse) GetTotal() int32 {
 	return 0
 }
 
+func (x *GetUserActivitiesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *GetUserActivitiesResponse) GetUnavailableShards() []int32 {
+	if x != nil {
+		return x.UnavailableShards
+	}
+	return nil
+}
+
 var File_activity_api_activity_proto protoreflect.FileDescriptor
 
 const file_activity_api_activity_proto_rawDesc = "" +
 	"\n" +
-	"\x1bactivity_api/activity.proto\x12\x14taskflow.activity.v1\x1a\x15models/activity.proto\x1a\x1cgoogle/api/annotations.proto\"\xcc\x01\n" +
+	"\x1bactivity_api/activity.proto\x12\x14taskflow.activity.v1\x1a\x15models/activity.proto\x1a\x1cgoogle/api/annotations.proto\"\x89\x02\n" +
 	"\x14GetActivitiesRequest\x12\x1f\n" +
 	"\ventity_type\x18\x01 \x01(\tR\n" +
 	"entityType\x12\x1b\n" +
@@ -299,23 +379,33 @@ const file_activity_api_activity_proto_rawDesc = "" +
 	"\x0efrom_timestamp\x18\x03 \x01(\x03R\rfromTimestamp\x12!\n" +
 	"\fto_timestamp\x18\x04 \x01(\x03R\vtoTimestamp\x12\x14\n" +
 	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x06 \x01(\x05R\x06offset\"k\n" +
+	"\x06offset\x18\x06 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\a \x01(\tR\x06cursor\x12#\n" +
+	"\rallow_partial\x18\b \x01(\bR\fallowPartial\"\xbb\x01\n" +
 	"\x15GetActivitiesResponse\x12<\n" +
 	"\n" +
 	"activities\x18\x01 \x03(\v2\x1c.taskflow.models.v1.ActivityR\n" +
 	"activities\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"\xab\x01\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\x12-\n" +
+	"\x12unavailable_shards\x18\x04 \x03(\x05R\x11unavailableShards\"\xe8\x01\n" +
 	"\x18GetUserActivitiesRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12%\n" +
 	"\x0efrom_timestamp\x18\x02 \x01(\x03R\rfromTimestamp\x12!\n" +
 	"\fto_timestamp\x18\x03 \x01(\x03R\vtoTimestamp\x12\x14\n" +
 	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x05 \x01(\x05R\x06offset\"o\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\x12#\n" +
+	"\rallow_partial\x18\a \x01(\bR\fallowPartial\"\xbf\x01\n" +
 	"\x19GetUserActivitiesResponse\x12<\n" +
 	"\n" +
 	"activities\x18\x01 \x03(\v2\x1c.taskflow.models.v1.ActivityR\n" +
 	"activities\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total2\xbb\x02\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\x12-\n" +
+	"\x12unavailable_shards\x18\x04 \x03(\x05R\x11unavailableShards2\xbb\x02\n" +
 	"\x0fActivityService\x12\x84\x01\n" +
 	"\rGetActivities\x12*.taskflow.activity.v1.GetActivitiesRequest\x1a+.taskflow.activity.v1.GetActivitiesResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/activities\x12\xa0\x01\n" +
 	"\x11GetUserActivities\x12..taskflow.activity.v1.GetUserActivitiesRequest\x1a/.taskflow.activity.v1.GetUserActivitiesResponse\"*\x82\xd3\xe4\x93\x02$\x12\"/api/v1/users/{user_id}/activitiesB7Z5github.com/Sol1tud9/taskflow/internal/pb/activity_apib\x06proto3"
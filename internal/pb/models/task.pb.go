@@ -34,6 +34,7 @@ type Task struct {
 	DueDate       int64                  `protobuf:"varint,9,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
 	CreatedAt     int64                  `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     int64                  `protobuf:"varint,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version       int64                  `protobuf:"varint,12,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -145,6 +146,13 @@ func (x *Task) GetUpdatedAt() int64 {
 	return 0
 }
 
+func (x *Task) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type TaskHistory struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -241,7 +249,7 @@ var File_models_task_proto protoreflect.FileDescriptor
 
 const file_models_task_proto_rawDesc = "" +
 	"\n" +
-	"\x11models/task.proto\x12\x12taskflow.models.v1\"\xb4\x02\n" +
+	"\x11models/task.proto\x12\x12taskflow.models.v1\"\xce\x02\n" +
 	"\x04Task\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -258,7 +266,8 @@ const file_models_task_proto_rawDesc = "" +
 	"created_at\x18\n" +
 	" \x01(\x03R\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\v \x01(\x03R\tupdatedAt\"\xbe\x01\n" +
+	"updated_at\x18\v \x01(\x03R\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\f \x01(\x03R\aversion\"\xbe\x01\n" +
 	"\vTaskHistory\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12\x17\n" +
@@ -247,13 +247,34 @@ func (x *GetTaskResponse) GetTask() *models.Task {
 	return nil
 }
 
+// ListTasksRequest filters tasks on every field that is set. Time ranges are
+// unix seconds and inclusive; 0 leaves that end of the range open.
 type ListTasksRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TeamId        string                 `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
-	AssigneeId    string                 `protobuf:"bytes,2,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	TeamId     string                 `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	AssigneeId string                 `protobuf:"bytes,2,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	// status is kept for older clients; statuses matches any of several.
+	Status      string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Limit       int32    `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset      int32    `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	Statuses    []string `protobuf:"bytes,6,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	Priority    string   `protobuf:"bytes,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	CreatorId   string   `protobuf:"bytes,8,opt,name=creator_id,json=creatorId,proto3" json:"creator_id,omitempty"`
+	DueFrom     int64    `protobuf:"varint,9,opt,name=due_from,json=dueFrom,proto3" json:"due_from,omitempty"`
+	DueTo       int64    `protobuf:"varint,10,opt,name=due_to,json=dueTo,proto3" json:"due_to,omitempty"`
+	CreatedFrom int64    `protobuf:"varint,11,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   int64    `protobuf:"varint,12,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	UpdatedFrom int64    `protobuf:"varint,13,opt,name=updated_from,json=updatedFrom,proto3" json:"updated_from,omitempty"`
+	UpdatedTo   int64    `protobuf:"varint,14,opt,name=updated_to,json=updatedTo,proto3" json:"updated_to,omitempty"`
+	// overdue keeps open tasks whose due date has passed.
+	Overdue bool `protobuf:"varint,15,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	// sort is created_at, updated_at, due_date, priority, status or title,
+	// prefixed with "-" for descending order. Defaults to -created_at.
+	Sort string `protobuf:"bytes,16,opt,name=sort,proto3" json:"sort,omitempty"`
+	// cursor is the next_cursor of the previous page and replaces offset.
+	Cursor string `protobuf:"bytes,17,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// count is "exact" (the default) or "none" to skip counting.
+	Count         string `protobuf:"bytes,18,opt,name=count,proto3" json:"count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -323,10 +344,103 @@ func (x *ListTasksRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *ListTasksRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetCreatorId() string {
+	if x != nil {
+		return x.CreatorId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetDueFrom() int64 {
+	if x != nil {
+		return x.DueFrom
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetDueTo() int64 {
+	if x != nil {
+		return x.DueTo
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetCreatedFrom() int64 {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetCreatedTo() int64 {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetUpdatedFrom() int64 {
+	if x != nil {
+		return x.UpdatedFrom
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetUpdatedTo() int64 {
+	if x != nil {
+		return x.UpdatedTo
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetOverdue() bool {
+	if x != nil {
+		return x.Overdue
+	}
+	return false
+}
+
+func (x *ListTasksRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
 type ListTasksResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tasks         []*models.Task         `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tasks []*models.Task         `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// total is unset when the request asked not to count.
+	Total         *int32 `protobuf:"varint,2,opt,name=total,proto3,oneof" json:"total,omitempty"`
+	NextCursor    string `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -369,23 +483,34 @@ func (x *ListTasksResponse) GetTasks() []*models.Task {
 }
 
 func (x *ListTasksResponse) GetTotal() int32 {
-	if x != nil {
-		return x.Total
+	if x != nil && x.Total != nil {
+		return *x.Total
 	}
 	return 0
 }
 
+func (x *ListTasksResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 type UpdateTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	Priority      string                 `protobuf:"bytes,5,opt,name=priority,proto3" json:"priority,omitempty"`
-	AssigneeId    string                 `protobuf:"bytes,6,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
-	DueDate       int64                  `protobuf:"varint,7,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Status      string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Priority    string                 `protobuf:"bytes,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	AssigneeId  string                 `protobuf:"bytes,6,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	DueDate     int64                  `protobuf:"varint,7,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	UserId      string                 `protobuf:"bytes,8,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// expected_version, when set, makes the update fail with ABORTED unless
+	// the task is still at that version.
+	ExpectedVersion int64 `protobuf:"varint,9,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateTaskRequest) Reset() {
@@ -467,6 +592,20 @@ func (x *UpdateTaskRequest) GetDueDate() int64 {
 	return 0
 }
 
+func (x *UpdateTaskRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
 type UpdateTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Task          *models.Task           `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
@@ -512,8 +651,12 @@ func (x *UpdateTaskResponse) GetTask() *models.Task {
 }
 
 type DeleteTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// user_id is ignored: the deletion is attributed to the acting user.
+	//
+	// Deprecated: Marked as deprecated in task_api/task.proto.
+	UserId        string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -555,6 +698,14 @@ func (x *DeleteTaskRequest) GetId() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in task_api/task.proto.
+func (x *DeleteTaskRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
 type DeleteTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -599,6 +750,296 @@ func (x *DeleteTaskResponse) GetSuccess() bool {
 	return false
 }
 
+// SearchTasksRequest matches query against task titles and descriptions,
+// narrowed by the same filters as ListTasks. query accepts web search syntax:
+// quoted phrases, "or" and -excluded words.
+type SearchTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	TeamId        string                 `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	AssigneeId    string                 `protobuf:"bytes,3,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	Statuses      []string               `protobuf:"bytes,7,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	Priority      string                 `protobuf:"bytes,8,opt,name=priority,proto3" json:"priority,omitempty"`
+	CreatorId     string                 `protobuf:"bytes,9,opt,name=creator_id,json=creatorId,proto3" json:"creator_id,omitempty"`
+	DueFrom       int64                  `protobuf:"varint,10,opt,name=due_from,json=dueFrom,proto3" json:"due_from,omitempty"`
+	DueTo         int64                  `protobuf:"varint,11,opt,name=due_to,json=dueTo,proto3" json:"due_to,omitempty"`
+	CreatedFrom   int64                  `protobuf:"varint,12,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo     int64                  `protobuf:"varint,13,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	UpdatedFrom   int64                  `protobuf:"varint,14,opt,name=updated_from,json=updatedFrom,proto3" json:"updated_from,omitempty"`
+	UpdatedTo     int64                  `protobuf:"varint,15,opt,name=updated_to,json=updatedTo,proto3" json:"updated_to,omitempty"`
+	Overdue       bool                   `protobuf:"varint,16,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTasksRequest) Reset() {
+	*x = SearchTasksRequest{}
+	mi := &file_task_api_task_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTasksRequest) ProtoMessage() {}
+
+func (x *SearchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_api_task_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTasksRequest.ProtoReflect.Descriptor instead.
+func (*SearchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_task_api_task_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchTasksRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchTasksRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+func (x *SearchTasksRequest) GetAssigneeId() string {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return ""
+}
+
+func (x *SearchTasksRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SearchTasksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchTasksRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *SearchTasksRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *SearchTasksRequest) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *SearchTasksRequest) GetCreatorId() string {
+	if x != nil {
+		return x.CreatorId
+	}
+	return ""
+}
+
+func (x *SearchTasksRequest) GetDueFrom() int64 {
+	if x != nil {
+		return x.DueFrom
+	}
+	return 0
+}
+
+func (x *SearchTasksRequest) GetDueTo() int64 {
+	if x != nil {
+		return x.DueTo
+	}
+	return 0
+}
+
+func (x *SearchTasksRequest) GetCreatedFrom() int64 {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return 0
+}
+
+func (x *SearchTasksRequest) GetCreatedTo() int64 {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return 0
+}
+
+func (x *SearchTasksRequest) GetUpdatedFrom() int64 {
+	if x != nil {
+		return x.UpdatedFrom
+	}
+	return 0
+}
+
+func (x *SearchTasksRequest) GetUpdatedTo() int64 {
+	if x != nil {
+		return x.UpdatedTo
+	}
+	return 0
+}
+
+func (x *SearchTasksRequest) GetOverdue() bool {
+	if x != nil {
+		return x.Overdue
+	}
+	return false
+}
+
+// TaskSearchHit is a matched task with its rank and highlighted snippets.
+// Snippets are HTML: the task text is HTML-escaped and matched words are
+// wrapped in <mark></mark>, which is the only markup they contain.
+type TaskSearchHit struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Task                 *models.Task           `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	Rank                 float64                `protobuf:"fixed64,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	TitleHighlight       string                 `protobuf:"bytes,3,opt,name=title_highlight,json=titleHighlight,proto3" json:"title_highlight,omitempty"`
+	DescriptionHighlight string                 `protobuf:"bytes,4,opt,name=description_highlight,json=descriptionHighlight,proto3" json:"description_highlight,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *TaskSearchHit) Reset() {
+	*x = TaskSearchHit{}
+	mi := &file_task_api_task_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskSearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskSearchHit) ProtoMessage() {}
+
+func (x *TaskSearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_task_api_task_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskSearchHit.ProtoReflect.Descriptor instead.
+func (*TaskSearchHit) Descriptor() ([]byte, []int) {
+	return file_task_api_task_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TaskSearchHit) GetTask() *models.Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *TaskSearchHit) GetRank() float64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *TaskSearchHit) GetTitleHighlight() string {
+	if x != nil {
+		return x.TitleHighlight
+	}
+	return ""
+}
+
+func (x *TaskSearchHit) GetDescriptionHighlight() string {
+	if x != nil {
+		return x.DescriptionHighlight
+	}
+	return ""
+}
+
+type SearchTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          []*TaskSearchHit       `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTasksResponse) Reset() {
+	*x = SearchTasksResponse{}
+	mi := &file_task_api_task_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTasksResponse) ProtoMessage() {}
+
+func (x *SearchTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_api_task_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTasksResponse.ProtoReflect.Descriptor instead.
+func (*SearchTasksResponse) Descriptor() ([]byte, []int) {
+	return file_task_api_task_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SearchTasksResponse) GetHits() []*TaskSearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *SearchTasksResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
 type GetTaskHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
@@ -608,7 +1049,7 @@ type GetTaskHistoryRequest struct {
 
 func (x *GetTaskHistoryRequest) Reset() {
 	*x = GetTaskHistoryRequest{}
-	mi := &file_task_api_task_proto_msgTypes[10]
+	mi := &file_task_api_task_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -620,7 +1061,7 @@ func (x *GetTaskHistoryRequest) String() string {
 func (*GetTaskHistoryRequest) ProtoMessage() {}
 
 func (x *GetTaskHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_task_api_task_proto_msgTypes[10]
+	mi := &file_task_api_task_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -633,7 +1074,7 @@ func (x *GetTaskHistoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTaskHistoryRequest.ProtoReflect.Descriptor instead.
 func (*GetTaskHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_task_api_task_proto_rawDescGZIP(), []int{10}
+	return file_task_api_task_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetTaskHistoryRequest) GetTaskId() string {
@@ -652,7 +1093,7 @@ type GetTaskHistoryResponse struct {
 
 func (x *GetTaskHistoryResponse) Reset() {
 	*x = GetTaskHistoryResponse{}
-	mi := &file_task_api_task_proto_msgTypes[11]
+	mi := &file_task_api_task_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -664,7 +1105,7 @@ func (x *GetTaskHistoryResponse) String() string {
 func (*GetTaskHistoryResponse) ProtoMessage() {}
 
 func (x *GetTaskHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_task_api_task_proto_msgTypes[11]
+	mi := &file_task_api_task_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -677,7 +1118,7 @@ func (x *GetTaskHistoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTaskHistoryResponse.ProtoReflect.Descriptor instead.
 func (*GetTaskHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_task_api_task_proto_rawDescGZIP(), []int{11}
+	return file_task_api_task_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetTaskHistoryResponse) GetHistory() []*models.TaskHistory {
@@ -707,17 +1148,37 @@ const file_task_api_task_proto_rawDesc = "" +
 	"\x0eGetTaskRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"?\n" +
 	"\x0fGetTaskResponse\x12,\n" +
-	"\x04task\x18\x01 \x01(\v2\x18.taskflow.models.v1.TaskR\x04task\"\x92\x01\n" +
+	"\x04task\x18\x01 \x01(\v2\x18.taskflow.models.v1.TaskR\x04task\"\xfb\x03\n" +
 	"\x10ListTasksRequest\x12\x17\n" +
 	"\ateam_id\x18\x01 \x01(\tR\x06teamId\x12\x1f\n" +
 	"\vassignee_id\x18\x02 \x01(\tR\n" +
 	"assigneeId\x12\x16\n" +
 	"\x06status\x18\x03 \x01(\tR\x06status\x12\x14\n" +
 	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x05 \x01(\x05R\x06offset\"Y\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\x12\x1a\n" +
+	"\bstatuses\x18\x06 \x03(\tR\bstatuses\x12\x1a\n" +
+	"\bpriority\x18\a \x01(\tR\bpriority\x12\x1d\n" +
+	"\n" +
+	"creator_id\x18\b \x01(\tR\tcreatorId\x12\x19\n" +
+	"\bdue_from\x18\t \x01(\x03R\adueFrom\x12\x15\n" +
+	"\x06due_to\x18\n" +
+	" \x01(\x03R\x05dueTo\x12!\n" +
+	"\fcreated_from\x18\v \x01(\x03R\vcreatedFrom\x12\x1d\n" +
+	"\n" +
+	"created_to\x18\f \x01(\x03R\tcreatedTo\x12!\n" +
+	"\fupdated_from\x18\r \x01(\x03R\vupdatedFrom\x12\x1d\n" +
+	"\n" +
+	"updated_to\x18\x0e \x01(\x03R\tupdatedTo\x12\x18\n" +
+	"\aoverdue\x18\x0f \x01(\bR\aoverdue\x12\x12\n" +
+	"\x04sort\x18\x10 \x01(\tR\x04sort\x12\x16\n" +
+	"\x06cursor\x18\x11 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05count\x18\x12 \x01(\tR\x05count\"\x89\x01\n" +
 	"\x11ListTasksResponse\x12.\n" +
-	"\x05tasks\x18\x01 \x03(\v2\x18.taskflow.models.v1.TaskR\x05tasks\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"\xcb\x01\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x18.taskflow.models.v1.TaskR\x05tasks\x12\x19\n" +
+	"\x05total\x18\x02 \x01(\x05H\x00R\x05total\x88\x01\x01\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursorB\b\n" +
+	"\x06_total\"\x8f\x02\n" +
 	"\x11UpdateTaskRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -726,17 +1187,50 @@ const file_task_api_task_proto_rawDesc = "" +
 	"\bpriority\x18\x05 \x01(\tR\bpriority\x12\x1f\n" +
 	"\vassignee_id\x18\x06 \x01(\tR\n" +
 	"assigneeId\x12\x19\n" +
-	"\bdue_date\x18\a \x01(\x03R\adueDate\"B\n" +
+	"\bdue_date\x18\a \x01(\x03R\adueDate\x12\x17\n" +
+	"\auser_id\x18\b \x01(\tR\x06userId\x12)\n" +
+	"\x10expected_version\x18\t \x01(\x03R\x0fexpectedVersion\"B\n" +
 	"\x12UpdateTaskResponse\x12,\n" +
-	"\x04task\x18\x01 \x01(\v2\x18.taskflow.models.v1.TaskR\x04task\"#\n" +
+	"\x04task\x18\x01 \x01(\v2\x18.taskflow.models.v1.TaskR\x04task\"@\n" +
 	"\x11DeleteTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\".\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\auser_id\x18\x02 \x01(\tB\x02\x18\x01R\x06userId\".\n" +
 	"\x12DeleteTaskResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"0\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xd1\x03\n" +
+	"\x12SearchTasksRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x17\n" +
+	"\ateam_id\x18\x02 \x01(\tR\x06teamId\x12\x1f\n" +
+	"\vassignee_id\x18\x03 \x01(\tR\n" +
+	"assigneeId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x06 \x01(\x05R\x06offset\x12\x1a\n" +
+	"\bstatuses\x18\a \x03(\tR\bstatuses\x12\x1a\n" +
+	"\bpriority\x18\b \x01(\tR\bpriority\x12\x1d\n" +
+	"\n" +
+	"creator_id\x18\t \x01(\tR\tcreatorId\x12\x19\n" +
+	"\bdue_from\x18\n" +
+	" \x01(\x03R\adueFrom\x12\x15\n" +
+	"\x06due_to\x18\v \x01(\x03R\x05dueTo\x12!\n" +
+	"\fcreated_from\x18\f \x01(\x03R\vcreatedFrom\x12\x1d\n" +
+	"\n" +
+	"created_to\x18\r \x01(\x03R\tcreatedTo\x12!\n" +
+	"\fupdated_from\x18\x0e \x01(\x03R\vupdatedFrom\x12\x1d\n" +
+	"\n" +
+	"updated_to\x18\x0f \x01(\x03R\tupdatedTo\x12\x18\n" +
+	"\aoverdue\x18\x10 \x01(\bR\aoverdue\"\xaf\x01\n" +
+	"\rTaskSearchHit\x12,\n" +
+	"\x04task\x18\x01 \x01(\v2\x18.taskflow.models.v1.TaskR\x04task\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x01R\x04rank\x12'\n" +
+	"\x0ftitle_highlight\x18\x03 \x01(\tR\x0etitleHighlight\x123\n" +
+	"\x15description_highlight\x18\x04 \x01(\tR\x14descriptionHighlight\"`\n" +
+	"\x13SearchTasksResponse\x123\n" +
+	"\x04hits\x18\x01 \x03(\v2\x1f.taskflow.task.v1.TaskSearchHitR\x04hits\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"0\n" +
 	"\x15GetTaskHistoryRequest\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\tR\x06taskId\"S\n" +
 	"\x16GetTaskHistoryResponse\x129\n" +
-	"\ahistory\x18\x01 \x03(\v2\x1f.taskflow.models.v1.TaskHistoryR\ahistory2\xd5\x05\n" +
+	"\ahistory\x18\x01 \x03(\v2\x1f.taskflow.models.v1.TaskHistoryR\ahistory2\xcf\x06\n" +
 	"\vTaskService\x12q\n" +
 	"\n" +
 	"CreateTask\x12#.taskflow.task.v1.CreateTaskRequest\x1a$.taskflow.task.v1.CreateTaskResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/api/v1/tasks\x12j\n" +
@@ -745,7 +1239,8 @@ const file_task_api_task_proto_rawDesc = "" +
 	"\n" +
 	"UpdateTask\x12#.taskflow.task.v1.UpdateTaskRequest\x1a$.taskflow.task.v1.UpdateTaskResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*2\x12/api/v1/tasks/{id}\x12s\n" +
 	"\n" +
-	"DeleteTask\x12#.taskflow.task.v1.DeleteTaskRequest\x1a$.taskflow.task.v1.DeleteTaskResponse\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/api/v1/tasks/{id}\x12\x8c\x01\n" +
+	"DeleteTask\x12#.taskflow.task.v1.DeleteTaskRequest\x1a$.taskflow.task.v1.DeleteTaskResponse\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/api/v1/tasks/{id}\x12x\n" +
+	"\vSearchTasks\x12$.taskflow.task.v1.SearchTasksRequest\x1a%.taskflow.task.v1.SearchTasksResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/tasks/search\x12\x8c\x01\n" +
 	"\x0eGetTaskHistory\x12'.taskflow.task.v1.GetTaskHistoryRequest\x1a(.taskflow.task.v1.GetTaskHistoryResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/tasks/{task_id}/historyB3Z1github.com/Sol1tud9/taskflow/internal/pb/task_apib\x06proto3"
 
 var (
@@ -760,7 +1255,7 @@ func file_task_api_task_proto_rawDescGZIP() []byte {
 	return file_task_api_task_proto_rawDescData
 }
 
-var file_task_api_task_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_task_api_task_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_task_api_task_proto_goTypes = []any{
 	(*CreateTaskRequest)(nil),      // 0: taskflow.task.v1.CreateTaskRequest
 	(*CreateTaskResponse)(nil),     // 1: taskflow.task.v1.CreateTaskResponse
@@ -772,34 +1267,41 @@ var file_task_api_task_proto_goTypes = []any{
 	(*UpdateTaskResponse)(nil),     // 7: taskflow.task.v1.UpdateTaskResponse
 	(*DeleteTaskRequest)(nil),      // 8: taskflow.task.v1.DeleteTaskRequest
 	(*DeleteTaskResponse)(nil),     // 9: taskflow.task.v1.DeleteTaskResponse
-	(*GetTaskHistoryRequest)(nil),  // 10: taskflow.task.v1.GetTaskHistoryRequest
-	(*GetTaskHistoryResponse)(nil), // 11: taskflow.task.v1.GetTaskHistoryResponse
-	(*models.Task)(nil),            // 12: taskflow.models.v1.Task
-	(*models.TaskHistory)(nil),     // 13: taskflow.models.v1.TaskHistory
+	(*SearchTasksRequest)(nil),     // 10: taskflow.task.v1.SearchTasksRequest
+	(*TaskSearchHit)(nil),          // 11: taskflow.task.v1.TaskSearchHit
+	(*SearchTasksResponse)(nil),    // 12: taskflow.task.v1.SearchTasksResponse
+	(*GetTaskHistoryRequest)(nil),  // 13: taskflow.task.v1.GetTaskHistoryRequest
+	(*GetTaskHistoryResponse)(nil), // 14: taskflow.task.v1.GetTaskHistoryResponse
+	(*models.Task)(nil),            // 15: taskflow.models.v1.Task
+	(*models.TaskHistory)(nil),     // 16: taskflow.models.v1.TaskHistory
 }
 var file_task_api_task_proto_depIdxs = []int32{
-	12, // 0: taskflow.task.v1.CreateTaskResponse.task:type_name -> taskflow.models.v1.Task
-	12, // 1: taskflow.task.v1.GetTaskResponse.task:type_name -> taskflow.models.v1.Task
-	12, // 2: taskflow.task.v1.ListTasksResponse.tasks:type_name -> taskflow.models.v1.Task
-	12, // 3: taskflow.task.v1.UpdateTaskResponse.task:type_name -> taskflow.models.v1.Task
-	13, // 4: taskflow.task.v1.GetTaskHistoryResponse.history:type_name -> taskflow.models.v1.TaskHistory
-	0,  // 5: taskflow.task.v1.TaskService.CreateTask:input_type -> taskflow.task.v1.CreateTaskRequest
-	2,  // 6: taskflow.task.v1.TaskService.GetTask:input_type -> taskflow.task.v1.GetTaskRequest
-	4,  // 7: taskflow.task.v1.TaskService.ListTasks:input_type -> taskflow.task.v1.ListTasksRequest
-	6,  // 8: taskflow.task.v1.TaskService.UpdateTask:input_type -> taskflow.task.v1.UpdateTaskRequest
-	8,  // 9: taskflow.task.v1.TaskService.DeleteTask:input_type -> taskflow.task.v1.DeleteTaskRequest
-	10, // 10: taskflow.task.v1.TaskService.GetTaskHistory:input_type -> taskflow.task.v1.GetTaskHistoryRequest
-	1,  // 11: taskflow.task.v1.TaskService.CreateTask:output_type -> taskflow.task.v1.CreateTaskResponse
-	3,  // 12: taskflow.task.v1.TaskService.GetTask:output_type -> taskflow.task.v1.GetTaskResponse
-	5,  // 13: taskflow.task.v1.TaskService.ListTasks:output_type -> taskflow.task.v1.ListTasksResponse
-	7,  // 14: taskflow.task.v1.TaskService.UpdateTask:output_type -> taskflow.task.v1.UpdateTaskResponse
-	9,  // 15: taskflow.task.v1.TaskService.DeleteTask:output_type -> taskflow.task.v1.DeleteTaskResponse
-	11, // 16: taskflow.task.v1.TaskService.GetTaskHistory:output_type -> taskflow.task.v1.GetTaskHistoryResponse
-	11, // [11:17] is the sub-list for method output_type
-	5,  // [5:11] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	15, // 0: taskflow.task.v1.CreateTaskResponse.task:type_name -> taskflow.models.v1.Task
+	15, // 1: taskflow.task.v1.GetTaskResponse.task:type_name -> taskflow.models.v1.Task
+	15, // 2: taskflow.task.v1.ListTasksResponse.tasks:type_name -> taskflow.models.v1.Task
+	15, // 3: taskflow.task.v1.UpdateTaskResponse.task:type_name -> taskflow.models.v1.Task
+	15, // 4: taskflow.task.v1.TaskSearchHit.task:type_name -> taskflow.models.v1.Task
+	11, // 5: taskflow.task.v1.SearchTasksResponse.hits:type_name -> taskflow.task.v1.TaskSearchHit
+	16, // 6: taskflow.task.v1.GetTaskHistoryResponse.history:type_name -> taskflow.models.v1.TaskHistory
+	0,  // 7: taskflow.task.v1.TaskService.CreateTask:input_type -> taskflow.task.v1.CreateTaskRequest
+	2,  // 8: taskflow.task.v1.TaskService.GetTask:input_type -> taskflow.task.v1.GetTaskRequest
+	4,  // 9: taskflow.task.v1.TaskService.ListTasks:input_type -> taskflow.task.v1.ListTasksRequest
+	6,  // 10: taskflow.task.v1.TaskService.UpdateTask:input_type -> taskflow.task.v1.UpdateTaskRequest
+	8,  // 11: taskflow.task.v1.TaskService.DeleteTask:input_type -> taskflow.task.v1.DeleteTaskRequest
+	10, // 12: taskflow.task.v1.TaskService.SearchTasks:input_type -> taskflow.task.v1.SearchTasksRequest
+	13, // 13: taskflow.task.v1.TaskService.GetTaskHistory:input_type -> taskflow.task.v1.GetTaskHistoryRequest
+	1,  // 14: taskflow.task.v1.TaskService.CreateTask:output_type -> taskflow.task.v1.CreateTaskResponse
+	3,  // 15: taskflow.task.v1.TaskService.GetTask:output_type -> taskflow.task.v1.GetTaskResponse
+	5,  // 16: taskflow.task.v1.TaskService.ListTasks:output_type -> taskflow.task.v1.ListTasksResponse
+	7,  // 17: taskflow.task.v1.TaskService.UpdateTask:output_type -> taskflow.task.v1.UpdateTaskResponse
+	9,  // 18: taskflow.task.v1.TaskService.DeleteTask:output_type -> taskflow.task.v1.DeleteTaskResponse
+	12, // 19: taskflow.task.v1.TaskService.SearchTasks:output_type -> taskflow.task.v1.SearchTasksResponse
+	14, // 20: taskflow.task.v1.TaskService.GetTaskHistory:output_type -> taskflow.task.v1.GetTaskHistoryResponse
+	14, // [14:21] is the sub-list for method output_type
+	7,  // [7:14] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_task_api_task_proto_init() }
@@ -807,13 +1309,14 @@ func file_task_api_task_proto_init() {
 	if File_task_api_task_proto != nil {
 		return
 	}
+	file_task_api_task_proto_msgTypes[5].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_task_api_task_proto_rawDesc), len(file_task_api_task_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -27,6 +27,7 @@ type CreateUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -75,6 +76,13 @@ func (x *CreateUserRequest) GetName() string {
 	return ""
 }
 
+func (x *CreateUserRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
 type CreateUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User          *models.User           `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
@@ -119,6 +127,102 @@ func (x *CreateUserResponse) GetUser() *models.User {
 	return nil
 }
 
+type AuthenticateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthenticateRequest) Reset() {
+	*x = AuthenticateRequest{}
+	mi := &file_user_api_user_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthenticateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthenticateRequest) ProtoMessage() {}
+
+func (x *AuthenticateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthenticateRequest.ProtoReflect.Descriptor instead.
+func (*AuthenticateRequest) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AuthenticateRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *AuthenticateRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type AuthenticateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *models.User           `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthenticateResponse) Reset() {
+	*x = AuthenticateResponse{}
+	mi := &file_user_api_user_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthenticateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthenticateResponse) ProtoMessage() {}
+
+func (x *AuthenticateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthenticateResponse.ProtoReflect.Descriptor instead.
+func (*AuthenticateResponse) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AuthenticateResponse) GetUser() *models.User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
 type GetUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -128,7 +232,7 @@ type GetUserRequest struct {
 
 func (x *GetUserRequest) Reset() {
 	*x = GetUserRequest{}
-	mi := &file_user_api_user_proto_msgTypes[2]
+	mi := &file_user_api_user_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -140,7 +244,7 @@ func (x *GetUserRequest) String() string {
 func (*GetUserRequest) ProtoMessage() {}
 
 func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_api_user_proto_msgTypes[2]
+	mi := &file_user_api_user_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -153,7 +257,7 @@ func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
 func (*GetUserRequest) Descriptor() ([]byte, []int) {
-	return file_user_api_user_proto_rawDescGZIP(), []int{2}
+	return file_user_api_user_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetUserRequest) GetId() string {
@@ -172,7 +276,7 @@ type GetUserResponse struct {
 
 func (x *GetUserResponse) Reset() {
 	*x = GetUserResponse{}
-	mi := &file_user_api_user_proto_msgTypes[3]
+	mi := &file_user_api_user_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -184,7 +288,7 @@ func (x *GetUserResponse) String() string {
 func (*GetUserResponse) ProtoMessage() {}
 
 func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_api_user_proto_msgTypes[3]
+	mi := &file_user_api_user_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -197,7 +301,7 @@ func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
 func (*GetUserResponse) Descriptor() ([]byte, []int) {
-	return file_user_api_user_proto_rawDescGZIP(), []int{3}
+	return file_user_api_user_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetUserResponse) GetUser() *models.User {
@@ -207,18 +311,103 @@ func (x *GetUserResponse) GetUser() *models.User {
 	return nil
 }
 
-type UpdateUserRequest struct {
+type ListUsersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_user_api_user_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{6}
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*models.User         `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_user_api_user_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListUsersResponse) GetUsers() []*models.User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+// UpdateUserRequest may only change the acting user. Setting password
+// requires current_password if the user has one, and ends all of the
+// user's sessions.
+type UpdateUserRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email           string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Password        string                 `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
+	CurrentPassword string                 `protobuf:"bytes,5,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
 func (x *UpdateUserRequest) Reset() {
 	*x = UpdateUserRequest{}
-	mi := &file_user_api_user_proto_msgTypes[4]
+	mi := &file_user_api_user_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -230,7 +419,7 @@ func (x *UpdateUserRequest) String() string {
 func (*UpdateUserRequest) ProtoMessage() {}
 
 func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_api_user_proto_msgTypes[4]
+	mi := &file_user_api_user_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -243,7 +432,7 @@ func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
 func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
-	return file_user_api_user_proto_rawDescGZIP(), []int{4}
+	return file_user_api_user_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *UpdateUserRequest) GetId() string {
@@ -267,6 +456,20 @@ func (x *UpdateUserRequest) GetName() string {
 	return ""
 }
 
+func (x *UpdateUserRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetCurrentPassword() string {
+	if x != nil {
+		return x.CurrentPassword
+	}
+	return ""
+}
+
 type UpdateUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User          *models.User           `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
@@ -276,7 +479,7 @@ type UpdateUserResponse struct {
 
 func (x *UpdateUserResponse) Reset() {
 	*x = UpdateUserResponse{}
-	mi := &file_user_api_user_proto_msgTypes[5]
+	mi := &file_user_api_user_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -288,7 +491,7 @@ func (x *UpdateUserResponse) String() string {
 func (*UpdateUserResponse) ProtoMessage() {}
 
 func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_api_user_proto_msgTypes[5]
+	mi := &file_user_api_user_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -301,7 +504,7 @@ func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
 func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
-	return file_user_api_user_proto_rawDescGZIP(), []int{5}
+	return file_user_api_user_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UpdateUserResponse) GetUser() *models.User {
@@ -321,7 +524,7 @@ type CreateTeamRequest struct {
 
 func (x *CreateTeamRequest) Reset() {
 	*x = CreateTeamRequest{}
-	mi := &file_user_api_user_proto_msgTypes[6]
+	mi := &file_user_api_user_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -333,7 +536,7 @@ func (x *CreateTeamRequest) String() string {
 func (*CreateTeamRequest) ProtoMessage() {}
 
 func (x *CreateTeamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_api_user_proto_msgTypes[6]
+	mi := &file_user_api_user_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -346,7 +549,7 @@ func (x *CreateTeamRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateTeamRequest.ProtoReflect.Descriptor instead.
 func (*CreateTeamRequest) Descriptor() ([]byte, []int) {
-	return file_user_api_user_proto_rawDescGZIP(), []int{6}
+	return file_user_api_user_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CreateTeamRequest) GetName() string {
@@ -372,7 +575,7 @@ type CreateTeamResponse struct {
 
 func (x *CreateTeamResponse) Reset() {
 	*x = CreateTeamResponse{}
-	mi := &file_user_api_user_proto_msgTypes[7]
+	mi := &file_user_api_user_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -384,7 +587,7 @@ func (x *CreateTeamResponse) String() string {
 func (*CreateTeamResponse) ProtoMessage() {}
 
 func (x *CreateTeamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_api_user_proto_msgTypes[7]
+	mi := &file_user_api_user_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -397,7 +600,7 @@ func (x *CreateTeamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateTeamResponse.ProtoReflect.Descriptor instead.
 func (*CreateTeamResponse) Descriptor() ([]byte, []int) {
-	return file_user_api_user_proto_rawDescGZIP(), []int{7}
+	return file_user_api_user_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CreateTeamResponse) GetTeam() *models.Team {
@@ -416,7 +619,7 @@ type GetTeamRequest struct {
 
 func (x *GetTeamRequest) Reset() {
 	*x = GetTeamRequest{}
-	mi := &file_user_api_user_proto_msgTypes[8]
+	mi := &file_user_api_user_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -428,7 +631,7 @@ func (x *GetTeamRequest) String() string {
 func (*GetTeamRequest) ProtoMessage() {}
 
 func (x *GetTeamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_api_user_proto_msgTypes[8]
+	mi := &file_user_api_user_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -441,7 +644,7 @@ func (x *GetTeamRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTeamRequest.ProtoReflect.Descriptor instead.
 func (*GetTeamRequest) Descriptor() ([]byte, []int) {
-	return file_user_api_user_proto_rawDescGZIP(), []int{8}
+	return file_user_api_user_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetTeamRequest) GetId() string {
@@ -460,7 +663,7 @@ type GetTeamResponse struct {
 
 func (x *GetTeamResponse) Reset() {
 	*x = GetTeamResponse{}
-	mi := &file_user_api_user_proto_msgTypes[9]
+	mi := &file_user_api_user_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -472,7 +675,7 @@ func (x *GetTeamResponse) String() string {
 func (*GetTeamResponse) ProtoMessage() {}
 
 func (x *GetTeamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_api_user_proto_msgTypes[9]
+	mi := &file_user_api_user_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -485,7 +688,7 @@ func (x *GetTeamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTeamResponse.ProtoReflect.Descriptor instead.
 func (*GetTeamResponse) Descriptor() ([]byte, []int) {
-	return file_user_api_user_proto_rawDescGZIP(), []int{9}
+	return file_user_api_user_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetTeamResponse) GetTeam() *models.Team {
@@ -495,30 +698,27 @@ func (x *GetTeamResponse) GetTeam() *models.Team {
 	return nil
 }
 
-type AddTeamMemberRequest struct {
+type ListTeamsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TeamId        string                 `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddTeamMemberRequest) Reset() {
-	*x = AddTeamMemberRequest{}
-	mi := &file_user_api_user_proto_msgTypes[10]
+func (x *ListTeamsRequest) Reset() {
+	*x = ListTeamsRequest{}
+	mi := &file_user_api_user_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddTeamMemberRequest) String() string {
+func (x *ListTeamsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddTeamMemberRequest) ProtoMessage() {}
+func (*ListTeamsRequest) ProtoMessage() {}
 
-func (x *AddTeamMemberRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_api_user_proto_msgTypes[10]
+func (x *ListTeamsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -529,54 +729,33 @@ func (x *AddTeamMemberRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddTeamMemberRequest.ProtoReflect.Descriptor instead.
-func (*AddTeamMemberRequest) Descriptor() ([]byte, []int) {
-	return file_user_api_user_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *AddTeamMemberRequest) GetTeamId() string {
-	if x != nil {
-		return x.TeamId
-	}
-	return ""
-}
-
-func (x *AddTeamMemberRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *AddTeamMemberRequest) GetRole() string {
-	if x != nil {
-		return x.Role
-	}
-	return ""
+// Deprecated: Use ListTeamsRequest.ProtoReflect.Descriptor instead.
+func (*ListTeamsRequest) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{14}
 }
 
-type AddTeamMemberResponse struct {
+type ListTeamsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Member        *models.TeamMember     `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	Teams         []*models.Team         `protobuf:"bytes,1,rep,name=teams,proto3" json:"teams,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddTeamMemberResponse) Reset() {
-	*x = AddTeamMemberResponse{}
-	mi := &file_user_api_user_proto_msgTypes[11]
+func (x *ListTeamsResponse) Reset() {
+	*x = ListTeamsResponse{}
+	mi := &file_user_api_user_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddTeamMemberResponse) String() string {
+func (x *ListTeamsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddTeamMemberResponse) ProtoMessage() {}
+func (*ListTeamsResponse) ProtoMessage() {}
 
-func (x *AddTeamMemberResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_api_user_proto_msgTypes[11]
+func (x *ListTeamsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -587,40 +766,636 @@ func (x *AddTeamMemberResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddTeamMemberResponse.ProtoReflect.Descriptor instead.
-func (*AddTeamMemberResponse) Descriptor() ([]byte, []int) {
-	return file_user_api_user_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ListTeamsResponse.ProtoReflect.Descriptor instead.
+func (*ListTeamsResponse) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *AddTeamMemberResponse) GetMember() *models.TeamMember {
+func (x *ListTeamsResponse) GetTeams() []*models.Team {
 	if x != nil {
-		return x.Member
+		return x.Teams
 	}
 	return nil
 }
 
-type GetTeamMembersRequest struct {
+type UpdateTeamRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TeamId        string                 `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTeamMembersRequest) Reset() {
-	*x = GetTeamMembersRequest{}
-	mi := &file_user_api_user_proto_msgTypes[12]
+func (x *UpdateTeamRequest) Reset() {
+	*x = UpdateTeamRequest{}
+	mi := &file_user_api_user_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTeamMembersRequest) String() string {
+func (x *UpdateTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTeamRequest) ProtoMessage() {}
+
+func (x *UpdateTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTeamRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTeamRequest) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateTeamRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTeamRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UpdateTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *models.Team           `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTeamResponse) Reset() {
+	*x = UpdateTeamResponse{}
+	mi := &file_user_api_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTeamResponse) ProtoMessage() {}
+
+func (x *UpdateTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTeamResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTeamResponse) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateTeamResponse) GetTeam() *models.Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type AddTeamMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamId        string                 `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTeamMemberRequest) Reset() {
+	*x = AddTeamMemberRequest{}
+	mi := &file_user_api_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTeamMemberRequest) ProtoMessage() {}
+
+func (x *AddTeamMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTeamMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddTeamMemberRequest) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AddTeamMemberRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+func (x *AddTeamMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AddTeamMemberRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type AddTeamMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *models.TeamMember     `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTeamMemberResponse) Reset() {
+	*x = AddTeamMemberResponse{}
+	mi := &file_user_api_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTeamMemberResponse) ProtoMessage() {}
+
+func (x *AddTeamMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTeamMemberResponse.ProtoReflect.Descriptor instead.
+func (*AddTeamMemberResponse) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AddTeamMemberResponse) GetMember() *models.TeamMember {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type GetTeamMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamId        string                 `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamMembersRequest) Reset() {
+	*x = GetTeamMembersRequest{}
+	mi := &file_user_api_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamMembersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
 func (*GetTeamMembersRequest) ProtoMessage() {}
 
-func (x *GetTeamMembersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_api_user_proto_msgTypes[12]
+func (x *GetTeamMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamMembersRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamMembersRequest) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetTeamMembersRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+type GetTeamMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*models.TeamMember   `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamMembersResponse) Reset() {
+	*x = GetTeamMembersResponse{}
+	mi := &file_user_api_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamMembersResponse) ProtoMessage() {}
+
+func (x *GetTeamMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamMembersResponse.ProtoReflect.Descriptor instead.
+func (*GetTeamMembersResponse) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetTeamMembersResponse) GetMembers() []*models.TeamMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type RemoveTeamMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamId        string                 `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTeamMemberRequest) Reset() {
+	*x = RemoveTeamMemberRequest{}
+	mi := &file_user_api_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTeamMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTeamMemberRequest) ProtoMessage() {}
+
+func (x *RemoveTeamMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTeamMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTeamMemberRequest) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RemoveTeamMemberRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+func (x *RemoveTeamMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RemoveTeamMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTeamMemberResponse) Reset() {
+	*x = RemoveTeamMemberResponse{}
+	mi := &file_user_api_user_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTeamMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTeamMemberResponse) ProtoMessage() {}
+
+func (x *RemoveTeamMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTeamMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveTeamMemberResponse) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RemoveTeamMemberResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListUserMembershipsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserMembershipsRequest) Reset() {
+	*x = ListUserMembershipsRequest{}
+	mi := &file_user_api_user_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserMembershipsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserMembershipsRequest) ProtoMessage() {}
+
+func (x *ListUserMembershipsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserMembershipsRequest.ProtoReflect.Descriptor instead.
+func (*ListUserMembershipsRequest) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListUserMembershipsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListUserMembershipsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Memberships   []*models.TeamMember   `protobuf:"bytes,1,rep,name=memberships,proto3" json:"memberships,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserMembershipsResponse) Reset() {
+	*x = ListUserMembershipsResponse{}
+	mi := &file_user_api_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserMembershipsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserMembershipsResponse) ProtoMessage() {}
+
+func (x *ListUserMembershipsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserMembershipsResponse.ProtoReflect.Descriptor instead.
+func (*ListUserMembershipsResponse) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListUserMembershipsResponse) GetMemberships() []*models.TeamMember {
+	if x != nil {
+		return x.Memberships
+	}
+	return nil
+}
+
+type CreateServiceAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateServiceAccountRequest) Reset() {
+	*x = CreateServiceAccountRequest{}
+	mi := &file_user_api_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateServiceAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateServiceAccountRequest) ProtoMessage() {}
+
+func (x *CreateServiceAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateServiceAccountRequest.ProtoReflect.Descriptor instead.
+func (*CreateServiceAccountRequest) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CreateServiceAccountRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateServiceAccountResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ServiceAccount *models.User           `protobuf:"bytes,1,opt,name=service_account,json=serviceAccount,proto3" json:"service_account,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateServiceAccountResponse) Reset() {
+	*x = CreateServiceAccountResponse{}
+	mi := &file_user_api_user_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateServiceAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateServiceAccountResponse) ProtoMessage() {}
+
+func (x *CreateServiceAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateServiceAccountResponse.ProtoReflect.Descriptor instead.
+func (*CreateServiceAccountResponse) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateServiceAccountResponse) GetServiceAccount() *models.User {
+	if x != nil {
+		return x.ServiceAccount
+	}
+	return nil
+}
+
+type ListServiceAccountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServiceAccountsRequest) Reset() {
+	*x = ListServiceAccountsRequest{}
+	mi := &file_user_api_user_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServiceAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServiceAccountsRequest) ProtoMessage() {}
+
+func (x *ListServiceAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServiceAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListServiceAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{28}
+}
+
+type ListServiceAccountsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ServiceAccounts []*models.User         `protobuf:"bytes,1,rep,name=service_accounts,json=serviceAccounts,proto3" json:"service_accounts,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListServiceAccountsResponse) Reset() {
+	*x = ListServiceAccountsResponse{}
+	mi := &file_user_api_user_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServiceAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServiceAccountsResponse) ProtoMessage() {}
+
+func (x *ListServiceAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -631,40 +1406,101 @@ func (x *GetTeamMembersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTeamMembersRequest.ProtoReflect.Descriptor instead.
-func (*GetTeamMembersRequest) Descriptor() ([]byte, []int) {
-	return file_user_api_user_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ListServiceAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListServiceAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *GetTeamMembersRequest) GetTeamId() string {
+func (x *ListServiceAccountsResponse) GetServiceAccounts() []*models.User {
 	if x != nil {
-		return x.TeamId
+		return x.ServiceAccounts
+	}
+	return nil
+}
+
+type CreateAPIKeyRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ServiceAccountId string                 `protobuf:"bytes,1,opt,name=service_account_id,json=serviceAccountId,proto3" json:"service_account_id,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes           []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyRequest) Reset() {
+	*x = CreateAPIKeyRequest{}
+	mi := &file_user_api_user_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyRequest) ProtoMessage() {}
+
+func (x *CreateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateAPIKeyRequest) GetServiceAccountId() string {
+	if x != nil {
+		return x.ServiceAccountId
 	}
 	return ""
 }
 
-type GetTeamMembersResponse struct {
+func (x *CreateAPIKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+type CreateAPIKeyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Members       []*models.TeamMember   `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	ApiKey        *models.APIKey         `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTeamMembersResponse) Reset() {
-	*x = GetTeamMembersResponse{}
-	mi := &file_user_api_user_proto_msgTypes[13]
+func (x *CreateAPIKeyResponse) Reset() {
+	*x = CreateAPIKeyResponse{}
+	mi := &file_user_api_user_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTeamMembersResponse) String() string {
+func (x *CreateAPIKeyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTeamMembersResponse) ProtoMessage() {}
+func (*CreateAPIKeyResponse) ProtoMessage() {}
 
-func (x *GetTeamMembersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_api_user_proto_msgTypes[13]
+func (x *CreateAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -675,14 +1511,293 @@ func (x *GetTeamMembersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTeamMembersResponse.ProtoReflect.Descriptor instead.
-func (*GetTeamMembersResponse) Descriptor() ([]byte, []int) {
-	return file_user_api_user_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use CreateAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *GetTeamMembersResponse) GetMembers() []*models.TeamMember {
+func (x *CreateAPIKeyResponse) GetApiKey() *models.APIKey {
 	if x != nil {
-		return x.Members
+		return x.ApiKey
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type ListAPIKeysRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ServiceAccountId string                 `protobuf:"bytes,1,opt,name=service_account_id,json=serviceAccountId,proto3" json:"service_account_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysRequest) Reset() {
+	*x = ListAPIKeysRequest{}
+	mi := &file_user_api_user_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysRequest) ProtoMessage() {}
+
+func (x *ListAPIKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysRequest) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListAPIKeysRequest) GetServiceAccountId() string {
+	if x != nil {
+		return x.ServiceAccountId
+	}
+	return ""
+}
+
+type ListAPIKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKeys       []*models.APIKey       `protobuf:"bytes,1,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysResponse) Reset() {
+	*x = ListAPIKeysResponse{}
+	mi := &file_user_api_user_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysResponse) ProtoMessage() {}
+
+func (x *ListAPIKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysResponse) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListAPIKeysResponse) GetApiKeys() []*models.APIKey {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return nil
+}
+
+type RevokeAPIKeyRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ServiceAccountId string                 `protobuf:"bytes,1,opt,name=service_account_id,json=serviceAccountId,proto3" json:"service_account_id,omitempty"`
+	Id               string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyRequest) Reset() {
+	*x = RevokeAPIKeyRequest{}
+	mi := &file_user_api_user_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyRequest) ProtoMessage() {}
+
+func (x *RevokeAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RevokeAPIKeyRequest) GetServiceAccountId() string {
+	if x != nil {
+		return x.ServiceAccountId
+	}
+	return ""
+}
+
+func (x *RevokeAPIKeyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RevokeAPIKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyResponse) Reset() {
+	*x = RevokeAPIKeyResponse{}
+	mi := &file_user_api_user_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyResponse) ProtoMessage() {}
+
+func (x *RevokeAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RevokeAPIKeyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type VerifyAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyAPIKeyRequest) Reset() {
+	*x = VerifyAPIKeyRequest{}
+	mi := &file_user_api_user_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAPIKeyRequest) ProtoMessage() {}
+
+func (x *VerifyAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*VerifyAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *VerifyAPIKeyRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type VerifyAPIKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey        *models.APIKey         `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyAPIKeyResponse) Reset() {
+	*x = VerifyAPIKeyResponse{}
+	mi := &file_user_api_user_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAPIKeyResponse) ProtoMessage() {}
+
+func (x *VerifyAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_api_user_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*VerifyAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_user_api_user_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *VerifyAPIKeyResponse) GetApiKey() *models.APIKey {
+	if x != nil {
+		return x.ApiKey
 	}
 	return nil
 }
@@ -691,20 +1806,31 @@ var File_user_api_user_proto protoreflect.FileDescriptor
 
 const file_user_api_user_proto_rawDesc = "" +
 	"\n" +
-	"\x13user_api/user.proto\x12\x10taskflow.user.v1\x1a\x11models/user.proto\x1a\x1cgoogle/api/annotations.proto\"=\n" +
+	"\x13user_api/user.proto\x12\x10taskflow.user.v1\x1a\x11models/user.proto\x1a\x1cgoogle/api/annotations.proto\"Y\n" +
 	"\x11CreateUserRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"B\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"B\n" +
 	"\x12CreateUserResponse\x12,\n" +
+	"\x04user\x18\x01 \x01(\v2\x18.taskflow.models.v1.UserR\x04user\"G\n" +
+	"\x13AuthenticateRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"D\n" +
+	"\x14AuthenticateResponse\x12,\n" +
 	"\x04user\x18\x01 \x01(\v2\x18.taskflow.models.v1.UserR\x04user\" \n" +
 	"\x0eGetUserRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"?\n" +
 	"\x0fGetUserResponse\x12,\n" +
-	"\x04user\x18\x01 \x01(\v2\x18.taskflow.models.v1.UserR\x04user\"M\n" +
+	"\x04user\x18\x01 \x01(\v2\x18.taskflow.models.v1.UserR\x04user\"\x12\n" +
+	"\x10ListUsersRequest\"C\n" +
+	"\x11ListUsersResponse\x12.\n" +
+	"\x05users\x18\x01 \x03(\v2\x18.taskflow.models.v1.UserR\x05users\"\x94\x01\n" +
 	"\x11UpdateUserRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\"B\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\bpassword\x18\x04 \x01(\tR\bpassword\x12)\n" +
+	"\x10current_password\x18\x05 \x01(\tR\x0fcurrentPassword\"B\n" +
 	"\x12UpdateUserResponse\x12,\n" +
 	"\x04user\x18\x01 \x01(\v2\x18.taskflow.models.v1.UserR\x04user\"B\n" +
 	"\x11CreateTeamRequest\x12\x12\n" +
@@ -715,6 +1841,14 @@ const file_user_api_user_proto_rawDesc = "" +
 	"\x0eGetTeamRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"?\n" +
 	"\x0fGetTeamResponse\x12,\n" +
+	"\x04team\x18\x01 \x01(\v2\x18.taskflow.models.v1.TeamR\x04team\"\x12\n" +
+	"\x10ListTeamsRequest\"C\n" +
+	"\x11ListTeamsResponse\x12.\n" +
+	"\x05teams\x18\x01 \x03(\v2\x18.taskflow.models.v1.TeamR\x05teams\"7\n" +
+	"\x11UpdateTeamRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"B\n" +
+	"\x12UpdateTeamResponse\x12,\n" +
 	"\x04team\x18\x01 \x01(\v2\x18.taskflow.models.v1.TeamR\x04team\"\\\n" +
 	"\x14AddTeamMemberRequest\x12\x17\n" +
 	"\ateam_id\x18\x01 \x01(\tR\x06teamId\x12\x17\n" +
@@ -725,18 +1859,67 @@ const file_user_api_user_proto_rawDesc = "" +
 	"\x15GetTeamMembersRequest\x12\x17\n" +
 	"\ateam_id\x18\x01 \x01(\tR\x06teamId\"R\n" +
 	"\x16GetTeamMembersResponse\x128\n" +
-	"\amembers\x18\x01 \x03(\v2\x1e.taskflow.models.v1.TeamMemberR\amembers2\xe1\x06\n" +
+	"\amembers\x18\x01 \x03(\v2\x1e.taskflow.models.v1.TeamMemberR\amembers\"K\n" +
+	"\x17RemoveTeamMemberRequest\x12\x17\n" +
+	"\ateam_id\x18\x01 \x01(\tR\x06teamId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"4\n" +
+	"\x18RemoveTeamMemberResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"5\n" +
+	"\x1aListUserMembershipsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"_\n" +
+	"\x1bListUserMembershipsResponse\x12@\n" +
+	"\vmemberships\x18\x01 \x03(\v2\x1e.taskflow.models.v1.TeamMemberR\vmemberships\"1\n" +
+	"\x1bCreateServiceAccountRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"a\n" +
+	"\x1cCreateServiceAccountResponse\x12A\n" +
+	"\x0fservice_account\x18\x01 \x01(\v2\x18.taskflow.models.v1.UserR\x0eserviceAccount\"\x1c\n" +
+	"\x1aListServiceAccountsRequest\"b\n" +
+	"\x1bListServiceAccountsResponse\x12C\n" +
+	"\x10service_accounts\x18\x01 \x03(\v2\x18.taskflow.models.v1.UserR\x0fserviceAccounts\"o\n" +
+	"\x13CreateAPIKeyRequest\x12,\n" +
+	"\x12service_account_id\x18\x01 \x01(\tR\x10serviceAccountId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\"]\n" +
+	"\x14CreateAPIKeyResponse\x123\n" +
+	"\aapi_key\x18\x01 \x01(\v2\x1a.taskflow.models.v1.APIKeyR\x06apiKey\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\"B\n" +
+	"\x12ListAPIKeysRequest\x12,\n" +
+	"\x12service_account_id\x18\x01 \x01(\tR\x10serviceAccountId\"L\n" +
+	"\x13ListAPIKeysResponse\x125\n" +
+	"\bapi_keys\x18\x01 \x03(\v2\x1a.taskflow.models.v1.APIKeyR\aapiKeys\"S\n" +
+	"\x13RevokeAPIKeyRequest\x12,\n" +
+	"\x12service_account_id\x18\x01 \x01(\tR\x10serviceAccountId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"0\n" +
+	"\x14RevokeAPIKeyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"'\n" +
+	"\x13VerifyAPIKeyRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"K\n" +
+	"\x14VerifyAPIKeyResponse\x123\n" +
+	"\aapi_key\x18\x01 \x01(\v2\x1a.taskflow.models.v1.APIKeyR\x06apiKey2\x91\x13\n" +
 	"\vUserService\x12q\n" +
 	"\n" +
-	"CreateUser\x12#.taskflow.user.v1.CreateUserRequest\x1a$.taskflow.user.v1.CreateUserResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/api/v1/users\x12j\n" +
-	"\aGetUser\x12 .taskflow.user.v1.GetUserRequest\x1a!.taskflow.user.v1.GetUserResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/users/{id}\x12v\n" +
+	"CreateUser\x12#.taskflow.user.v1.CreateUserRequest\x1a$.taskflow.user.v1.CreateUserResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/api/v1/users\x12]\n" +
+	"\fAuthenticate\x12%.taskflow.user.v1.AuthenticateRequest\x1a&.taskflow.user.v1.AuthenticateResponse\x12j\n" +
+	"\aGetUser\x12 .taskflow.user.v1.GetUserRequest\x1a!.taskflow.user.v1.GetUserResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/users/{id}\x12k\n" +
+	"\tListUsers\x12\".taskflow.user.v1.ListUsersRequest\x1a#.taskflow.user.v1.ListUsersResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/api/v1/users\x12v\n" +
 	"\n" +
 	"UpdateUser\x12#.taskflow.user.v1.UpdateUserRequest\x1a$.taskflow.user.v1.UpdateUserResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*2\x12/api/v1/users/{id}\x12q\n" +
 	"\n" +
 	"CreateTeam\x12#.taskflow.user.v1.CreateTeamRequest\x1a$.taskflow.user.v1.CreateTeamResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/api/v1/teams\x12j\n" +
-	"\aGetTeam\x12 .taskflow.user.v1.GetTeamRequest\x1a!.taskflow.user.v1.GetTeamResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/teams/{id}\x12\x8c\x01\n" +
+	"\aGetTeam\x12 .taskflow.user.v1.GetTeamRequest\x1a!.taskflow.user.v1.GetTeamResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/teams/{id}\x12k\n" +
+	"\tListTeams\x12\".taskflow.user.v1.ListTeamsRequest\x1a#.taskflow.user.v1.ListTeamsResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/api/v1/teams\x12v\n" +
+	"\n" +
+	"UpdateTeam\x12#.taskflow.user.v1.UpdateTeamRequest\x1a$.taskflow.user.v1.UpdateTeamResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*2\x12/api/v1/teams/{id}\x12\x8c\x01\n" +
 	"\rAddTeamMember\x12&.taskflow.user.v1.AddTeamMemberRequest\x1a'.taskflow.user.v1.AddTeamMemberResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/api/v1/teams/{team_id}/members\x12\x8c\x01\n" +
-	"\x0eGetTeamMembers\x12'.taskflow.user.v1.GetTeamMembersRequest\x1a(.taskflow.user.v1.GetTeamMembersResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/teams/{team_id}/membersB3Z1github.com/Sol1tud9/taskflow/internal/pb/user_apib\x06proto3"
+	"\x0eGetTeamMembers\x12'.taskflow.user.v1.GetTeamMembersRequest\x1a(.taskflow.user.v1.GetTeamMembersResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/teams/{team_id}/members\x12r\n" +
+	"\x13ListUserMemberships\x12,.taskflow.user.v1.ListUserMembershipsRequest\x1a-.taskflow.user.v1.ListUserMembershipsResponse\x12\x9c\x01\n" +
+	"\x10RemoveTeamMember\x12).taskflow.user.v1.RemoveTeamMemberRequest\x1a*.taskflow.user.v1.RemoveTeamMemberResponse\"1\x82\xd3\xe4\x93\x02+*)/api/v1/teams/{team_id}/members/{user_id}\x12\x9a\x01\n" +
+	"\x14CreateServiceAccount\x12-.taskflow.user.v1.CreateServiceAccountRequest\x1a..taskflow.user.v1.CreateServiceAccountResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/api/v1/service-accounts\x12\x94\x01\n" +
+	"\x13ListServiceAccounts\x12,.taskflow.user.v1.ListServiceAccountsRequest\x1a-.taskflow.user.v1.ListServiceAccountsResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/service-accounts\x12\x9c\x01\n" +
+	"\fCreateAPIKey\x12%.taskflow.user.v1.CreateAPIKeyRequest\x1a&.taskflow.user.v1.CreateAPIKeyResponse\"=\x82\xd3\xe4\x93\x027:\x01*\"2/api/v1/service-accounts/{service_account_id}/keys\x12\x96\x01\n" +
+	"\vListAPIKeys\x12$.taskflow.user.v1.ListAPIKeysRequest\x1a%.taskflow.user.v1.ListAPIKeysResponse\":\x82\xd3\xe4\x93\x024\x122/api/v1/service-accounts/{service_account_id}/keys\x12\x9e\x01\n" +
+	"\fRevokeAPIKey\x12%.taskflow.user.v1.RevokeAPIKeyRequest\x1a&.taskflow.user.v1.RevokeAPIKeyResponse\"?\x82\xd3\xe4\x93\x029*7/api/v1/service-accounts/{service_account_id}/keys/{id}\x12]\n" +
+	"\fVerifyAPIKey\x12%.taskflow.user.v1.VerifyAPIKeyRequest\x1a&.taskflow.user.v1.VerifyAPIKeyResponseB3Z1github.com/Sol1tud9/taskflow/internal/pb/user_apib\x06proto3"
 
 var (
 	file_user_api_user_proto_rawDescOnce sync.Once
@@ -750,53 +1933,112 @@ func file_user_api_user_proto_rawDescGZIP() []byte {
 	return file_user_api_user_proto_rawDescData
 }
 
-var file_user_api_user_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_user_api_user_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_user_api_user_proto_goTypes = []any{
-	(*CreateUserRequest)(nil),      // 0: taskflow.user.v1.CreateUserRequest
-	(*CreateUserResponse)(nil),     // 1: taskflow.user.v1.CreateUserResponse
-	(*GetUserRequest)(nil),         // 2: taskflow.user.v1.GetUserRequest
-	(*GetUserResponse)(nil),        // 3: taskflow.user.v1.GetUserResponse
-	(*UpdateUserRequest)(nil),      // 4: taskflow.user.v1.UpdateUserRequest
-	(*UpdateUserResponse)(nil),     // 5: taskflow.user.v1.UpdateUserResponse
-	(*CreateTeamRequest)(nil),      // 6: taskflow.user.v1.CreateTeamRequest
-	(*CreateTeamResponse)(nil),     // 7: taskflow.user.v1.CreateTeamResponse
-	(*GetTeamRequest)(nil),         // 8: taskflow.user.v1.GetTeamRequest
-	(*GetTeamResponse)(nil),        // 9: taskflow.user.v1.GetTeamResponse
-	(*AddTeamMemberRequest)(nil),   // 10: taskflow.user.v1.AddTeamMemberRequest
-	(*AddTeamMemberResponse)(nil),  // 11: taskflow.user.v1.AddTeamMemberResponse
-	(*GetTeamMembersRequest)(nil),  // 12: taskflow.user.v1.GetTeamMembersRequest
-	(*GetTeamMembersResponse)(nil), // 13: taskflow.user.v1.GetTeamMembersResponse
-	(*models.User)(nil),            // 14: taskflow.models.v1.User
-	(*models.Team)(nil),            // 15: taskflow.models.v1.Team
-	(*models.TeamMember)(nil),      // 16: taskflow.models.v1.TeamMember
+	(*CreateUserRequest)(nil),            // 0: taskflow.user.v1.CreateUserRequest
+	(*CreateUserResponse)(nil),           // 1: taskflow.user.v1.CreateUserResponse
+	(*AuthenticateRequest)(nil),          // 2: taskflow.user.v1.AuthenticateRequest
+	(*AuthenticateResponse)(nil),         // 3: taskflow.user.v1.AuthenticateResponse
+	(*GetUserRequest)(nil),               // 4: taskflow.user.v1.GetUserRequest
+	(*GetUserResponse)(nil),              // 5: taskflow.user.v1.GetUserResponse
+	(*ListUsersRequest)(nil),             // 6: taskflow.user.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),            // 7: taskflow.user.v1.ListUsersResponse
+	(*UpdateUserRequest)(nil),            // 8: taskflow.user.v1.UpdateUserRequest
+	(*UpdateUserResponse)(nil),           // 9: taskflow.user.v1.UpdateUserResponse
+	(*CreateTeamRequest)(nil),            // 10: taskflow.user.v1.CreateTeamRequest
+	(*CreateTeamResponse)(nil),           // 11: taskflow.user.v1.CreateTeamResponse
+	(*GetTeamRequest)(nil),               // 12: taskflow.user.v1.GetTeamRequest
+	(*GetTeamResponse)(nil),              // 13: taskflow.user.v1.GetTeamResponse
+	(*ListTeamsRequest)(nil),             // 14: taskflow.user.v1.ListTeamsRequest
+	(*ListTeamsResponse)(nil),            // 15: taskflow.user.v1.ListTeamsResponse
+	(*UpdateTeamRequest)(nil),            // 16: taskflow.user.v1.UpdateTeamRequest
+	(*UpdateTeamResponse)(nil),           // 17: taskflow.user.v1.UpdateTeamResponse
+	(*AddTeamMemberRequest)(nil),         // 18: taskflow.user.v1.AddTeamMemberRequest
+	(*AddTeamMemberResponse)(nil),        // 19: taskflow.user.v1.AddTeamMemberResponse
+	(*GetTeamMembersRequest)(nil),        // 20: taskflow.user.v1.GetTeamMembersRequest
+	(*GetTeamMembersResponse)(nil),       // 21: taskflow.user.v1.GetTeamMembersResponse
+	(*RemoveTeamMemberRequest)(nil),      // 22: taskflow.user.v1.RemoveTeamMemberRequest
+	(*RemoveTeamMemberResponse)(nil),     // 23: taskflow.user.v1.RemoveTeamMemberResponse
+	(*ListUserMembershipsRequest)(nil),   // 24: taskflow.user.v1.ListUserMembershipsRequest
+	(*ListUserMembershipsResponse)(nil),  // 25: taskflow.user.v1.ListUserMembershipsResponse
+	(*CreateServiceAccountRequest)(nil),  // 26: taskflow.user.v1.CreateServiceAccountRequest
+	(*CreateServiceAccountResponse)(nil), // 27: taskflow.user.v1.CreateServiceAccountResponse
+	(*ListServiceAccountsRequest)(nil),   // 28: taskflow.user.v1.ListServiceAccountsRequest
+	(*ListServiceAccountsResponse)(nil),  // 29: taskflow.user.v1.ListServiceAccountsResponse
+	(*CreateAPIKeyRequest)(nil),          // 30: taskflow.user.v1.CreateAPIKeyRequest
+	(*CreateAPIKeyResponse)(nil),         // 31: taskflow.user.v1.CreateAPIKeyResponse
+	(*ListAPIKeysRequest)(nil),           // 32: taskflow.user.v1.ListAPIKeysRequest
+	(*ListAPIKeysResponse)(nil),          // 33: taskflow.user.v1.ListAPIKeysResponse
+	(*RevokeAPIKeyRequest)(nil),          // 34: taskflow.user.v1.RevokeAPIKeyRequest
+	(*RevokeAPIKeyResponse)(nil),         // 35: taskflow.user.v1.RevokeAPIKeyResponse
+	(*VerifyAPIKeyRequest)(nil),          // 36: taskflow.user.v1.VerifyAPIKeyRequest
+	(*VerifyAPIKeyResponse)(nil),         // 37: taskflow.user.v1.VerifyAPIKeyResponse
+	(*models.User)(nil),                  // 38: taskflow.models.v1.User
+	(*models.Team)(nil),                  // 39: taskflow.models.v1.Team
+	(*models.TeamMember)(nil),            // 40: taskflow.models.v1.TeamMember
+	(*models.APIKey)(nil),                // 41: taskflow.models.v1.APIKey
 }
 var file_user_api_user_proto_depIdxs = []int32{
-	14, // 0: taskflow.user.v1.CreateUserResponse.user:type_name -> taskflow.models.v1.User
-	14, // 1: taskflow.user.v1.GetUserResponse.user:type_name -> taskflow.models.v1.User
-	14, // 2: taskflow.user.v1.UpdateUserResponse.user:type_name -> taskflow.models.v1.User
-	15, // 3: taskflow.user.v1.CreateTeamResponse.team:type_name -> taskflow.models.v1.Team
-	15, // 4: taskflow.user.v1.GetTeamResponse.team:type_name -> taskflow.models.v1.Team
-	16, // 5: taskflow.user.v1.AddTeamMemberResponse.member:type_name -> taskflow.models.v1.TeamMember
-	16, // 6: taskflow.user.v1.GetTeamMembersResponse.members:type_name -> taskflow.models.v1.TeamMember
-	0,  // 7: taskflow.user.v1.UserService.CreateUser:input_type -> taskflow.user.v1.CreateUserRequest
-	2,  // 8: taskflow.user.v1.UserService.GetUser:input_type -> taskflow.user.v1.GetUserRequest
-	4,  // 9: taskflow.user.v1.UserService.UpdateUser:input_type -> taskflow.user.v1.UpdateUserRequest
-	6,  // 10: taskflow.user.v1.UserService.CreateTeam:input_type -> taskflow.user.v1.CreateTeamRequest
-	8,  // 11: taskflow.user.v1.UserService.GetTeam:input_type -> taskflow.user.v1.GetTeamRequest
-	10, // 12: taskflow.user.v1.UserService.AddTeamMember:input_type -> taskflow.user.v1.AddTeamMemberRequest
-	12, // 13: taskflow.user.v1.UserService.GetTeamMembers:input_type -> taskflow.user.v1.GetTeamMembersRequest
-	1,  // 14: taskflow.user.v1.UserService.CreateUser:output_type -> taskflow.user.v1.CreateUserResponse
-	3,  // 15: taskflow.user.v1.UserService.GetUser:output_type -> taskflow.user.v1.GetUserResponse
-	5,  // 16: taskflow.user.v1.UserService.UpdateUser:output_type -> taskflow.user.v1.UpdateUserResponse
-	7,  // 17: taskflow.user.v1.UserService.CreateTeam:output_type -> taskflow.user.v1.CreateTeamResponse
-	9,  // 18: taskflow.user.v1.UserService.GetTeam:output_type -> taskflow.user.v1.GetTeamResponse
-	11, // 19: taskflow.user.v1.UserService.AddTeamMember:output_type -> taskflow.user.v1.AddTeamMemberResponse
-	13, // 20: taskflow.user.v1.UserService.GetTeamMembers:output_type -> taskflow.user.v1.GetTeamMembersResponse
-	14, // [14:21] is the sub-list for method output_type
-	7,  // [7:14] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	38, // 0: taskflow.user.v1.CreateUserResponse.user:type_name -> taskflow.models.v1.User
+	38, // 1: taskflow.user.v1.AuthenticateResponse.user:type_name -> taskflow.models.v1.User
+	38, // 2: taskflow.user.v1.GetUserResponse.user:type_name -> taskflow.models.v1.User
+	38, // 3: taskflow.user.v1.ListUsersResponse.users:type_name -> taskflow.models.v1.User
+	38, // 4: taskflow.user.v1.UpdateUserResponse.user:type_name -> taskflow.models.v1.User
+	39, // 5: taskflow.user.v1.CreateTeamResponse.team:type_name -> taskflow.models.v1.Team
+	39, // 6: taskflow.user.v1.GetTeamResponse.team:type_name -> taskflow.models.v1.Team
+	39, // 7: taskflow.user.v1.ListTeamsResponse.teams:type_name -> taskflow.models.v1.Team
+	39, // 8: taskflow.user.v1.UpdateTeamResponse.team:type_name -> taskflow.models.v1.Team
+	40, // 9: taskflow.user.v1.AddTeamMemberResponse.member:type_name -> taskflow.models.v1.TeamMember
+	40, // 10: taskflow.user.v1.GetTeamMembersResponse.members:type_name -> taskflow.models.v1.TeamMember
+	40, // 11: taskflow.user.v1.ListUserMembershipsResponse.memberships:type_name -> taskflow.models.v1.TeamMember
+	38, // 12: taskflow.user.v1.CreateServiceAccountResponse.service_account:type_name -> taskflow.models.v1.User
+	38, // 13: taskflow.user.v1.ListServiceAccountsResponse.service_accounts:type_name -> taskflow.models.v1.User
+	41, // 14: taskflow.user.v1.CreateAPIKeyResponse.api_key:type_name -> taskflow.models.v1.APIKey
+	41, // 15: taskflow.user.v1.ListAPIKeysResponse.api_keys:type_name -> taskflow.models.v1.APIKey
+	41, // 16: taskflow.user.v1.VerifyAPIKeyResponse.api_key:type_name -> taskflow.models.v1.APIKey
+	0,  // 17: taskflow.user.v1.UserService.CreateUser:input_type -> taskflow.user.v1.CreateUserRequest
+	2,  // 18: taskflow.user.v1.UserService.Authenticate:input_type -> taskflow.user.v1.AuthenticateRequest
+	4,  // 19: taskflow.user.v1.UserService.GetUser:input_type -> taskflow.user.v1.GetUserRequest
+	6,  // 20: taskflow.user.v1.UserService.ListUsers:input_type -> taskflow.user.v1.ListUsersRequest
+	8,  // 21: taskflow.user.v1.UserService.UpdateUser:input_type -> taskflow.user.v1.UpdateUserRequest
+	10, // 22: taskflow.user.v1.UserService.CreateTeam:input_type -> taskflow.user.v1.CreateTeamRequest
+	12, // 23: taskflow.user.v1.UserService.GetTeam:input_type -> taskflow.user.v1.GetTeamRequest
+	14, // 24: taskflow.user.v1.UserService.ListTeams:input_type -> taskflow.user.v1.ListTeamsRequest
+	16, // 25: taskflow.user.v1.UserService.UpdateTeam:input_type -> taskflow.user.v1.UpdateTeamRequest
+	18, // 26: taskflow.user.v1.UserService.AddTeamMember:input_type -> taskflow.user.v1.AddTeamMemberRequest
+	20, // 27: taskflow.user.v1.UserService.GetTeamMembers:input_type -> taskflow.user.v1.GetTeamMembersRequest
+	24, // 28: taskflow.user.v1.UserService.ListUserMemberships:input_type -> taskflow.user.v1.ListUserMembershipsRequest
+	22, // 29: taskflow.user.v1.UserService.RemoveTeamMember:input_type -> taskflow.user.v1.RemoveTeamMemberRequest
+	26, // 30: taskflow.user.v1.UserService.CreateServiceAccount:input_type -> taskflow.user.v1.CreateServiceAccountRequest
+	28, // 31: taskflow.user.v1.UserService.ListServiceAccounts:input_type -> taskflow.user.v1.ListServiceAccountsRequest
+	30, // 32: taskflow.user.v1.UserService.CreateAPIKey:input_type -> taskflow.user.v1.CreateAPIKeyRequest
+	32, // 33: taskflow.user.v1.UserService.ListAPIKeys:input_type -> taskflow.user.v1.ListAPIKeysRequest
+	34, // 34: taskflow.user.v1.UserService.RevokeAPIKey:input_type -> taskflow.user.v1.RevokeAPIKeyRequest
+	36, // 35: taskflow.user.v1.UserService.VerifyAPIKey:input_type -> taskflow.user.v1.VerifyAPIKeyRequest
+	1,  // 36: taskflow.user.v1.UserService.CreateUser:output_type -> taskflow.user.v1.CreateUserResponse
+	3,  // 37: taskflow.user.v1.UserService.Authenticate:output_type -> taskflow.user.v1.AuthenticateResponse
+	5,  // 38: taskflow.user.v1.UserService.GetUser:output_type -> taskflow.user.v1.GetUserResponse
+	7,  // 39: taskflow.user.v1.UserService.ListUsers:output_type -> taskflow.user.v1.ListUsersResponse
+	9,  // 40: taskflow.user.v1.UserService.UpdateUser:output_type -> taskflow.user.v1.UpdateUserResponse
+	11, // 41: taskflow.user.v1.UserService.CreateTeam:output_type -> taskflow.user.v1.CreateTeamResponse
+	13, // 42: taskflow.user.v1.UserService.GetTeam:output_type -> taskflow.user.v1.GetTeamResponse
+	15, // 43: taskflow.user.v1.UserService.ListTeams:output_type -> taskflow.user.v1.ListTeamsResponse
+	17, // 44: taskflow.user.v1.UserService.UpdateTeam:output_type -> taskflow.user.v1.UpdateTeamResponse
+	19, // 45: taskflow.user.v1.UserService.AddTeamMember:output_type -> taskflow.user.v1.AddTeamMemberResponse
+	21, // 46: taskflow.user.v1.UserService.GetTeamMembers:output_type -> taskflow.user.v1.GetTeamMembersResponse
+	25, // 47: taskflow.user.v1.UserService.ListUserMemberships:output_type -> taskflow.user.v1.ListUserMembershipsResponse
+	23, // 48: taskflow.user.v1.UserService.RemoveTeamMember:output_type -> taskflow.user.v1.RemoveTeamMemberResponse
+	27, // 49: taskflow.user.v1.UserService.CreateServiceAccount:output_type -> taskflow.user.v1.CreateServiceAccountResponse
+	29, // 50: taskflow.user.v1.UserService.ListServiceAccounts:output_type -> taskflow.user.v1.ListServiceAccountsResponse
+	31, // 51: taskflow.user.v1.UserService.CreateAPIKey:output_type -> taskflow.user.v1.CreateAPIKeyResponse
+	33, // 52: taskflow.user.v1.UserService.ListAPIKeys:output_type -> taskflow.user.v1.ListAPIKeysResponse
+	35, // 53: taskflow.user.v1.UserService.RevokeAPIKey:output_type -> taskflow.user.v1.RevokeAPIKeyResponse
+	37, // 54: taskflow.user.v1.UserService.VerifyAPIKey:output_type -> taskflow.user.v1.VerifyAPIKeyResponse
+	36, // [36:55] is the sub-list for method output_type
+	17, // [17:36] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_user_api_user_proto_init() }
@@ -810,7 +2052,7 @@ func file_user_api_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_api_user_proto_rawDesc), len(file_user_api_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -2,46 +2,134 @@ package bootstrap
 
 import (
 	"context"
+	"net/http"
+	"time"
 
+	apievents "github.com/Sol1tud9/taskflow/api/events"
 	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/pb/task_api"
+	"github.com/Sol1tud9/taskflow/internal/task/directory"
 	"github.com/Sol1tud9/taskflow/internal/task/publisher"
+	"github.com/Sol1tud9/taskflow/internal/task/server"
 	"github.com/Sol1tud9/taskflow/internal/task/storage/postgres"
 	"github.com/Sol1tud9/taskflow/internal/task/usecase"
+	"github.com/Sol1tud9/taskflow/pkg/actor"
 	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/events"
+	"github.com/Sol1tud9/taskflow/pkg/grpcserver"
 	"github.com/Sol1tud9/taskflow/pkg/logger"
+	"github.com/Sol1tud9/taskflow/pkg/metrics"
+	"github.com/Sol1tud9/taskflow/pkg/outbox"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
+const httpShutdownTimeout = 10 * time.Second
+
 type App struct {
-	Config    *config.TaskServiceConfig
-	Storage   *postgres.Storage
-	Publisher *publisher.Publisher
-	TaskUC    *usecase.TaskUseCase
+	Config     *config.TaskServiceConfig
+	Storage    *postgres.Storage
+	Sender     *outbox.KafkaSender
+	Relay      *outbox.Relay
+	Directory  *directory.Client
+	TaskUC     *usecase.TaskUseCase
+	GRPCServer *grpcserver.Server
+	// MetricsServer serves /metrics on the HTTP port.
+	MetricsServer *http.Server
 }
 
 func NewApp(cfg *config.TaskServiceConfig) (*App, error) {
+	registry, err := events.NewRegistry(apievents.Schemas)
+	if err != nil {
+		logger.Error("failed to load event schemas", zap.Error(err))
+		return nil, err
+	}
+
 	storage, err := postgres.NewStorage(cfg.Database)
 	if err != nil {
 		logger.Error("failed to init storage", zap.Error(err))
 		return nil, err
 	}
+	if err := metrics.RegisterPool(storage.Pool(), cfg.Database.Name, ""); err != nil {
+		logger.Error("failed to register pool metrics", zap.Error(err))
+		return nil, err
+	}
+
+	outboxStore := outbox.NewStore(storage.Pool())
+	pub := publisher.NewPublisher(outboxStore, events.NewEncoder(registry, cfg.App.Name), cfg.Kafka.Topics)
 
-	pub := publisher.NewPublisher(cfg.Kafka.Brokers, cfg.Kafka.Topics)
+	sender := outbox.NewKafkaSender(cfg.Kafka.Brokers, cfg.Kafka.Topics)
+	relay := outbox.NewRelay(cfg.App.Name, outboxStore, sender, cfg.Outbox)
+
+	workflows, err := newWorkflows(cfg.Workflow)
+	if err != nil {
+		logger.Error("invalid task workflow", zap.Error(err))
+		return nil, err
+	}
+
+	teams, err := directory.New(cfg.UserService)
+	if err != nil {
+		logger.Error("failed to init team directory", zap.Error(err))
+		return nil, err
+	}
 
 	historyRepoAdapter := &historyRepoAdapter{storage: storage}
-	taskUC := usecase.NewTaskUseCase(storage, historyRepoAdapter, pub)
+	taskUC := usecase.NewTaskUseCase(storage, historyRepoAdapter, pub, storage, workflows, teams)
+
+	grpcServer := grpcserver.New(cfg.Server.GRPCPort, grpc.ChainUnaryInterceptor(
+		events.UnaryServerInterceptor(),
+		actor.UnaryServerInterceptor(),
+	))
+	grpcServer.RegisterService(&task_api.TaskService_ServiceDesc, server.NewServer(taskUC))
 
 	return &App{
-		Config:    cfg,
-		Storage:   storage,
-		Publisher: pub,
-		TaskUC:    taskUC,
+		Config:        cfg,
+		Storage:       storage,
+		Sender:        sender,
+		Relay:         relay,
+		Directory:     teams,
+		TaskUC:        taskUC,
+		GRPCServer:    grpcServer,
+		MetricsServer: metrics.NewServer(cfg.Server.HTTPPort),
 	}, nil
 }
 
 func (a *App) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+	defer cancel()
+	if err := a.MetricsServer.Shutdown(ctx); err != nil {
+		logger.Error("failed to shutdown metrics server", zap.Error(err))
+	}
+
+	a.GRPCServer.Stop()
 	a.Storage.Close()
-	_ = a.Publisher.Close()
+	_ = a.Sender.Close()
+	_ = a.Directory.Close()
+}
+
+func newWorkflows(cfg config.TaskWorkflowsConfig) (usecase.Workflows, error) {
+	workflows := usecase.Workflows{
+		Default: toWorkflow(cfg.Default),
+		Teams:   make(map[string]domain.Workflow, len(cfg.Teams)),
+	}
+	for teamID, wf := range cfg.Teams {
+		workflows.Teams[teamID] = toWorkflow(wf)
+	}
+	return workflows, workflows.Validate()
+}
+
+func toWorkflow(cfg config.WorkflowConfig) domain.Workflow {
+	if cfg == nil {
+		return nil
+	}
+
+	wf := make(domain.Workflow, len(cfg))
+	for from, targets := range cfg {
+		for _, to := range targets {
+			wf[domain.TaskStatus(from)] = append(wf[domain.TaskStatus(from)], domain.TaskStatus(to))
+		}
+	}
+	return wf
 }
 
 type historyRepoAdapter struct {
@@ -55,4 +143,3 @@ func (a *historyRepoAdapter) Create(ctx context.Context, history *domain.TaskHis
 func (a *historyRepoAdapter) GetByTaskID(ctx context.Context, taskID string) ([]*domain.TaskHistory, error) {
 	return a.storage.GetHistoryByTaskID(ctx, taskID)
 }
-
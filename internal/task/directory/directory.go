@@ -0,0 +1,66 @@
+// Package directory looks up team memberships in user-service, which owns
+// them, so task-service can authorize requests by team role.
+package directory
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/pb/user_api"
+	"github.com/Sol1tud9/taskflow/pkg/actor"
+	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/events"
+)
+
+const defaultTimeout = 5 * time.Second
+
+type Client struct {
+	conn    *grpc.ClientConn
+	client  user_api.UserServiceClient
+	timeout time.Duration
+}
+
+// New connects to user-service at endpoint. The acting user and the
+// request's correlation ID are forwarded with every call.
+func New(endpoint config.ServiceEndpoint) (*Client, error) {
+	timeout := time.Duration(endpoint.TimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	conn, err := grpc.NewClient(endpoint.GRPCAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(events.UnaryClientInterceptor(), actor.UnaryClientInterceptor()),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to user service")
+	}
+
+	return &Client{conn: conn, client: user_api.NewUserServiceClient(conn), timeout: timeout}, nil
+}
+
+// Memberships returns userID's role in each of their teams, keyed by team ID.
+func (c *Client) Memberships(ctx context.Context, userID string) (map[string]domain.TeamRole, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.client.ListUserMemberships(ctx, &user_api.ListUserMembershipsRequest{UserId: userID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list team memberships")
+	}
+
+	memberships := make(map[string]domain.TeamRole, len(resp.GetMemberships()))
+	for _, m := range resp.GetMemberships() {
+		memberships[m.GetTeamId()] = domain.TeamRole(m.GetRole())
+	}
+	return memberships, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
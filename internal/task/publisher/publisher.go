@@ -4,32 +4,41 @@ import (
 	"context"
 
 	"github.com/Sol1tud9/taskflow/internal/domain"
-	"github.com/Sol1tud9/taskflow/pkg/kafka"
+	"github.com/Sol1tud9/taskflow/pkg/events"
+	"github.com/Sol1tud9/taskflow/pkg/outbox"
 )
 
+// Publisher records task events in the outbox; the relay delivers them to Kafka.
 type Publisher struct {
-	taskCreatedProducer *kafka.Producer
-	taskUpdatedProducer *kafka.Producer
+	outbox  *outbox.Store
+	encoder *events.Encoder
+	topics  map[string]string
 }
 
-func NewPublisher(brokers []string, topics map[string]string) *Publisher {
+func NewPublisher(store *outbox.Store, encoder *events.Encoder, topics map[string]string) *Publisher {
 	return &Publisher{
-		taskCreatedProducer: kafka.NewProducer(brokers, topics["task_created"]),
-		taskUpdatedProducer: kafka.NewProducer(brokers, topics["task_updated"]),
+		outbox:  store,
+		encoder: encoder,
+		topics:  topics,
 	}
 }
 
 func (p *Publisher) PublishTaskCreated(ctx context.Context, event domain.TaskCreatedEvent) error {
-	return p.taskCreatedProducer.Publish(ctx, event.TaskID, event)
+	return p.publish(ctx, "task_created", domain.EventTypeTaskCreated, event.TaskID, event.EventID, event)
 }
 
 func (p *Publisher) PublishTaskUpdated(ctx context.Context, event domain.TaskUpdatedEvent) error {
-	return p.taskUpdatedProducer.Publish(ctx, event.TaskID, event)
+	return p.publish(ctx, "task_updated", domain.EventTypeTaskUpdated, event.TaskID, event.EventID, event)
 }
 
-func (p *Publisher) Close() error {
-	_ = p.taskCreatedProducer.Close()
-	_ = p.taskUpdatedProducer.Close()
-	return nil
+func (p *Publisher) PublishTaskDeleted(ctx context.Context, event domain.TaskDeletedEvent) error {
+	return p.publish(ctx, "task_deleted", domain.EventTypeTaskDeleted, event.TaskID, event.EventID, event)
 }
 
+func (p *Publisher) publish(ctx context.Context, topic, eventType, key, eventID string, event interface{}) error {
+	envelope, err := p.encoder.Encode(ctx, eventID, eventType, event)
+	if err != nil {
+		return err
+	}
+	return p.outbox.Add(ctx, p.topics[topic], key, envelope)
+}
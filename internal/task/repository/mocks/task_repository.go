@@ -32,12 +32,20 @@ func (m *TaskRepository) GetByID(ctx context.Context, id string) (*domain.Task,
 	return args.Get(0).(*domain.Task), args.Error(1)
 }
 
-func (m *TaskRepository) List(ctx context.Context, filter taskUsecase.TaskFilter) ([]*domain.Task, int, error) {
+func (m *TaskRepository) List(ctx context.Context, filter taskUsecase.TaskFilter) (*domain.TaskPage, error) {
 	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaskPage), args.Error(1)
+}
+
+func (m *TaskRepository) Search(ctx context.Context, query string, filter taskUsecase.TaskFilter) ([]*domain.TaskSearchHit, int, error) {
+	args := m.Called(ctx, query, filter)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(int), args.Error(2)
 	}
-	return args.Get(0).([]*domain.Task), args.Get(1).(int), args.Error(2)
+	return args.Get(0).([]*domain.TaskSearchHit), args.Get(1).(int), args.Error(2)
 }
 
 func (m *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/pb/models"
+	"github.com/Sol1tud9/taskflow/internal/pb/task_api"
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func toTaskPB(t *domain.Task) *models.Task {
+	return &models.Task{
+		Id:          t.ID,
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      string(t.Status),
+		Priority:    string(t.Priority),
+		AssigneeId:  t.AssigneeID,
+		CreatorId:   t.CreatorID,
+		TeamId:      t.TeamID,
+		DueDate:     toUnix(t.DueDate),
+		CreatedAt:   toUnix(t.CreatedAt),
+		UpdatedAt:   toUnix(t.UpdatedAt),
+		Version:     t.Version,
+	}
+}
+
+func toTasksPB(tasks []*domain.Task) []*models.Task {
+	result := make([]*models.Task, 0, len(tasks))
+	for _, t := range tasks {
+		result = append(result, toTaskPB(t))
+	}
+	return result
+}
+
+func toSearchHitsPB(hits []*domain.TaskSearchHit) []*task_api.TaskSearchHit {
+	result := make([]*task_api.TaskSearchHit, 0, len(hits))
+	for _, h := range hits {
+		result = append(result, &task_api.TaskSearchHit{
+			Task:                 toTaskPB(h.Task),
+			Rank:                 h.Rank,
+			TitleHighlight:       h.Highlights.Title,
+			DescriptionHighlight: h.Highlights.Description,
+		})
+	}
+	return result
+}
+
+func toHistoryPB(history []*domain.TaskHistory) []*models.TaskHistory {
+	result := make([]*models.TaskHistory, 0, len(history))
+	for _, h := range history {
+		result = append(result, &models.TaskHistory{
+			Id:        h.ID,
+			TaskId:    h.TaskID,
+			UserId:    h.UserID,
+			Field:     h.Field,
+			OldValue:  h.OldValue,
+			NewValue:  h.NewValue,
+			ChangedAt: toUnix(h.ChangedAt),
+		})
+	}
+	return result
+}
+
+// statuses merges the single status field older clients send into the list.
+func statuses(status string, list []string) []string {
+	if status == "" {
+		return list
+	}
+	return append([]string{status}, list...)
+}
+
+func toUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func toStatus(err error) error {
+	var (
+		validationErr *domain.ValidationError
+		transitionErr *domain.TransitionError
+	)
+
+	switch {
+	case errors.As(err, &validationErr):
+		return withFieldViolations(codes.InvalidArgument, err, validationErr.Fields)
+	case errors.As(err, &transitionErr):
+		return withFieldViolations(codes.FailedPrecondition, err, []domain.FieldError{
+			{Field: "status", Message: transitionErr.Error()},
+		})
+	case errors.Is(err, domain.ErrInvalidCursor):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, domain.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// withFieldViolations attaches the rejected fields as a BadRequest detail,
+// which the gateway turns into field-level errors.
+func withFieldViolations(code codes.Code, err error, fields []domain.FieldError) error {
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(fields))
+	for _, f := range fields {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: f.Field, Description: f.Message})
+	}
+
+	st, detailErr := status.New(code, err.Error()).WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if detailErr != nil {
+		return status.Error(code, err.Error())
+	}
+	return st.Err()
+}
@@ -0,0 +1,191 @@
+package server
+
+import (
+	"context"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/pb/task_api"
+	"github.com/Sol1tud9/taskflow/internal/task/usecase"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultListLimit = 20
+
+type TaskUseCase interface {
+	CreateTask(ctx context.Context, input usecase.CreateTaskInput) (*domain.Task, error)
+	GetTask(ctx context.Context, id string) (*domain.Task, error)
+	ListTasks(ctx context.Context, filter usecase.TaskFilter) (*domain.TaskPage, error)
+	SearchTasks(ctx context.Context, query string, filter usecase.TaskFilter) ([]*domain.TaskSearchHit, int, error)
+	UpdateTask(ctx context.Context, id string, input usecase.UpdateTaskInput) (*domain.Task, error)
+	DeleteTask(ctx context.Context, id string) error
+	GetTaskHistory(ctx context.Context, taskID string) ([]*domain.TaskHistory, error)
+}
+
+type Server struct {
+	task_api.UnimplementedTaskServiceServer
+	taskUC TaskUseCase
+}
+
+func NewServer(taskUC TaskUseCase) *Server {
+	return &Server{
+		taskUC: taskUC,
+	}
+}
+
+func (s *Server) CreateTask(ctx context.Context, req *task_api.CreateTaskRequest) (*task_api.CreateTaskResponse, error) {
+	if req.GetTitle() == "" {
+		return nil, status.Error(codes.InvalidArgument, "title is required")
+	}
+	if req.GetCreatorId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "creator_id is required")
+	}
+
+	task, err := s.taskUC.CreateTask(ctx, usecase.CreateTaskInput{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		Priority:    req.GetPriority(),
+		AssigneeID:  req.GetAssigneeId(),
+		CreatorID:   req.GetCreatorId(),
+		TeamID:      req.GetTeamId(),
+		DueDate:     req.GetDueDate(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &task_api.CreateTaskResponse{Task: toTaskPB(task)}, nil
+}
+
+func (s *Server) GetTask(ctx context.Context, req *task_api.GetTaskRequest) (*task_api.GetTaskResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	task, err := s.taskUC.GetTask(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &task_api.GetTaskResponse{Task: toTaskPB(task)}, nil
+}
+
+func (s *Server) ListTasks(ctx context.Context, req *task_api.ListTasksRequest) (*task_api.ListTasksResponse, error) {
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+
+	page, err := s.taskUC.ListTasks(ctx, usecase.TaskFilter{
+		TeamID:      req.GetTeamId(),
+		AssigneeID:  req.GetAssigneeId(),
+		CreatorID:   req.GetCreatorId(),
+		Statuses:    statuses(req.GetStatus(), req.GetStatuses()),
+		Priority:    req.GetPriority(),
+		DueFrom:     req.GetDueFrom(),
+		DueTo:       req.GetDueTo(),
+		CreatedFrom: req.GetCreatedFrom(),
+		CreatedTo:   req.GetCreatedTo(),
+		UpdatedFrom: req.GetUpdatedFrom(),
+		UpdatedTo:   req.GetUpdatedTo(),
+		Overdue:     req.GetOverdue(),
+		Sort:        req.GetSort(),
+		Limit:       limit,
+		Offset:      int(req.GetOffset()),
+		Cursor:      req.GetCursor(),
+		Count:       usecase.CountMode(req.GetCount()),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &task_api.ListTasksResponse{
+		Tasks:      toTasksPB(page.Tasks),
+		NextCursor: page.NextCursor,
+	}
+	if page.Total != nil {
+		total := int32(*page.Total)
+		resp.Total = &total
+	}
+	return resp, nil
+}
+
+func (s *Server) SearchTasks(ctx context.Context, req *task_api.SearchTasksRequest) (*task_api.SearchTasksResponse, error) {
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+
+	hits, total, err := s.taskUC.SearchTasks(ctx, req.GetQuery(), usecase.TaskFilter{
+		TeamID:      req.GetTeamId(),
+		AssigneeID:  req.GetAssigneeId(),
+		CreatorID:   req.GetCreatorId(),
+		Statuses:    statuses(req.GetStatus(), req.GetStatuses()),
+		Priority:    req.GetPriority(),
+		DueFrom:     req.GetDueFrom(),
+		DueTo:       req.GetDueTo(),
+		CreatedFrom: req.GetCreatedFrom(),
+		CreatedTo:   req.GetCreatedTo(),
+		UpdatedFrom: req.GetUpdatedFrom(),
+		UpdatedTo:   req.GetUpdatedTo(),
+		Overdue:     req.GetOverdue(),
+		Limit:       limit,
+		Offset:      int(req.GetOffset()),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &task_api.SearchTasksResponse{
+		Hits:  toSearchHitsPB(hits),
+		Total: int32(total),
+	}, nil
+}
+
+func (s *Server) UpdateTask(ctx context.Context, req *task_api.UpdateTaskRequest) (*task_api.UpdateTaskResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	task, err := s.taskUC.UpdateTask(ctx, req.GetId(), usecase.UpdateTaskInput{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		Status:      req.GetStatus(),
+		Priority:    req.GetPriority(),
+		AssigneeID:  req.GetAssigneeId(),
+		DueDate:     req.GetDueDate(),
+		UserID:      req.GetUserId(),
+
+		ExpectedVersion: req.GetExpectedVersion(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &task_api.UpdateTaskResponse{Task: toTaskPB(task)}, nil
+}
+
+func (s *Server) DeleteTask(ctx context.Context, req *task_api.DeleteTaskRequest) (*task_api.DeleteTaskResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	if err := s.taskUC.DeleteTask(ctx, req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &task_api.DeleteTaskResponse{Success: true}, nil
+}
+
+func (s *Server) GetTaskHistory(ctx context.Context, req *task_api.GetTaskHistoryRequest) (*task_api.GetTaskHistoryResponse, error) {
+	if req.GetTaskId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "task_id is required")
+	}
+
+	history, err := s.taskUC.GetTaskHistory(ctx, req.GetTaskId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &task_api.GetTaskHistoryResponse{History: toHistoryPB(history)}, nil
+}
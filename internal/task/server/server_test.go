@@ -0,0 +1,365 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/pb/task_api"
+	repoMocks "github.com/Sol1tud9/taskflow/internal/task/repository/mocks"
+	taskServer "github.com/Sol1tud9/taskflow/internal/task/server"
+	taskUsecase "github.com/Sol1tud9/taskflow/internal/task/usecase"
+	usecaseMocks "github.com/Sol1tud9/taskflow/internal/task/usecase/mocks"
+	"github.com/Sol1tud9/taskflow/pkg/actor"
+)
+
+const actorID = "actor-1"
+
+type TaskServerSuite struct {
+	suite.Suite
+	ctx             context.Context
+	taskRepo        *repoMocks.TaskRepository
+	taskHistoryRepo *repoMocks.TaskHistoryRepository
+	publisher       *usecaseMocks.EventPublisher
+	teams           *usecaseMocks.TeamDirectory
+	grpcServer      *grpc.Server
+	conn            *grpc.ClientConn
+	client          task_api.TaskServiceClient
+}
+
+func (s *TaskServerSuite) SetupTest() {
+	s.ctx = actor.WithUserID(context.Background(), actorID)
+	s.taskRepo = repoMocks.NewTaskRepository(s.T())
+	s.taskHistoryRepo = repoMocks.NewTaskHistoryRepository(s.T())
+	s.publisher = usecaseMocks.NewEventPublisher(s.T())
+	s.teams = usecaseMocks.NewTeamDirectory(s.T())
+	txManager := usecaseMocks.NewTxManager(s.T())
+	txManager.On("WithinTx", mock.Anything, mock.Anything).Return(runInTx)
+	taskUC := taskUsecase.NewTaskUseCase(s.taskRepo, s.taskHistoryRepo, s.publisher, txManager, taskUsecase.Workflows{}, s.teams)
+
+	lis := bufconn.Listen(1024 * 1024)
+	s.grpcServer = grpc.NewServer(grpc.ChainUnaryInterceptor(actor.UnaryServerInterceptor()))
+	task_api.RegisterTaskServiceServer(s.grpcServer, taskServer.NewServer(taskUC))
+	go func() {
+		_ = s.grpcServer.Serve(lis)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(actor.UnaryClientInterceptor()),
+	)
+	s.Require().NoError(err)
+	s.conn = conn
+	s.client = task_api.NewTaskServiceClient(conn)
+}
+
+// runInTx stands in for a real transaction by calling fn with the same context.
+func runInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// memberships gives the acting user the listed team roles.
+func (s *TaskServerSuite) memberships(roles map[string]domain.TeamRole) {
+	s.teams.On("Memberships", mock.Anything, actorID).Return(roles, nil)
+}
+
+func (s *TaskServerSuite) TearDownTest() {
+	_ = s.conn.Close()
+	s.grpcServer.Stop()
+}
+
+func (s *TaskServerSuite) TestCreateTask_Success() {
+	creatorID := uuid.New().String()
+	dueDate := time.Now().Add(24 * time.Hour).Unix()
+
+	s.taskRepo.On("Create", mock.Anything, mock.MatchedBy(func(t *domain.Task) bool {
+		return t.Title == "Test Task" && t.CreatorID == creatorID
+	})).Return(nil)
+	s.publisher.On("PublishTaskCreated", mock.Anything, mock.Anything).Return(nil)
+
+	resp, err := s.client.CreateTask(s.ctx, &task_api.CreateTaskRequest{
+		Title:     "Test Task",
+		Priority:  "high",
+		CreatorId: creatorID,
+		DueDate:   dueDate,
+	})
+
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), resp.GetTask().GetId())
+	assert.Equal(s.T(), "Test Task", resp.GetTask().GetTitle())
+	assert.Equal(s.T(), string(domain.TaskStatusTodo), resp.GetTask().GetStatus())
+	assert.Equal(s.T(), dueDate, resp.GetTask().GetDueDate())
+}
+
+func (s *TaskServerSuite) TestCreateTask_MissingTitle() {
+	_, err := s.client.CreateTask(s.ctx, &task_api.CreateTaskRequest{
+		CreatorId: uuid.New().String(),
+	})
+
+	assert.Equal(s.T(), codes.InvalidArgument, status.Code(err))
+}
+
+func (s *TaskServerSuite) TestGetTask_Success() {
+	taskID := uuid.New().String()
+	s.taskRepo.On("GetByID", mock.Anything, taskID).Return(&domain.Task{
+		ID:        taskID,
+		Title:     "Test Task",
+		Status:    domain.TaskStatusInProgress,
+		CreatorID: actorID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil)
+
+	resp, err := s.client.GetTask(s.ctx, &task_api.GetTaskRequest{Id: taskID})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), taskID, resp.GetTask().GetId())
+	assert.Equal(s.T(), string(domain.TaskStatusInProgress), resp.GetTask().GetStatus())
+	assert.Zero(s.T(), resp.GetTask().GetDueDate())
+}
+
+func (s *TaskServerSuite) TestGetTask_NotFound() {
+	taskID := uuid.New().String()
+	s.taskRepo.On("GetByID", mock.Anything, taskID).Return(nil, domain.ErrNotFound)
+
+	_, err := s.client.GetTask(s.ctx, &task_api.GetTaskRequest{Id: taskID})
+
+	assert.Equal(s.T(), codes.NotFound, status.Code(err))
+}
+
+func (s *TaskServerSuite) TestListTasks_DefaultLimit() {
+	teamID := uuid.New().String()
+	total := 2
+	s.memberships(map[string]domain.TeamRole{teamID: domain.RoleViewer})
+	s.taskRepo.On("List", mock.Anything, taskUsecase.TaskFilter{
+		TeamID: teamID,
+		Sort:   taskUsecase.DefaultSort,
+		Limit:  20,
+		Count:  taskUsecase.CountExact,
+	}).Return(&domain.TaskPage{
+		Tasks: []*domain.Task{{ID: uuid.New().String()}, {ID: uuid.New().String()}},
+		Total: &total,
+	}, nil)
+
+	resp, err := s.client.ListTasks(s.ctx, &task_api.ListTasksRequest{TeamId: teamID})
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), resp.GetTasks(), 2)
+	assert.Equal(s.T(), int32(2), resp.GetTotal())
+}
+
+func (s *TaskServerSuite) TestListTasks_MergesStatusesAndSkipsCount() {
+	teamID := uuid.New().String()
+	s.memberships(map[string]domain.TeamRole{teamID: domain.RoleMember})
+	s.taskRepo.On("List", mock.Anything, taskUsecase.TaskFilter{
+		Statuses:     []string{"todo", "in_progress", "done"},
+		Overdue:      true,
+		Sort:         "-due_date",
+		Limit:        20,
+		Cursor:       "next",
+		Count:        taskUsecase.CountNone,
+		VisibleTo:    actorID,
+		VisibleTeams: []string{teamID},
+	}).Return(&domain.TaskPage{
+		Tasks:      []*domain.Task{{ID: uuid.New().String()}},
+		NextCursor: "after",
+	}, nil)
+
+	resp, err := s.client.ListTasks(s.ctx, &task_api.ListTasksRequest{
+		Status:   "todo",
+		Statuses: []string{"in_progress", "done"},
+		Overdue:  true,
+		Sort:     "-due_date",
+		Cursor:   "next",
+		Count:    "none",
+	})
+
+	s.Require().NoError(err)
+	assert.Nil(s.T(), resp.Total)
+	assert.Equal(s.T(), "after", resp.GetNextCursor())
+}
+
+func (s *TaskServerSuite) TestListTasks_InvalidCursor() {
+	s.memberships(map[string]domain.TeamRole{})
+	s.taskRepo.On("List", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("bad token: %w", domain.ErrInvalidCursor))
+
+	_, err := s.client.ListTasks(s.ctx, &task_api.ListTasksRequest{Cursor: "bad"})
+
+	assert.Equal(s.T(), codes.InvalidArgument, status.Code(err))
+}
+
+func (s *TaskServerSuite) TestSearchTasks_MapsHits() {
+	teamID := uuid.New().String()
+	taskID := uuid.New().String()
+	s.memberships(map[string]domain.TeamRole{teamID: domain.RoleViewer})
+
+	s.taskRepo.On("Search", mock.Anything, "deploy", taskUsecase.TaskFilter{
+		TeamID: teamID,
+		Sort:   taskUsecase.DefaultSort,
+		Limit:  20,
+		Count:  taskUsecase.CountExact,
+	}).Return([]*domain.TaskSearchHit{{
+		Task: &domain.Task{ID: taskID, Title: "Fix deploy"},
+		Rank: 0.6,
+		Highlights: domain.TaskHighlights{
+			Title: "Fix <mark>deploy</mark>",
+		},
+	}}, 1, nil)
+
+	resp, err := s.client.SearchTasks(s.ctx, &task_api.SearchTasksRequest{Query: "deploy", TeamId: teamID})
+
+	s.Require().NoError(err)
+	s.Require().Len(resp.GetHits(), 1)
+	assert.Equal(s.T(), taskID, resp.GetHits()[0].GetTask().GetId())
+	assert.Equal(s.T(), "Fix <mark>deploy</mark>", resp.GetHits()[0].GetTitleHighlight())
+	assert.InDelta(s.T(), 0.6, resp.GetHits()[0].GetRank(), 1e-9)
+	assert.Equal(s.T(), int32(1), resp.GetTotal())
+}
+
+func (s *TaskServerSuite) TestUpdateTask_Success() {
+	taskID := uuid.New().String()
+	userID := uuid.New().String()
+
+	s.taskRepo.On("GetByID", mock.Anything, taskID).Return(&domain.Task{
+		ID:        taskID,
+		Title:     "Old Title",
+		Status:    domain.TaskStatusInProgress,
+		CreatorID: actorID,
+	}, nil)
+	s.taskRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	s.taskHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		return h.UserID == userID
+	})).Return(nil)
+	s.publisher.On("PublishTaskUpdated", mock.Anything, mock.Anything).Return(nil)
+
+	resp, err := s.client.UpdateTask(s.ctx, &task_api.UpdateTaskRequest{
+		Id:     taskID,
+		Status: string(domain.TaskStatusDone),
+		UserId: userID,
+	})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), string(domain.TaskStatusDone), resp.GetTask().GetStatus())
+}
+
+func (s *TaskServerSuite) TestUpdateTask_VersionConflict() {
+	taskID := uuid.New().String()
+
+	s.taskRepo.On("GetByID", mock.Anything, taskID).Return(&domain.Task{
+		ID:      taskID,
+		Title:   "Old Title",
+		Status:    domain.TaskStatusTodo,
+		CreatorID: actorID,
+		Version:   4,
+	}, nil)
+
+	_, err := s.client.UpdateTask(s.ctx, &task_api.UpdateTaskRequest{
+		Id:              taskID,
+		Title:           "New Title",
+		ExpectedVersion: 3,
+	})
+
+	assert.Equal(s.T(), codes.Aborted, status.Code(err))
+	s.taskRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (s *TaskServerSuite) TestUpdateTask_UnknownStatus() {
+	_, err := s.client.UpdateTask(s.ctx, &task_api.UpdateTaskRequest{
+		Id:     uuid.New().String(),
+		Status: "blocked",
+	})
+
+	st := status.Convert(err)
+	assert.Equal(s.T(), codes.InvalidArgument, st.Code())
+	s.Require().Len(st.Details(), 1)
+	violations := st.Details()[0].(*errdetails.BadRequest).GetFieldViolations()
+	s.Require().Len(violations, 1)
+	assert.Equal(s.T(), "status", violations[0].GetField())
+}
+
+func (s *TaskServerSuite) TestUpdateTask_TransitionNotAllowed() {
+	taskID := uuid.New().String()
+
+	s.taskRepo.On("GetByID", mock.Anything, taskID).Return(&domain.Task{
+		ID:        taskID,
+		Status:    domain.TaskStatusCancelled,
+		CreatorID: actorID,
+	}, nil)
+
+	_, err := s.client.UpdateTask(s.ctx, &task_api.UpdateTaskRequest{
+		Id:     taskID,
+		Status: string(domain.TaskStatusTodo),
+	})
+
+	st := status.Convert(err)
+	assert.Equal(s.T(), codes.FailedPrecondition, st.Code())
+	s.Require().Len(st.Details(), 1)
+	assert.Equal(s.T(), "status", st.Details()[0].(*errdetails.BadRequest).GetFieldViolations()[0].GetField())
+}
+
+func (s *TaskServerSuite) TestDeleteTask_InternalError() {
+	taskID := uuid.New().String()
+	s.taskRepo.On("GetByID", mock.Anything, taskID).Return(&domain.Task{ID: taskID, CreatorID: actorID}, nil)
+	s.taskRepo.On("Delete", mock.Anything, taskID).Return(errors.New("connection refused"))
+
+	_, err := s.client.DeleteTask(s.ctx, &task_api.DeleteTaskRequest{Id: taskID})
+
+	assert.Equal(s.T(), codes.Internal, status.Code(err))
+}
+
+func (s *TaskServerSuite) TestGetTaskHistory_Success() {
+	taskID := uuid.New().String()
+	s.taskRepo.On("GetByID", mock.Anything, taskID).Return(&domain.Task{ID: taskID, CreatorID: actorID}, nil)
+	s.taskHistoryRepo.On("GetByTaskID", mock.Anything, taskID).Return([]*domain.TaskHistory{
+		{ID: uuid.New().String(), TaskID: taskID, Field: "status", OldValue: "todo", NewValue: "done", ChangedAt: time.Now()},
+	}, nil)
+
+	resp, err := s.client.GetTaskHistory(s.ctx, &task_api.GetTaskHistoryRequest{TaskId: taskID})
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), resp.GetHistory(), 1)
+	assert.Equal(s.T(), "status", resp.GetHistory()[0].GetField())
+}
+
+func (s *TaskServerSuite) TestDeleteTask_PermissionDenied() {
+	taskID := uuid.New().String()
+	teamID := uuid.New().String()
+	s.memberships(map[string]domain.TeamRole{teamID: domain.RoleMember})
+	s.taskRepo.On("GetByID", mock.Anything, taskID).Return(&domain.Task{ID: taskID, TeamID: teamID, CreatorID: uuid.New().String()}, nil)
+
+	_, err := s.client.DeleteTask(s.ctx, &task_api.DeleteTaskRequest{Id: taskID})
+
+	assert.Equal(s.T(), codes.PermissionDenied, status.Code(err))
+	s.taskRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (s *TaskServerSuite) TestGetTask_WithoutActor() {
+	taskID := uuid.New().String()
+	s.taskRepo.On("GetByID", mock.Anything, taskID).Return(&domain.Task{ID: taskID}, nil)
+
+	_, err := s.client.GetTask(context.Background(), &task_api.GetTaskRequest{Id: taskID})
+
+	assert.Equal(s.T(), codes.Unauthenticated, status.Code(err))
+}
+
+func TestTaskServerSuite(t *testing.T) {
+	suite.Run(t, new(TaskServerSuite))
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+)
+
+// sortColumn is how tasks are ordered by one of usecase.SortFields.
+type sortColumn struct {
+	// expr is the SQL the rows are ordered by.
+	expr string
+	// value is the task's value of expr, as stored in a cursor.
+	value func(t *domain.Task) any
+	// decode reads a cursor value back into the Go type compared with expr.
+	decode func(raw json.RawMessage) (any, error)
+}
+
+var sortColumns = map[string]sortColumn{
+	"created_at": {"created_at", func(t *domain.Task) any { return t.CreatedAt }, decodeAs[time.Time]},
+	"updated_at": {"updated_at", func(t *domain.Task) any { return t.UpdatedAt }, decodeAs[time.Time]},
+	"due_date":   {"due_date", func(t *domain.Task) any { return t.DueDate }, decodeAs[time.Time]},
+	"title":      {"title", func(t *domain.Task) any { return t.Title }, decodeAs[string]},
+	"priority": {
+		rankExpr("priority", domain.TaskPriorities),
+		func(t *domain.Task) any { return slices.Index(domain.TaskPriorities, t.Priority) },
+		decodeAs[int],
+	},
+	"status": {
+		rankExpr("status", domain.TaskStatuses),
+		func(t *domain.Task) any { return slices.Index(domain.TaskStatuses, t.Status) },
+		decodeAs[int],
+	},
+}
+
+// rankExpr orders an enum column by the position of its value in values
+// rather than alphabetically. Unknown values rank first, as slices.Index
+// does.
+func rankExpr[T ~string](column string, values []T) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "CASE %s", column)
+	for i, v := range values {
+		fmt.Fprintf(&b, " WHEN '%s' THEN %d", v, i)
+	}
+	b.WriteString(" ELSE -1 END")
+	return b.String()
+}
+
+func decodeAs[T any](raw json.RawMessage) (any, error) {
+	var v T
+	err := json.Unmarshal(raw, &v)
+	return v, err
+}
+
+// taskCursor is the decoded form of the page token: the sort it was issued
+// for and the sort value and id of the last task on the page.
+type taskCursor struct {
+	Sort  string          `json:"s"`
+	Value json.RawMessage `json:"v"`
+	ID    string          `json:"id"`
+
+	value any
+}
+
+func encodeTaskCursor(sort string, column sortColumn, last *domain.Task) string {
+	value, _ := json.Marshal(column.value(last))
+	data, _ := json.Marshal(taskCursor{Sort: sort, Value: value, ID: last.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeTaskCursor parses token, which must have been issued for the same
+// sort. An empty token yields nil.
+func decodeTaskCursor(token, sort string, column sortColumn) (*taskCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.Wrap(domain.ErrInvalidCursor, err.Error())
+	}
+
+	var c taskCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, errors.Wrap(domain.ErrInvalidCursor, err.Error())
+	}
+	if c.Sort != sort {
+		return nil, errors.Wrapf(domain.ErrInvalidCursor, "cursor was issued for sort %q", c.Sort)
+	}
+	if c.ID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidCursor, "no task id")
+	}
+
+	c.value, err = column.decode(c.Value)
+	if err != nil {
+		return nil, errors.Wrap(domain.ErrInvalidCursor, err.Error())
+	}
+
+	return &c, nil
+}
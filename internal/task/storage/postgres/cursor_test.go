@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/task/usecase"
+)
+
+type CursorSuite struct {
+	suite.Suite
+}
+
+func (s *CursorSuite) TestEverySortFieldHasColumn() {
+	for _, field := range usecase.SortFields {
+		_, ok := sortColumns[field]
+		s.True(ok, field)
+	}
+	s.Len(sortColumns, len(usecase.SortFields))
+}
+
+func (s *CursorSuite) TestRoundTripsTimeValue() {
+	created := time.Date(2026, 3, 14, 9, 26, 53, 589793000, time.UTC)
+	task := &domain.Task{ID: "task-1", CreatedAt: created}
+
+	token := encodeTaskCursor("-created_at", sortColumns["created_at"], task)
+	c, err := decodeTaskCursor(token, "-created_at", sortColumns["created_at"])
+
+	s.Require().NoError(err)
+	s.Equal("task-1", c.ID)
+	s.True(created.Equal(c.value.(time.Time)))
+}
+
+func (s *CursorSuite) TestRoundTripsPriorityRank() {
+	task := &domain.Task{ID: "task-1", Priority: domain.TaskPriorityHigh}
+
+	token := encodeTaskCursor("priority", sortColumns["priority"], task)
+	c, err := decodeTaskCursor(token, "priority", sortColumns["priority"])
+
+	s.Require().NoError(err)
+	s.Equal(2, c.value)
+}
+
+func (s *CursorSuite) TestRejectsCursorOfOtherSort() {
+	token := encodeTaskCursor("title", sortColumns["title"], &domain.Task{ID: "task-1", Title: "a"})
+
+	_, err := decodeTaskCursor(token, "-title", sortColumns["title"])
+
+	s.ErrorIs(err, domain.ErrInvalidCursor)
+}
+
+func (s *CursorSuite) TestRejectsMalformedCursor() {
+	for _, token := range []string{"not base64!", "bm90IGpzb24", "e30"} {
+		_, err := decodeTaskCursor(token, "title", sortColumns["title"])
+		s.ErrorIs(err, domain.ErrInvalidCursor, token)
+	}
+}
+
+func (s *CursorSuite) TestEmptyCursor() {
+	c, err := decodeTaskCursor("", "title", sortColumns["title"])
+
+	s.NoError(err)
+	s.Nil(c)
+}
+
+func (s *CursorSuite) TestRankExprFollowsEnumOrder() {
+	s.Equal(
+		"CASE priority WHEN 'low' THEN 0 WHEN 'medium' THEN 1 WHEN 'high' THEN 2 ELSE -1 END",
+		rankExpr("priority", domain.TaskPriorities),
+	)
+}
+
+func TestCursorSuite(t *testing.T) {
+	suite.Run(t, new(CursorSuite))
+}
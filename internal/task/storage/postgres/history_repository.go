@@ -19,7 +19,7 @@ func (s *Storage) CreateHistory(ctx context.Context, history *domain.TaskHistory
 		return errors.Wrap(err, "failed to build query")
 	}
 
-	if _, err := s.db.Exec(ctx, sql, args...); err != nil {
+	if _, err := s.conn(ctx).Exec(ctx, sql, args...); err != nil {
 		return errors.Wrap(err, "failed to create task history")
 	}
 
@@ -38,7 +38,7 @@ func (s *Storage) GetHistoryByTaskID(ctx context.Context, taskID string) ([]*dom
 		return nil, errors.Wrap(err, "failed to build query")
 	}
 
-	rows, err := s.db.Query(ctx, sql, args...)
+	rows, err := s.conn(ctx).Query(ctx, sql, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get task history")
 	}
@@ -2,8 +2,13 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
 	"github.com/pkg/errors"
 	"github.com/Sol1tud9/taskflow/internal/domain"
 	"github.com/Sol1tud9/taskflow/internal/task/usecase"
@@ -11,8 +16,8 @@ import (
 
 func (s *Storage) Create(ctx context.Context, task *domain.Task) error {
 	query := squirrel.Insert("tasks").
-		Columns("id", "title", "description", "status", "priority", "assignee_id", "creator_id", "team_id", "due_date", "created_at", "updated_at").
-		Values(task.ID, task.Title, task.Description, task.Status, task.Priority, task.AssigneeID, task.CreatorID, task.TeamID, task.DueDate, task.CreatedAt, task.UpdatedAt).
+		Columns("id", "title", "description", "status", "priority", "assignee_id", "creator_id", "team_id", "due_date", "created_at", "updated_at", "version").
+		Values(task.ID, task.Title, task.Description, task.Status, task.Priority, task.AssigneeID, task.CreatorID, task.TeamID, task.DueDate, task.CreatedAt, task.UpdatedAt, task.Version).
 		PlaceholderFormat(squirrel.Dollar)
 
 	sql, args, err := query.ToSql()
@@ -20,7 +25,7 @@ func (s *Storage) Create(ctx context.Context, task *domain.Task) error {
 		return errors.Wrap(err, "failed to build query")
 	}
 
-	if _, err := s.db.Exec(ctx, sql, args...); err != nil {
+	if _, err := s.conn(ctx).Exec(ctx, sql, args...); err != nil {
 		return errors.Wrap(err, "failed to create task")
 	}
 
@@ -28,7 +33,7 @@ func (s *Storage) Create(ctx context.Context, task *domain.Task) error {
 }
 
 func (s *Storage) GetByID(ctx context.Context, id string) (*domain.Task, error) {
-	query := squirrel.Select("id", "title", "description", "status", "priority", "assignee_id", "creator_id", "team_id", "due_date", "created_at", "updated_at").
+	query := squirrel.Select("id", "title", "description", "status", "priority", "assignee_id", "creator_id", "team_id", "due_date", "created_at", "updated_at", "version").
 		From("tasks").
 		Where(squirrel.Eq{"id": id}).
 		PlaceholderFormat(squirrel.Dollar)
@@ -39,11 +44,14 @@ func (s *Storage) GetByID(ctx context.Context, id string) (*domain.Task, error)
 	}
 
 	var task domain.Task
-	err = s.db.QueryRow(ctx, sql, args...).Scan(
+	err = s.conn(ctx).QueryRow(ctx, sql, args...).Scan(
 		&task.ID, &task.Title, &task.Description, &task.Status, &task.Priority,
 		&task.AssigneeID, &task.CreatorID, &task.TeamID, &task.DueDate,
-		&task.CreatedAt, &task.UpdatedAt,
+		&task.CreatedAt, &task.UpdatedAt, &task.Version,
 	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get task")
 	}
@@ -51,20 +59,134 @@ func (s *Storage) GetByID(ctx context.Context, id string) (*domain.Task, error)
 	return &task, nil
 }
 
-func (s *Storage) List(ctx context.Context, filter usecase.TaskFilter) ([]*domain.Task, int, error) {
-	query := squirrel.Select("id", "title", "description", "status", "priority", "assignee_id", "creator_id", "team_id", "due_date", "created_at", "updated_at").
+// List returns one page of the tasks matching filter in the order of
+// filter.Sort, with id breaking ties. A cursor continues right after the last
+// task of the previous page, so pages stay stable while tasks are added.
+func (s *Storage) List(ctx context.Context, filter usecase.TaskFilter) (*domain.TaskPage, error) {
+	field, desc := usecase.ParseSort(filter.Sort)
+	column, ok := sortColumns[field]
+	if !ok {
+		return nil, errors.Errorf("unknown sort field %q", field)
+	}
+
+	direction, compare := "ASC", ">"
+	if desc {
+		direction, compare = "DESC", "<"
+	}
+
+	query := squirrel.Select("id", "title", "description", "status", "priority", "assignee_id", "creator_id", "team_id", "due_date", "created_at", "updated_at", "version").
 		From("tasks").
+		OrderBy(column.expr+" "+direction, "id "+direction).
 		PlaceholderFormat(squirrel.Dollar)
+	query = applyTaskFilter(query, filter)
 
-	if filter.TeamID != "" {
-		query = query.Where(squirrel.Eq{"team_id": filter.TeamID})
+	after, err := decodeTaskCursor(filter.Cursor, filter.Sort, column)
+	if err != nil {
+		return nil, err
 	}
-	if filter.AssigneeID != "" {
-		query = query.Where(squirrel.Eq{"assignee_id": filter.AssigneeID})
+	if after != nil {
+		query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column.expr, compare), after.value, after.ID)
+	} else if filter.Offset > 0 {
+		query = query.Offset(uint64(filter.Offset))
 	}
-	if filter.Status != "" {
-		query = query.Where(squirrel.Eq{"status": filter.Status})
+
+	// One extra row tells whether there is a next page.
+	if filter.Limit > 0 {
+		query = query.Limit(uint64(filter.Limit + 1))
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	rows, err := s.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list tasks")
+	}
+	defer rows.Close()
+
+	tasks := make([]*domain.Task, 0, filter.Limit)
+	for rows.Next() {
+		var t domain.Task
+		if err := rows.Scan(
+			&t.ID, &t.Title, &t.Description, &t.Status, &t.Priority,
+			&t.AssigneeID, &t.CreatorID, &t.TeamID, &t.DueDate,
+			&t.CreatedAt, &t.UpdatedAt, &t.Version,
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to scan task")
+		}
+		tasks = append(tasks, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to list tasks")
+	}
+
+	page := &domain.TaskPage{Tasks: tasks}
+	if filter.Limit > 0 && len(tasks) > filter.Limit {
+		page.Tasks = tasks[:filter.Limit]
+		page.NextCursor = encodeTaskCursor(filter.Sort, column, page.Tasks[filter.Limit-1])
+	}
+
+	if filter.Count == usecase.CountNone {
+		return page, nil
+	}
+
+	countQuery := applyTaskFilter(squirrel.Select("COUNT(*)").From("tasks").PlaceholderFormat(squirrel.Dollar), filter)
+
+	countSQL, countArgs, err := countQuery.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build count query")
+	}
+
+	var total int
+	if err := s.conn(ctx).QueryRow(ctx, countSQL, countArgs...).Scan(&total); err != nil {
+		return nil, errors.Wrap(err, "failed to count tasks")
 	}
+	page.Total = &total
+
+	return page, nil
+}
+
+// searchQuery parses the search text once per query; websearch_to_tsquery
+// accepts any input, so user text never causes a syntax error.
+const searchQuery = `WITH search AS (SELECT websearch_to_tsquery('simple', ?) AS query)`
+
+// Titles are short, so they are highlighted whole; descriptions are cut to
+// the fragments around the matches. ts_headline marks matches with control
+// characters that markHighlights turns into <mark> tags once the task text
+// around them is HTML-escaped.
+const (
+	highlightStart = "\x02"
+	highlightStop  = "\x03"
+
+	titleHighlightOptions       = "HighlightAll=true, StartSel=" + highlightStart + ", StopSel=" + highlightStop
+	descriptionHighlightOptions = "MaxFragments=2, MinWords=5, MaxWords=20, StartSel=" + highlightStart + ", StopSel=" + highlightStop
+)
+
+var highlightTags = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// markHighlights makes a ts_headline snippet safe to render as HTML: the
+// task text is escaped and only the <mark> tags around matches are markup.
+func markHighlights(snippet string) string {
+	return highlightTags.Replace(html.EscapeString(snippet))
+}
+
+// Search returns the tasks whose title or description match text, best
+// matches first, along with the number of matching tasks.
+func (s *Storage) Search(ctx context.Context, text string, filter usecase.TaskFilter) ([]*domain.TaskSearchHit, int, error) {
+	query := squirrel.Select(
+		"id", "title", "description", "status", "priority", "assignee_id", "creator_id", "team_id", "due_date", "created_at", "updated_at", "version",
+		"ts_rank(search_vector, search.query) AS rank",
+		"ts_headline('simple', title, search.query, '"+titleHighlightOptions+"')",
+		"ts_headline('simple', coalesce(description, ''), search.query, '"+descriptionHighlightOptions+"')",
+	).
+		Prefix(searchQuery, text).
+		From("tasks, search").
+		Where("search_vector @@ search.query").
+		OrderBy("rank DESC", "created_at DESC", "id").
+		PlaceholderFormat(squirrel.Dollar)
+	query = applyTaskFilter(query, filter)
 
 	if filter.Limit > 0 {
 		query = query.Limit(uint64(filter.Limit))
@@ -78,49 +200,109 @@ func (s *Storage) List(ctx context.Context, filter usecase.TaskFilter) ([]*domai
 		return nil, 0, errors.Wrap(err, "failed to build query")
 	}
 
-	rows, err := s.db.Query(ctx, sql, args...)
+	rows, err := s.conn(ctx).Query(ctx, sql, args...)
 	if err != nil {
-		return nil, 0, errors.Wrap(err, "failed to list tasks")
+		return nil, 0, errors.Wrap(err, "failed to search tasks")
 	}
 	defer rows.Close()
 
-	var tasks []*domain.Task
+	var hits []*domain.TaskSearchHit
 	for rows.Next() {
-		var t domain.Task
+		var (
+			t   domain.Task
+			hit = domain.TaskSearchHit{Task: &t}
+		)
 		if err := rows.Scan(
 			&t.ID, &t.Title, &t.Description, &t.Status, &t.Priority,
 			&t.AssigneeID, &t.CreatorID, &t.TeamID, &t.DueDate,
-			&t.CreatedAt, &t.UpdatedAt,
+			&t.CreatedAt, &t.UpdatedAt, &t.Version,
+			&hit.Rank, &hit.Highlights.Title, &hit.Highlights.Description,
 		); err != nil {
 			return nil, 0, errors.Wrap(err, "failed to scan task")
 		}
-		tasks = append(tasks, &t)
-	}
-
-	countQuery := squirrel.Select("COUNT(*)").From("tasks").PlaceholderFormat(squirrel.Dollar)
-	if filter.TeamID != "" {
-		countQuery = countQuery.Where(squirrel.Eq{"team_id": filter.TeamID})
-	}
-	if filter.AssigneeID != "" {
-		countQuery = countQuery.Where(squirrel.Eq{"assignee_id": filter.AssigneeID})
+		hit.Highlights.Title = markHighlights(hit.Highlights.Title)
+		hit.Highlights.Description = markHighlights(hit.Highlights.Description)
+		hits = append(hits, &hit)
 	}
-	if filter.Status != "" {
-		countQuery = countQuery.Where(squirrel.Eq{"status": filter.Status})
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to search tasks")
 	}
 
+	countQuery := squirrel.Select("COUNT(*)").
+		Prefix(searchQuery, text).
+		From("tasks, search").
+		Where("search_vector @@ search.query").
+		PlaceholderFormat(squirrel.Dollar)
+	countQuery = applyTaskFilter(countQuery, filter)
+
 	countSQL, countArgs, err := countQuery.ToSql()
 	if err != nil {
 		return nil, 0, errors.Wrap(err, "failed to build count query")
 	}
 
 	var total int
-	if err := s.db.QueryRow(ctx, countSQL, countArgs...).Scan(&total); err != nil {
+	if err := s.conn(ctx).QueryRow(ctx, countSQL, countArgs...).Scan(&total); err != nil {
 		return nil, 0, errors.Wrap(err, "failed to count tasks")
 	}
 
-	return tasks, total, nil
+	return hits, total, nil
 }
 
+func applyTaskFilter(query squirrel.SelectBuilder, filter usecase.TaskFilter) squirrel.SelectBuilder {
+	if filter.TeamID != "" {
+		query = query.Where(squirrel.Eq{"team_id": filter.TeamID})
+	}
+	if filter.AssigneeID != "" {
+		query = query.Where(squirrel.Eq{"assignee_id": filter.AssigneeID})
+	}
+	if filter.CreatorID != "" {
+		query = query.Where(squirrel.Eq{"creator_id": filter.CreatorID})
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where(squirrel.Eq{"status": filter.Statuses})
+	}
+	if filter.Priority != "" {
+		query = query.Where(squirrel.Eq{"priority": filter.Priority})
+	}
+	if filter.VisibleTo != "" {
+		query = query.Where(squirrel.Or{
+			squirrel.Eq{"team_id": filter.VisibleTeams},
+			squirrel.And{
+				squirrel.Expr("COALESCE(team_id, '') = ''"),
+				squirrel.Or{
+					squirrel.Eq{"creator_id": filter.VisibleTo},
+					squirrel.Eq{"assignee_id": filter.VisibleTo},
+				},
+			},
+		})
+	}
+
+	query = applyRange(query, "due_date", filter.DueFrom, filter.DueTo)
+	query = applyRange(query, "created_at", filter.CreatedFrom, filter.CreatedTo)
+	query = applyRange(query, "updated_at", filter.UpdatedFrom, filter.UpdatedTo)
+
+	// Tasks created without a due date store the unix epoch.
+	if filter.Overdue {
+		query = query.Where(squirrel.Lt{"due_date": time.Now()}).
+			Where(squirrel.Gt{"due_date": time.Unix(0, 0)}).
+			Where(squirrel.NotEq{"status": []domain.TaskStatus{domain.TaskStatusDone, domain.TaskStatusCancelled}})
+	}
+
+	return query
+}
+
+func applyRange(query squirrel.SelectBuilder, column string, from, to int64) squirrel.SelectBuilder {
+	if from > 0 {
+		query = query.Where(squirrel.GtOrEq{column: time.Unix(from, 0)})
+	}
+	if to > 0 {
+		query = query.Where(squirrel.LtOrEq{column: time.Unix(to, 0)})
+	}
+	return query
+}
+
+// Update saves task only if the stored row is still at task.Version, and
+// advances task.Version on success.
 func (s *Storage) Update(ctx context.Context, task *domain.Task) error {
 	query := squirrel.Update("tasks").
 		Set("title", task.Title).
@@ -130,7 +312,9 @@ func (s *Storage) Update(ctx context.Context, task *domain.Task) error {
 		Set("assignee_id", task.AssigneeID).
 		Set("due_date", task.DueDate).
 		Set("updated_at", task.UpdatedAt).
-		Where(squirrel.Eq{"id": task.ID}).
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": task.ID, "version": task.Version}).
+		Suffix("RETURNING version").
 		PlaceholderFormat(squirrel.Dollar)
 
 	sql, args, err := query.ToSql()
@@ -138,10 +322,19 @@ func (s *Storage) Update(ctx context.Context, task *domain.Task) error {
 		return errors.Wrap(err, "failed to build query")
 	}
 
-	if _, err := s.db.Exec(ctx, sql, args...); err != nil {
+	var version int64
+	err = s.conn(ctx).QueryRow(ctx, sql, args...).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		if _, err := s.GetByID(ctx, task.ID); err != nil {
+			return err
+		}
+		return domain.ErrVersionConflict
+	}
+	if err != nil {
 		return errors.Wrap(err, "failed to update task")
 	}
 
+	task.Version = version
 	return nil
 }
 
@@ -155,9 +348,13 @@ func (s *Storage) Delete(ctx context.Context, id string) error {
 		return errors.Wrap(err, "failed to build query")
 	}
 
-	if _, err := s.db.Exec(ctx, sql, args...); err != nil {
+	tag, err := s.conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
 		return errors.Wrap(err, "failed to delete task")
 	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
 
 	return nil
 }
@@ -0,0 +1,15 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkHighlights(t *testing.T) {
+	snippet := `<img src=x onerror="alert(1)"> fix ` + highlightStart + "login" + highlightStop + " & logout"
+
+	got := markHighlights(snippet)
+
+	assert.Equal(t, `&lt;img src=x onerror=&#34;alert(1)&#34;&gt; fix <mark>login</mark> &amp; logout`, got)
+}
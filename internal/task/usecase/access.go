@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/pkg/actor"
+)
+
+// TeamDirectory reports the teams a user belongs to. It is backed by
+// user-service, which owns team membership.
+type TeamDirectory interface {
+	// Memberships returns the user's role in each of their teams, keyed by
+	// team ID.
+	Memberships(ctx context.Context, userID string) (map[string]domain.TeamRole, error)
+}
+
+func actorID(ctx context.Context) (string, error) {
+	userID, ok := actor.UserID(ctx)
+	if !ok {
+		return "", domain.ErrUnauthenticated
+	}
+	return userID, nil
+}
+
+// roleIn returns the acting user's role in teamID; non-members get an empty
+// role, which grants nothing.
+func (uc *TaskUseCase) roleIn(ctx context.Context, userID, teamID string) (domain.TeamRole, error) {
+	memberships, err := uc.teams.Memberships(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return memberships[teamID], nil
+}
+
+// authorizeTeam checks that the acting user's role in teamID grants perm.
+func (uc *TaskUseCase) authorizeTeam(ctx context.Context, teamID string, perm domain.Permission) error {
+	userID, err := actorID(ctx)
+	if err != nil {
+		return err
+	}
+	role, err := uc.roleIn(ctx, userID, teamID)
+	if err != nil {
+		return err
+	}
+	if !role.Can(perm) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// authorizeTask checks that the acting user may do perm with task. Team
+// tasks follow the user's team role, and members may delete the tasks they
+// created. Personal tasks are visible to and editable by their creator and
+// assignee; only the creator may delete them.
+func (uc *TaskUseCase) authorizeTask(ctx context.Context, task *domain.Task, perm domain.Permission) error {
+	userID, err := actorID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if task.TeamID == "" {
+		switch {
+		case perm == domain.PermissionDeleteTasks && task.CreatorID == userID:
+			return nil
+		case perm != domain.PermissionDeleteTasks && (task.CreatorID == userID || task.AssigneeID == userID):
+			return nil
+		}
+		return domain.ErrForbidden
+	}
+
+	role, err := uc.roleIn(ctx, userID, task.TeamID)
+	if err != nil {
+		return err
+	}
+	if role.Can(perm) {
+		return nil
+	}
+	if perm == domain.PermissionDeleteTasks && task.CreatorID == userID && role.Can(domain.PermissionEditTasks) {
+		return nil
+	}
+	return domain.ErrForbidden
+}
+
+// scopeFilter limits a listing to the tasks the acting user may see: the
+// requested team if the user can view its tasks, otherwise their teams'
+// tasks and their own personal tasks.
+func (uc *TaskUseCase) scopeFilter(ctx context.Context, filter *TaskFilter) error {
+	if filter.TeamID != "" {
+		return uc.authorizeTeam(ctx, filter.TeamID, domain.PermissionViewTasks)
+	}
+
+	userID, err := actorID(ctx)
+	if err != nil {
+		return err
+	}
+	memberships, err := uc.teams.Memberships(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	filter.VisibleTo = userID
+	filter.VisibleTeams = make([]string, 0, len(memberships))
+	for teamID, role := range memberships {
+		if role.Can(domain.PermissionViewTasks) {
+			filter.VisibleTeams = append(filter.VisibleTeams, teamID)
+		}
+	}
+	sort.Strings(filter.VisibleTeams)
+	return nil
+}
@@ -0,0 +1,214 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	repoMocks "github.com/Sol1tud9/taskflow/internal/task/repository/mocks"
+	taskUsecase "github.com/Sol1tud9/taskflow/internal/task/usecase"
+	usecaseMocks "github.com/Sol1tud9/taskflow/internal/task/usecase/mocks"
+	"github.com/Sol1tud9/taskflow/pkg/actor"
+)
+
+const otherUserID = "other-user"
+
+type AccessSuite struct {
+	suite.Suite
+	ctx    context.Context
+	teamID string
+}
+
+func (s *AccessSuite) SetupTest() {
+	s.ctx = actor.WithUserID(context.Background(), actorID)
+	s.teamID = uuid.New().String()
+}
+
+// newUseCase returns a use case over task in which the acting user has role
+// in s.teamID; an empty role makes them a non-member. Writes succeed.
+func (s *AccessSuite) newUseCase(task *domain.Task, role domain.TeamRole) (*taskUsecase.TaskUseCase, *repoMocks.TaskRepository) {
+	taskRepo := repoMocks.NewTaskRepository(s.T())
+	historyRepo := repoMocks.NewTaskHistoryRepository(s.T())
+	publisher := usecaseMocks.NewEventPublisher(s.T())
+	txManager := usecaseMocks.NewTxManager(s.T())
+	teams := usecaseMocks.NewTeamDirectory(s.T())
+
+	memberships := map[string]domain.TeamRole{}
+	if role != "" {
+		memberships[s.teamID] = role
+	}
+	teams.On("Memberships", mock.Anything, actorID).Return(memberships, nil).Maybe()
+	txManager.On("WithinTx", mock.Anything, mock.Anything).Return(runInTx).Maybe()
+	taskRepo.On("GetByID", mock.Anything, task.ID).Return(task, nil).Maybe()
+	taskRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+	taskRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Maybe()
+	taskRepo.On("Delete", mock.Anything, task.ID).Return(nil).Maybe()
+	taskRepo.On("List", mock.Anything, mock.Anything).Return(&domain.TaskPage{}, nil).Maybe()
+	historyRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+	historyRepo.On("GetByTaskID", mock.Anything, task.ID).Return([]*domain.TaskHistory{}, nil).Maybe()
+	publisher.On("PublishTaskCreated", mock.Anything, mock.Anything).Return(nil).Maybe()
+	publisher.On("PublishTaskUpdated", mock.Anything, mock.Anything).Return(nil).Maybe()
+	publisher.On("PublishTaskDeleted", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	return taskUsecase.NewTaskUseCase(taskRepo, historyRepo, publisher, txManager, taskUsecase.Workflows{}, teams), taskRepo
+}
+
+type taskOperation func(ctx context.Context, uc *taskUsecase.TaskUseCase, task *domain.Task) error
+
+var taskOperations = map[string]taskOperation{
+	"get": func(ctx context.Context, uc *taskUsecase.TaskUseCase, task *domain.Task) error {
+		_, err := uc.GetTask(ctx, task.ID)
+		return err
+	},
+	"history": func(ctx context.Context, uc *taskUsecase.TaskUseCase, task *domain.Task) error {
+		_, err := uc.GetTaskHistory(ctx, task.ID)
+		return err
+	},
+	"list team": func(ctx context.Context, uc *taskUsecase.TaskUseCase, task *domain.Task) error {
+		_, err := uc.ListTasks(ctx, taskUsecase.TaskFilter{TeamID: task.TeamID})
+		return err
+	},
+	"create": func(ctx context.Context, uc *taskUsecase.TaskUseCase, task *domain.Task) error {
+		_, err := uc.CreateTask(ctx, taskUsecase.CreateTaskInput{Title: "New", CreatorID: actorID, TeamID: task.TeamID})
+		return err
+	},
+	"update": func(ctx context.Context, uc *taskUsecase.TaskUseCase, task *domain.Task) error {
+		_, err := uc.UpdateTask(ctx, task.ID, taskUsecase.UpdateTaskInput{Title: "Renamed", UserID: actorID})
+		return err
+	},
+	"delete": func(ctx context.Context, uc *taskUsecase.TaskUseCase, task *domain.Task) error {
+		return uc.DeleteTask(ctx, task.ID)
+	},
+}
+
+func (s *AccessSuite) TestTeamTaskPermissionMatrix() {
+	const nonMember domain.TeamRole = ""
+
+	allowed := map[string][]domain.TeamRole{
+		"get":       {domain.RoleOwner, domain.RoleAdmin, domain.RoleMember, domain.RoleViewer},
+		"history":   {domain.RoleOwner, domain.RoleAdmin, domain.RoleMember, domain.RoleViewer},
+		"list team": {domain.RoleOwner, domain.RoleAdmin, domain.RoleMember, domain.RoleViewer},
+		"create":    {domain.RoleOwner, domain.RoleAdmin, domain.RoleMember},
+		"update":    {domain.RoleOwner, domain.RoleAdmin, domain.RoleMember},
+		"delete":    {domain.RoleOwner, domain.RoleAdmin},
+	}
+
+	for name, op := range taskOperations {
+		for _, role := range append(domain.TeamRoles, nonMember) {
+			s.Run(name+"/"+roleName(role), func() {
+				task := &domain.Task{ID: uuid.New().String(), Title: "Task", TeamID: s.teamID, CreatorID: otherUserID}
+				uc, _ := s.newUseCase(task, role)
+
+				err := op(s.ctx, uc, task)
+
+				if containsRole(allowed[name], role) {
+					assert.NoError(s.T(), err)
+				} else {
+					assert.ErrorIs(s.T(), err, domain.ErrForbidden)
+				}
+			})
+		}
+	}
+}
+
+func (s *AccessSuite) TestDeleteOwnTeamTask() {
+	tests := map[domain.TeamRole]error{
+		domain.RoleMember: nil,
+		domain.RoleViewer: domain.ErrForbidden,
+	}
+
+	for role, want := range tests {
+		s.Run(string(role), func() {
+			task := &domain.Task{ID: uuid.New().String(), TeamID: s.teamID, CreatorID: actorID}
+			uc, _ := s.newUseCase(task, role)
+
+			err := uc.DeleteTask(s.ctx, task.ID)
+
+			if want == nil {
+				assert.NoError(s.T(), err)
+			} else {
+				assert.ErrorIs(s.T(), err, want)
+			}
+		})
+	}
+}
+
+func (s *AccessSuite) TestPersonalTask() {
+	tests := []struct {
+		name      string
+		creatorID string
+		assignee  string
+		op        string
+		allowed   bool
+	}{
+		{"creator updates", actorID, "", "update", true},
+		{"creator deletes", actorID, "", "delete", true},
+		{"assignee views", otherUserID, actorID, "get", true},
+		{"assignee updates", otherUserID, actorID, "update", true},
+		{"assignee deletes", otherUserID, actorID, "delete", false},
+		{"stranger views", otherUserID, "", "get", false},
+		{"stranger updates", otherUserID, "", "update", false},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			task := &domain.Task{ID: uuid.New().String(), Title: "Task", CreatorID: tt.creatorID, AssigneeID: tt.assignee}
+			uc, _ := s.newUseCase(task, "")
+
+			err := taskOperations[tt.op](s.ctx, uc, task)
+
+			if tt.allowed {
+				assert.NoError(s.T(), err)
+			} else {
+				assert.ErrorIs(s.T(), err, domain.ErrForbidden)
+			}
+		})
+	}
+}
+
+func (s *AccessSuite) TestListTasks_ScopedToVisibleTeams() {
+	task := &domain.Task{ID: uuid.New().String()}
+	uc, taskRepo := s.newUseCase(task, domain.RoleViewer)
+
+	_, err := uc.ListTasks(s.ctx, taskUsecase.TaskFilter{})
+
+	s.Require().NoError(err)
+	taskRepo.AssertCalled(s.T(), "List", mock.Anything, mock.MatchedBy(func(f taskUsecase.TaskFilter) bool {
+		return f.VisibleTo == actorID && assert.ObjectsAreEqual([]string{s.teamID}, f.VisibleTeams)
+	}))
+}
+
+func (s *AccessSuite) TestWithoutActor() {
+	task := &domain.Task{ID: uuid.New().String(), CreatorID: actorID}
+	uc, _ := s.newUseCase(task, "")
+
+	for name, op := range taskOperations {
+		s.Run(name, func() {
+			assert.ErrorIs(s.T(), op(context.Background(), uc, task), domain.ErrUnauthenticated)
+		})
+	}
+}
+
+func roleName(role domain.TeamRole) string {
+	if role == "" {
+		return "non-member"
+	}
+	return string(role)
+}
+
+func containsRole(roles []domain.TeamRole, role domain.TeamRole) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAccessSuite(t *testing.T) {
+	suite.Run(t, new(AccessSuite))
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+)
+
+// SortFields are the task fields a listing can be sorted by.
+var SortFields = []string{"created_at", "updated_at", "due_date", "priority", "status", "title"}
+
+// DefaultSort lists the newest tasks first.
+const DefaultSort = "-created_at"
+
+// CountMode selects whether a listing counts all matching tasks, which costs
+// a second query.
+type CountMode string
+
+const (
+	CountExact CountMode = "exact"
+	CountNone  CountMode = "none"
+)
+
+// ParseSort splits a sort parameter into its field and direction.
+func ParseSort(sort string) (field string, desc bool) {
+	if sort == "" {
+		sort = DefaultSort
+	}
+	return strings.TrimPrefix(sort, "-"), strings.HasPrefix(sort, "-")
+}
+
+// validateFilter rejects unknown enum values and fills in the defaults of
+// Sort and Count.
+func validateFilter(filter *TaskFilter) error {
+	verr := &domain.ValidationError{}
+
+	for _, status := range filter.Statuses {
+		if !domain.TaskStatus(status).Valid() {
+			verr.Add("status", fmt.Sprintf("unknown status %q", status))
+		}
+	}
+	if filter.Priority != "" && !domain.TaskPriority(filter.Priority).Valid() {
+		verr.Add("priority", fmt.Sprintf("unknown priority %q", filter.Priority))
+	}
+
+	if filter.Sort == "" {
+		filter.Sort = DefaultSort
+	}
+	if field, _ := ParseSort(filter.Sort); !slices.Contains(SortFields, field) {
+		verr.Add("sort", fmt.Sprintf("cannot sort by %q; use one of %s", field, strings.Join(SortFields, ", ")))
+	}
+
+	switch filter.Count {
+	case "":
+		filter.Count = CountExact
+	case CountExact, CountNone:
+	default:
+		verr.Add("count", fmt.Sprintf("unknown count mode %q", filter.Count))
+	}
+
+	return verr.Err()
+}
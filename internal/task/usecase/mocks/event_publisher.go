@@ -28,3 +28,7 @@ func (m *EventPublisher) PublishTaskUpdated(ctx context.Context, event domain.Ta
 	return args.Error(0)
 }
 
+func (m *EventPublisher) PublishTaskDeleted(ctx context.Context, event domain.TaskDeletedEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
\ No newline at end of file
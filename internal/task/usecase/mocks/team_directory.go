@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/Sol1tud9/taskflow/internal/domain"
+)
+
+type TeamDirectory struct {
+	mock.Mock
+}
+
+func NewTeamDirectory(t testing.TB) *TeamDirectory {
+	mock := &TeamDirectory{}
+	mock.Mock.Test(t)
+	return mock
+}
+
+func (m *TeamDirectory) Memberships(ctx context.Context, userID string) (map[string]domain.TeamRole, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]domain.TeamRole), args.Error(1)
+}
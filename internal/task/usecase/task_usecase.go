@@ -2,19 +2,22 @@ package usecase
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/Sol1tud9/taskflow/internal/domain"
-	"github.com/Sol1tud9/taskflow/pkg/logger"
-	"go.uber.org/zap"
 )
 
 // TaskRepository and TaskHistoryRepository are defined at the point of use.
 type TaskRepository interface {
 	Create(ctx context.Context, task *domain.Task) error
 	GetByID(ctx context.Context, id string) (*domain.Task, error)
-	List(ctx context.Context, filter TaskFilter) ([]*domain.Task, int, error)
+	List(ctx context.Context, filter TaskFilter) (*domain.TaskPage, error)
+	Search(ctx context.Context, query string, filter TaskFilter) ([]*domain.TaskSearchHit, int, error)
+	// Update fails with domain.ErrVersionConflict unless the stored task is
+	// still at task.Version, and advances task.Version on success.
 	Update(ctx context.Context, task *domain.Task) error
 	Delete(ctx context.Context, id string) error
 }
@@ -28,35 +31,89 @@ type TaskHistoryRepository interface {
 type TaskFilter struct {
 	TeamID     string
 	AssigneeID string
-	Status     string
-	Limit      int
-	Offset     int
+	CreatorID  string
+	// Statuses matches tasks in any of the listed statuses.
+	Statuses []string
+	Priority string
+	// The ranges are unix seconds, inclusive at both ends; 0 leaves that end
+	// open.
+	DueFrom     int64
+	DueTo       int64
+	CreatedFrom int64
+	CreatedTo   int64
+	UpdatedFrom int64
+	UpdatedTo   int64
+	// Overdue keeps open tasks whose due date has passed.
+	Overdue bool
+	// Sort is one of SortFields, prefixed with "-" for descending order;
+	// empty means DefaultSort.
+	Sort   string
+	Limit  int
+	Offset int
+	// Cursor is the NextCursor of the previous page; it replaces Offset.
+	Cursor string
+	Count  CountMode
+	// VisibleTo, when set, keeps the tasks of VisibleTeams and the personal
+	// tasks VisibleTo created or is assigned. The use case fills both in from
+	// the caller's memberships.
+	VisibleTo    string
+	VisibleTeams []string
 }
 
 type EventPublisher interface {
 	PublishTaskCreated(ctx context.Context, event domain.TaskCreatedEvent) error
 	PublishTaskUpdated(ctx context.Context, event domain.TaskUpdatedEvent) error
+	PublishTaskDeleted(ctx context.Context, event domain.TaskDeletedEvent) error
+}
+
+// TxManager runs fn in a single database transaction. Repositories and the
+// outbox-backed publisher join it through the context passed to fn.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type TaskUseCase struct {
 	taskRepo        TaskRepository
 	taskHistoryRepo TaskHistoryRepository
 	publisher       EventPublisher
+	txManager       TxManager
+	workflows       Workflows
+	teams           TeamDirectory
 }
 
 func NewTaskUseCase(
 	taskRepo TaskRepository,
 	taskHistoryRepo TaskHistoryRepository,
 	publisher EventPublisher,
+	txManager TxManager,
+	workflows Workflows,
+	teams TeamDirectory,
 ) *TaskUseCase {
 	return &TaskUseCase{
 		taskRepo:        taskRepo,
 		taskHistoryRepo: taskHistoryRepo,
 		publisher:       publisher,
+		txManager:       txManager,
+		workflows:       workflows,
+		teams:           teams,
 	}
 }
 
 func (uc *TaskUseCase) CreateTask(ctx context.Context, input CreateTaskInput) (*domain.Task, error) {
+	if err := validateCreate(input); err != nil {
+		return nil, err
+	}
+	if input.TeamID != "" {
+		if err := uc.authorizeTeam(ctx, input.TeamID, domain.PermissionEditTasks); err != nil {
+			return nil, err
+		}
+	} else if _, err := actorID(ctx); err != nil {
+		return nil, err
+	}
+	if input.Priority == "" {
+		input.Priority = string(domain.TaskPriorityMedium)
+	}
+
 	now := time.Now()
 	task := &domain.Task{
 		ID:          uuid.New().String(),
@@ -70,22 +127,26 @@ func (uc *TaskUseCase) CreateTask(ctx context.Context, input CreateTaskInput) (*
 		DueDate:     time.Unix(input.DueDate, 0),
 		CreatedAt:   now,
 		UpdatedAt:   now,
+		Version:     1,
 	}
 
-	if err := uc.taskRepo.Create(ctx, task); err != nil {
-		return nil, err
-	}
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.taskRepo.Create(ctx, task); err != nil {
+			return err
+		}
 
-	event := domain.TaskCreatedEvent{
-		TaskID:     task.ID,
-		Title:      task.Title,
-		CreatorID:  task.CreatorID,
-		AssigneeID: task.AssigneeID,
-		TeamID:     task.TeamID,
-		CreatedAt:  task.CreatedAt,
-	}
-	if err := uc.publisher.PublishTaskCreated(ctx, event); err != nil {
-		logger.Error("failed to publish task.created event", zap.Error(err), zap.String("task_id", task.ID))
+		return uc.publisher.PublishTaskCreated(ctx, domain.TaskCreatedEvent{
+			EventID:    uuid.New().String(),
+			TaskID:     task.ID,
+			Title:      task.Title,
+			CreatorID:  task.CreatorID,
+			AssigneeID: task.AssigneeID,
+			TeamID:     task.TeamID,
+			CreatedAt:  task.CreatedAt,
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return task, nil
@@ -102,18 +163,64 @@ type CreateTaskInput struct {
 }
 
 func (uc *TaskUseCase) GetTask(ctx context.Context, id string) (*domain.Task, error) {
-	return uc.taskRepo.GetByID(ctx, id)
+	task, err := uc.taskRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.authorizeTask(ctx, task, domain.PermissionViewTasks); err != nil {
+		return nil, err
+	}
+	return task, nil
 }
 
-func (uc *TaskUseCase) ListTasks(ctx context.Context, filter TaskFilter) ([]*domain.Task, int, error) {
+func (uc *TaskUseCase) ListTasks(ctx context.Context, filter TaskFilter) (*domain.TaskPage, error) {
+	if err := validateFilter(&filter); err != nil {
+		return nil, err
+	}
+	if err := uc.scopeFilter(ctx, &filter); err != nil {
+		return nil, err
+	}
 	return uc.taskRepo.List(ctx, filter)
 }
 
+// SearchTasks runs a full-text search over task titles and descriptions,
+// narrowed by filter.
+func (uc *TaskUseCase) SearchTasks(ctx context.Context, query string, filter TaskFilter) ([]*domain.TaskSearchHit, int, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, 0, &domain.ValidationError{Fields: []domain.FieldError{{Field: "q", Message: "search query is required"}}}
+	}
+	if err := validateFilter(&filter); err != nil {
+		return nil, 0, err
+	}
+	if err := uc.scopeFilter(ctx, &filter); err != nil {
+		return nil, 0, err
+	}
+	return uc.taskRepo.Search(ctx, query, filter)
+}
+
+// UpdateTask applies input to the task it reads, so the update and its
+// history are only written if nobody changed the task in between; otherwise
+// it returns domain.ErrVersionConflict. input.ExpectedVersion extends the
+// check back to the version the caller last saw. Status changes must be
+// allowed by the workflow of the task's team.
 func (uc *TaskUseCase) UpdateTask(ctx context.Context, id string, input UpdateTaskInput) (*domain.Task, error) {
+	if err := validateUpdate(input); err != nil {
+		return nil, err
+	}
+
 	task, err := uc.taskRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := uc.authorizeTask(ctx, task, domain.PermissionEditTasks); err != nil {
+		return nil, err
+	}
+	if input.ExpectedVersion != 0 && input.ExpectedVersion != task.Version {
+		return nil, domain.ErrVersionConflict
+	}
+	if input.Status != "" && !uc.workflows.For(task.TeamID).Allows(task.Status, domain.TaskStatus(input.Status)) {
+		return nil, &domain.TransitionError{From: task.Status, To: domain.TaskStatus(input.Status)}
+	}
 
 	changes := make(map[string]struct {
 		old string
@@ -162,33 +269,53 @@ func (uc *TaskUseCase) UpdateTask(ctx context.Context, id string, input UpdateTa
 
 	task.UpdatedAt = time.Now()
 
-	if err := uc.taskRepo.Update(ctx, task); err != nil {
-		return nil, err
-	}
+	err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.taskRepo.Update(ctx, task); err != nil {
+			return err
+		}
 
-	for field, change := range changes {
-		history := &domain.TaskHistory{
-			ID:        uuid.New().String(),
-			TaskID:    task.ID,
-			UserID:    input.UserID,
-			Field:     field,
-			OldValue:  change.old,
-			NewValue:  change.new,
-			ChangedAt: task.UpdatedAt,
+		fields := make([]string, 0, len(changes))
+		for field := range changes {
+			fields = append(fields, field)
 		}
-		_ = uc.taskHistoryRepo.Create(ctx, history)
+		sort.Strings(fields)
 
 		event := domain.TaskUpdatedEvent{
+			EventID:   uuid.New().String(),
 			TaskID:    task.ID,
 			UserID:    input.UserID,
-			Field:     field,
-			OldValue:  change.old,
-			NewValue:  change.new,
 			UpdatedAt: task.UpdatedAt,
 		}
-		if err := uc.publisher.PublishTaskUpdated(ctx, event); err != nil {
-			logger.Error("failed to publish task.updated event", zap.Error(err), zap.String("task_id", task.ID))
+
+		for _, field := range fields {
+			change := changes[field]
+			history := &domain.TaskHistory{
+				ID:        uuid.New().String(),
+				TaskID:    task.ID,
+				UserID:    input.UserID,
+				Field:     field,
+				OldValue:  change.old,
+				NewValue:  change.new,
+				ChangedAt: task.UpdatedAt,
+			}
+			if err := uc.taskHistoryRepo.Create(ctx, history); err != nil {
+				return err
+			}
+
+			event.Changes = append(event.Changes, domain.TaskFieldChange{
+				Field:    field,
+				OldValue: change.old,
+				NewValue: change.new,
+			})
+		}
+
+		if len(event.Changes) == 0 {
+			return nil
 		}
+		return uc.publisher.PublishTaskUpdated(ctx, event)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return task, nil
@@ -202,13 +329,51 @@ type UpdateTaskInput struct {
 	AssigneeID  string
 	DueDate     int64
 	UserID      string
+	// ExpectedVersion, when non-zero, is the task version the caller based
+	// the update on.
+	ExpectedVersion int64
 }
 
+// DeleteTask removes the task; the deletion is attributed to the acting
+// user.
 func (uc *TaskUseCase) DeleteTask(ctx context.Context, id string) error {
-	return uc.taskRepo.Delete(ctx, id)
+	userID, err := actorID(ctx)
+	if err != nil {
+		return err
+	}
+
+	task, err := uc.taskRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := uc.authorizeTask(ctx, task, domain.PermissionDeleteTasks); err != nil {
+		return err
+	}
+
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.taskRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return uc.publisher.PublishTaskDeleted(ctx, domain.TaskDeletedEvent{
+			EventID:   uuid.New().String(),
+			TaskID:    task.ID,
+			UserID:    userID,
+			Title:     task.Title,
+			TeamID:    task.TeamID,
+			DeletedAt: time.Now(),
+		})
+	})
 }
 
 func (uc *TaskUseCase) GetTaskHistory(ctx context.Context, taskID string) ([]*domain.TaskHistory, error) {
+	task, err := uc.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.authorizeTask(ctx, task, domain.PermissionViewTasks); err != nil {
+		return nil, err
+	}
 	return uc.taskHistoryRepo.GetByTaskID(ctx, taskID)
 }
 
@@ -11,26 +11,44 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/pkg/actor"
 	repoMocks "github.com/Sol1tud9/taskflow/internal/task/repository/mocks"
 	taskUsecase "github.com/Sol1tud9/taskflow/internal/task/usecase"
 	usecaseMocks "github.com/Sol1tud9/taskflow/internal/task/usecase/mocks"
 )
 
+const actorID = "actor-1"
+
 type TaskUseCaseSuite struct {
 	suite.Suite
 	ctx              context.Context
 	taskRepo         *repoMocks.TaskRepository
 	taskHistoryRepo  *repoMocks.TaskHistoryRepository
 	publisher        *usecaseMocks.EventPublisher
+	txManager        *usecaseMocks.TxManager
+	teams            *usecaseMocks.TeamDirectory
 	taskUseCase      *taskUsecase.TaskUseCase
 }
 
+// runInTx stands in for a real transaction by calling fn with the same context.
+func runInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 func (s *TaskUseCaseSuite) SetupTest() {
-	s.ctx = context.Background()
+	s.ctx = actor.WithUserID(context.Background(), actorID)
 	s.taskRepo = repoMocks.NewTaskRepository(s.T())
 	s.taskHistoryRepo = repoMocks.NewTaskHistoryRepository(s.T())
 	s.publisher = usecaseMocks.NewEventPublisher(s.T())
-	s.taskUseCase = taskUsecase.NewTaskUseCase(s.taskRepo, s.taskHistoryRepo, s.publisher)
+	s.txManager = usecaseMocks.NewTxManager(s.T())
+	s.teams = usecaseMocks.NewTeamDirectory(s.T())
+	s.txManager.On("WithinTx", s.ctx, mock.Anything).Return(runInTx)
+	s.taskUseCase = taskUsecase.NewTaskUseCase(s.taskRepo, s.taskHistoryRepo, s.publisher, s.txManager, taskUsecase.Workflows{}, s.teams)
+}
+
+// memberOf gives the acting user role in teamID.
+func (s *TaskUseCaseSuite) memberOf(teamID string, role domain.TeamRole) {
+	s.teams.On("Memberships", s.ctx, actorID).Return(map[string]domain.TeamRole{teamID: role}, nil)
 }
 
 func (s *TaskUseCaseSuite) TestCreateTask_Success() {
@@ -43,6 +61,7 @@ func (s *TaskUseCaseSuite) TestCreateTask_Success() {
 		TeamID:      uuid.New().String(),
 		DueDate:     time.Now().Unix(),
 	}
+	s.memberOf(input.TeamID, domain.RoleMember)
 
 	s.taskRepo.On("Create", s.ctx, mock.MatchedBy(func(t *domain.Task) bool {
 		return t.Title == input.Title && t.Description == input.Description
@@ -80,6 +99,41 @@ func (s *TaskUseCaseSuite) TestCreateTask_RepositoryError() {
 	assert.Equal(s.T(), repoErr, err)
 }
 
+func (s *TaskUseCaseSuite) TestCreateTask_PublishError() {
+	input := taskUsecase.CreateTaskInput{
+		Title: "Test Task",
+	}
+	outboxErr := errors.New("outbox insert failed")
+
+	s.taskRepo.On("Create", s.ctx, mock.Anything).Return(nil)
+	s.publisher.On("PublishTaskCreated", s.ctx, mock.Anything).Return(outboxErr)
+
+	result, err := s.taskUseCase.CreateTask(s.ctx, input)
+
+	assert.Nil(s.T(), result)
+	assert.Equal(s.T(), outboxErr, err)
+}
+
+func (s *TaskUseCaseSuite) TestCreateTask_DefaultPriority() {
+	s.taskRepo.On("Create", s.ctx, mock.MatchedBy(func(t *domain.Task) bool {
+		return t.Priority == domain.TaskPriorityMedium
+	})).Return(nil)
+	s.publisher.On("PublishTaskCreated", s.ctx, mock.Anything).Return(nil)
+
+	_, err := s.taskUseCase.CreateTask(s.ctx, taskUsecase.CreateTaskInput{Title: "Task", CreatorID: uuid.New().String()})
+
+	assert.NoError(s.T(), err)
+}
+
+func (s *TaskUseCaseSuite) TestCreateTask_UnknownPriority() {
+	_, err := s.taskUseCase.CreateTask(s.ctx, taskUsecase.CreateTaskInput{Title: "Task", Priority: "urgent"})
+
+	var validationErr *domain.ValidationError
+	s.Require().True(errors.As(err, &validationErr))
+	assert.Equal(s.T(), "priority", validationErr.Fields[0].Field)
+	s.taskRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
 func (s *TaskUseCaseSuite) TestGetTask_Success() {
 	taskID := uuid.New().String()
 	expectedTask := &domain.Task{
@@ -88,6 +142,7 @@ func (s *TaskUseCaseSuite) TestGetTask_Success() {
 		Description: "Test Description",
 		Status:      domain.TaskStatusTodo,
 		Priority:    domain.TaskPriorityHigh,
+		CreatorID:   actorID,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -102,6 +157,74 @@ func (s *TaskUseCaseSuite) TestGetTask_Success() {
 	assert.Equal(s.T(), expectedTask.Title, result.Title)
 }
 
+func (s *TaskUseCaseSuite) TestListTasks_FillsDefaults() {
+	page := &domain.TaskPage{}
+	teamID := uuid.New().String()
+	s.memberOf(teamID, domain.RoleViewer)
+	s.taskRepo.On("List", s.ctx, taskUsecase.TaskFilter{
+		Statuses:     []string{"todo", "in_progress"},
+		Sort:         taskUsecase.DefaultSort,
+		Limit:        20,
+		Count:        taskUsecase.CountExact,
+		VisibleTo:    actorID,
+		VisibleTeams: []string{teamID},
+	}).Return(page, nil)
+
+	result, err := s.taskUseCase.ListTasks(s.ctx, taskUsecase.TaskFilter{
+		Statuses: []string{"todo", "in_progress"},
+		Limit:    20,
+	})
+
+	assert.NoError(s.T(), err)
+	assert.Same(s.T(), page, result)
+}
+
+func (s *TaskUseCaseSuite) TestListTasks_RejectsUnknownValues() {
+	_, err := s.taskUseCase.ListTasks(s.ctx, taskUsecase.TaskFilter{
+		Statuses: []string{"todo", "blocked"},
+		Priority: "urgent",
+		Sort:     "-assignee_id",
+		Count:    "estimate",
+	})
+
+	var validationErr *domain.ValidationError
+	s.Require().True(errors.As(err, &validationErr))
+	fields := make([]string, 0, len(validationErr.Fields))
+	for _, f := range validationErr.Fields {
+		fields = append(fields, f.Field)
+	}
+	assert.Equal(s.T(), []string{"status", "priority", "sort", "count"}, fields)
+	s.taskRepo.AssertNotCalled(s.T(), "List", mock.Anything, mock.Anything)
+}
+
+func (s *TaskUseCaseSuite) TestSearchTasks_PassesFilter() {
+	filter := taskUsecase.TaskFilter{
+		TeamID: uuid.New().String(),
+		Sort:   taskUsecase.DefaultSort,
+		Limit:  20,
+		Count:  taskUsecase.CountExact,
+	}
+	hits := []*domain.TaskSearchHit{{Task: &domain.Task{ID: uuid.New().String()}, Rank: 0.5}}
+	s.memberOf(filter.TeamID, domain.RoleViewer)
+
+	s.taskRepo.On("Search", s.ctx, "login bug", filter).Return(hits, 1, nil)
+
+	result, total, err := s.taskUseCase.SearchTasks(s.ctx, "login bug", filter)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), hits, result)
+	assert.Equal(s.T(), 1, total)
+}
+
+func (s *TaskUseCaseSuite) TestSearchTasks_EmptyQuery() {
+	_, _, err := s.taskUseCase.SearchTasks(s.ctx, "  ", taskUsecase.TaskFilter{})
+
+	var validationErr *domain.ValidationError
+	s.Require().True(errors.As(err, &validationErr))
+	assert.Equal(s.T(), "q", validationErr.Fields[0].Field)
+	s.taskRepo.AssertNotCalled(s.T(), "Search", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func (s *TaskUseCaseSuite) TestUpdateTask_Success() {
 	taskID := uuid.New().String()
 	userID := uuid.New().String()
@@ -112,6 +235,7 @@ func (s *TaskUseCaseSuite) TestUpdateTask_Success() {
 		Description: "Old Description",
 		Status:      domain.TaskStatusTodo,
 		Priority:    domain.TaskPriorityLow,
+		CreatorID:   actorID,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -127,7 +251,10 @@ func (s *TaskUseCaseSuite) TestUpdateTask_Success() {
 		return t.ID == taskID && t.Title == input.Title
 	})).Return(nil)
 	s.taskHistoryRepo.On("Create", s.ctx, mock.Anything).Return(nil)
-	s.publisher.On("PublishTaskUpdated", s.ctx, mock.Anything).Return(nil)
+	s.publisher.On("PublishTaskUpdated", s.ctx, mock.MatchedBy(func(e domain.TaskUpdatedEvent) bool {
+		return e.EventID != "" && e.UserID == userID && len(e.Changes) == 2 &&
+			e.Changes[0].Field == "status" && e.Changes[1].Field == "title"
+	})).Return(nil).Once()
 
 	result, err := s.taskUseCase.UpdateTask(s.ctx, taskID, input)
 
@@ -137,16 +264,134 @@ func (s *TaskUseCaseSuite) TestUpdateTask_Success() {
 	assert.Equal(s.T(), domain.TaskStatusInProgress, result.Status)
 }
 
+func (s *TaskUseCaseSuite) TestUpdateTask_HistoryError() {
+	taskID := uuid.New().String()
+	historyErr := errors.New("history insert failed")
+
+	s.taskRepo.On("GetByID", s.ctx, taskID).Return(&domain.Task{
+		ID:        taskID,
+		Title:     "Old Title",
+		Status:    domain.TaskStatusTodo,
+		CreatorID: actorID,
+	}, nil)
+	s.taskRepo.On("Update", s.ctx, mock.Anything).Return(nil)
+	s.taskHistoryRepo.On("Create", s.ctx, mock.Anything).Return(historyErr)
+
+	result, err := s.taskUseCase.UpdateTask(s.ctx, taskID, taskUsecase.UpdateTaskInput{
+		Title:  "New Title",
+		UserID: uuid.New().String(),
+	})
+
+	assert.Nil(s.T(), result)
+	assert.Equal(s.T(), historyErr, err)
+	s.publisher.AssertNotCalled(s.T(), "PublishTaskUpdated", mock.Anything, mock.Anything)
+}
+
+func (s *TaskUseCaseSuite) TestUpdateTask_NoChanges() {
+	task := &domain.Task{ID: uuid.New().String(), Title: "Same Title", Status: domain.TaskStatusTodo, CreatorID: actorID}
+
+	s.taskRepo.On("GetByID", s.ctx, task.ID).Return(task, nil)
+	s.taskRepo.On("Update", s.ctx, mock.Anything).Return(nil)
+
+	_, err := s.taskUseCase.UpdateTask(s.ctx, task.ID, taskUsecase.UpdateTaskInput{Title: "Same Title"})
+
+	assert.NoError(s.T(), err)
+	s.publisher.AssertNotCalled(s.T(), "PublishTaskUpdated", mock.Anything, mock.Anything)
+}
+
+func (s *TaskUseCaseSuite) TestUpdateTask_ExpectedVersionMatches() {
+	task := &domain.Task{ID: uuid.New().String(), Title: "Old Title", Status: domain.TaskStatusTodo, CreatorID: actorID, Version: 3}
+
+	s.taskRepo.On("GetByID", s.ctx, task.ID).Return(task, nil)
+	s.taskRepo.On("Update", s.ctx, mock.MatchedBy(func(t *domain.Task) bool {
+		return t.Version == 3
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Task).Version++
+	}).Return(nil)
+	s.taskHistoryRepo.On("Create", s.ctx, mock.Anything).Return(nil)
+	s.publisher.On("PublishTaskUpdated", s.ctx, mock.Anything).Return(nil)
+
+	result, err := s.taskUseCase.UpdateTask(s.ctx, task.ID, taskUsecase.UpdateTaskInput{
+		Title:           "New Title",
+		ExpectedVersion: 3,
+	})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(4), result.Version)
+}
+
+func (s *TaskUseCaseSuite) TestUpdateTask_StaleExpectedVersion() {
+	task := &domain.Task{ID: uuid.New().String(), Title: "Old Title", Status: domain.TaskStatusTodo, CreatorID: actorID, Version: 5}
+
+	s.taskRepo.On("GetByID", s.ctx, task.ID).Return(task, nil)
+
+	result, err := s.taskUseCase.UpdateTask(s.ctx, task.ID, taskUsecase.UpdateTaskInput{
+		Title:           "New Title",
+		ExpectedVersion: 4,
+	})
+
+	assert.Nil(s.T(), result)
+	assert.ErrorIs(s.T(), err, domain.ErrVersionConflict)
+	s.taskRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	s.taskHistoryRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *TaskUseCaseSuite) TestUpdateTask_ConcurrentUpdate() {
+	task := &domain.Task{ID: uuid.New().String(), Title: "Old Title", Status: domain.TaskStatusTodo, CreatorID: actorID, Version: 2}
+
+	s.taskRepo.On("GetByID", s.ctx, task.ID).Return(task, nil)
+	s.taskRepo.On("Update", s.ctx, mock.Anything).Return(domain.ErrVersionConflict)
+
+	result, err := s.taskUseCase.UpdateTask(s.ctx, task.ID, taskUsecase.UpdateTaskInput{Title: "New Title"})
+
+	assert.Nil(s.T(), result)
+	assert.ErrorIs(s.T(), err, domain.ErrVersionConflict)
+	s.taskHistoryRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	s.publisher.AssertNotCalled(s.T(), "PublishTaskUpdated", mock.Anything, mock.Anything)
+}
+
+func (s *TaskUseCaseSuite) TestUpdateTask_UnknownStatusAndPriority() {
+	_, err := s.taskUseCase.UpdateTask(s.ctx, uuid.New().String(), taskUsecase.UpdateTaskInput{
+		Status:   "blocked",
+		Priority: "urgent",
+	})
+
+	var validationErr *domain.ValidationError
+	s.Require().True(errors.As(err, &validationErr))
+	assert.Equal(s.T(), []domain.FieldError{
+		{Field: "status", Message: `unknown status "blocked"`},
+		{Field: "priority", Message: `unknown priority "urgent"`},
+	}, validationErr.Fields)
+	s.taskRepo.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything)
+}
+
 func (s *TaskUseCaseSuite) TestDeleteTask_Success() {
 	taskID := uuid.New().String()
+	task := &domain.Task{ID: taskID, Title: "Test Task", CreatorID: actorID}
 
+	s.taskRepo.On("GetByID", s.ctx, taskID).Return(task, nil)
 	s.taskRepo.On("Delete", s.ctx, taskID).Return(nil)
+	s.publisher.On("PublishTaskDeleted", s.ctx, mock.MatchedBy(func(e domain.TaskDeletedEvent) bool {
+		return e.EventID != "" && e.TaskID == taskID && e.UserID == actorID && e.Title == task.Title
+	})).Return(nil)
 
 	err := s.taskUseCase.DeleteTask(s.ctx, taskID)
 
 	assert.NoError(s.T(), err)
 }
 
+func (s *TaskUseCaseSuite) TestDeleteTask_NotFound() {
+	taskID := uuid.New().String()
+
+	s.taskRepo.On("GetByID", s.ctx, taskID).Return(nil, domain.ErrNotFound)
+
+	err := s.taskUseCase.DeleteTask(s.ctx, taskID)
+
+	assert.ErrorIs(s.T(), err, domain.ErrNotFound)
+	s.taskRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+	s.publisher.AssertNotCalled(s.T(), "PublishTaskDeleted", mock.Anything, mock.Anything)
+}
+
 func TestTaskUseCaseSuite(t *testing.T) {
 	suite.Run(t, new(TaskUseCaseSuite))
 }
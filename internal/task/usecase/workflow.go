@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+)
+
+// Workflows holds the status workflow of each team that has its own; other
+// teams, and tasks without a team, use Default, or domain.DefaultWorkflow
+// when Default is unset.
+type Workflows struct {
+	Default domain.Workflow
+	Teams   map[string]domain.Workflow
+}
+
+func (w Workflows) For(teamID string) domain.Workflow {
+	if wf, ok := w.Teams[teamID]; ok && teamID != "" {
+		return wf
+	}
+	if w.Default != nil {
+		return w.Default
+	}
+	return domain.DefaultWorkflow
+}
+
+func (w Workflows) Validate() error {
+	if err := w.Default.Validate(); err != nil {
+		return fmt.Errorf("default workflow: %w", err)
+	}
+	for teamID, wf := range w.Teams {
+		if err := wf.Validate(); err != nil {
+			return fmt.Errorf("workflow of team %s: %w", teamID, err)
+		}
+	}
+	return nil
+}
+
+func validateCreate(input CreateTaskInput) error {
+	verr := &domain.ValidationError{}
+	if input.Priority != "" && !domain.TaskPriority(input.Priority).Valid() {
+		verr.Add("priority", fmt.Sprintf("unknown priority %q", input.Priority))
+	}
+	return verr.Err()
+}
+
+func validateUpdate(input UpdateTaskInput) error {
+	verr := &domain.ValidationError{}
+	if input.Status != "" && !domain.TaskStatus(input.Status).Valid() {
+		verr.Add("status", fmt.Sprintf("unknown status %q", input.Status))
+	}
+	if input.Priority != "" && !domain.TaskPriority(input.Priority).Valid() {
+		verr.Add("priority", fmt.Sprintf("unknown priority %q", input.Priority))
+	}
+	return verr.Err()
+}
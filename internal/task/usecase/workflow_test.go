@@ -0,0 +1,136 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/pkg/actor"
+	repoMocks "github.com/Sol1tud9/taskflow/internal/task/repository/mocks"
+	taskUsecase "github.com/Sol1tud9/taskflow/internal/task/usecase"
+	usecaseMocks "github.com/Sol1tud9/taskflow/internal/task/usecase/mocks"
+)
+
+type WorkflowSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (s *WorkflowSuite) SetupTest() {
+	s.ctx = actor.WithUserID(context.Background(), actorID)
+}
+
+// updateStatus moves a task of teamID from one status to another and returns
+// the use case's error.
+func (s *WorkflowSuite) updateStatus(workflows taskUsecase.Workflows, teamID string, from, to domain.TaskStatus) error {
+	taskRepo := repoMocks.NewTaskRepository(s.T())
+	historyRepo := repoMocks.NewTaskHistoryRepository(s.T())
+	publisher := usecaseMocks.NewEventPublisher(s.T())
+	txManager := usecaseMocks.NewTxManager(s.T())
+	txManager.On("WithinTx", s.ctx, mock.Anything).Return(runInTx).Maybe()
+	teams := usecaseMocks.NewTeamDirectory(s.T())
+	teams.On("Memberships", s.ctx, actorID).Return(map[string]domain.TeamRole{teamID: domain.RoleMember}, nil).Maybe()
+
+	task := &domain.Task{ID: uuid.New().String(), Title: "Task", Status: from, TeamID: teamID, CreatorID: actorID}
+	taskRepo.On("GetByID", s.ctx, task.ID).Return(task, nil)
+	taskRepo.On("Update", s.ctx, mock.Anything).Return(nil).Maybe()
+	historyRepo.On("Create", s.ctx, mock.Anything).Return(nil).Maybe()
+	publisher.On("PublishTaskUpdated", s.ctx, mock.Anything).Return(nil).Maybe()
+
+	uc := taskUsecase.NewTaskUseCase(taskRepo, historyRepo, publisher, txManager, workflows, teams)
+	_, err := uc.UpdateTask(s.ctx, task.ID, taskUsecase.UpdateTaskInput{Status: string(to)})
+	if err != nil {
+		taskRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	}
+	return err
+}
+
+func (s *WorkflowSuite) TestDefaultWorkflowTransitions() {
+	const (
+		todo       = domain.TaskStatusTodo
+		inProgress = domain.TaskStatusInProgress
+		done       = domain.TaskStatusDone
+		cancelled  = domain.TaskStatusCancelled
+	)
+
+	tests := []struct {
+		from, to domain.TaskStatus
+		allowed  bool
+	}{
+		{todo, todo, true},
+		{todo, inProgress, true},
+		{todo, done, false},
+		{todo, cancelled, true},
+		{inProgress, todo, true},
+		{inProgress, inProgress, true},
+		{inProgress, done, true},
+		{inProgress, cancelled, true},
+		{done, todo, false},
+		{done, inProgress, true},
+		{done, done, true},
+		{done, cancelled, false},
+		{cancelled, todo, false},
+		{cancelled, inProgress, false},
+		{cancelled, done, false},
+		{cancelled, cancelled, true},
+	}
+
+	for _, tt := range tests {
+		s.Run(string(tt.from)+"->"+string(tt.to), func() {
+			err := s.updateStatus(taskUsecase.Workflows{}, "", tt.from, tt.to)
+			if tt.allowed {
+				s.NoError(err)
+				return
+			}
+
+			var transitionErr *domain.TransitionError
+			s.Require().True(errors.As(err, &transitionErr), "got %v", err)
+			s.Equal(tt.from, transitionErr.From)
+			s.Equal(tt.to, transitionErr.To)
+		})
+	}
+}
+
+func (s *WorkflowSuite) TestTeamWorkflowOverridesDefault() {
+	workflows := taskUsecase.Workflows{
+		Teams: map[string]domain.Workflow{
+			"kanban": {
+				domain.TaskStatusTodo: {domain.TaskStatusDone},
+				domain.TaskStatusDone: {domain.TaskStatusTodo},
+			},
+		},
+	}
+
+	s.NoError(s.updateStatus(workflows, "kanban", domain.TaskStatusTodo, domain.TaskStatusDone))
+	s.Error(s.updateStatus(workflows, "kanban", domain.TaskStatusTodo, domain.TaskStatusInProgress))
+
+	s.NoError(s.updateStatus(workflows, "other", domain.TaskStatusTodo, domain.TaskStatusInProgress))
+	s.Error(s.updateStatus(workflows, "other", domain.TaskStatusTodo, domain.TaskStatusDone))
+}
+
+func (s *WorkflowSuite) TestConfiguredDefaultReplacesBuiltIn() {
+	workflows := taskUsecase.Workflows{
+		Default: domain.Workflow{domain.TaskStatusTodo: {domain.TaskStatusDone}},
+	}
+
+	s.NoError(s.updateStatus(workflows, "", domain.TaskStatusTodo, domain.TaskStatusDone))
+	s.Error(s.updateStatus(workflows, "", domain.TaskStatusTodo, domain.TaskStatusInProgress))
+}
+
+func (s *WorkflowSuite) TestWorkflowsValidate() {
+	assert.NoError(s.T(), taskUsecase.Workflows{Default: domain.DefaultWorkflow}.Validate())
+
+	err := taskUsecase.Workflows{
+		Teams: map[string]domain.Workflow{"team": {domain.TaskStatusTodo: {"review"}}},
+	}.Validate()
+	assert.ErrorContains(s.T(), err, `"review"`)
+}
+
+func TestWorkflowSuite(t *testing.T) {
+	suite.Run(t, new(WorkflowSuite))
+}
@@ -2,51 +2,113 @@ package bootstrap
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"time"
 
+	"github.com/go-redis/redis/v8"
+
+	apievents "github.com/Sol1tud9/taskflow/api/events"
 	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/pb/user_api"
 	"github.com/Sol1tud9/taskflow/internal/user/publisher"
+	"github.com/Sol1tud9/taskflow/internal/user/server"
 	"github.com/Sol1tud9/taskflow/internal/user/storage/postgres"
 	"github.com/Sol1tud9/taskflow/internal/user/usecase"
+	"github.com/Sol1tud9/taskflow/pkg/actor"
 	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/events"
+	"github.com/Sol1tud9/taskflow/pkg/grpcserver"
 	"github.com/Sol1tud9/taskflow/pkg/logger"
+	"github.com/Sol1tud9/taskflow/pkg/metrics"
+	"github.com/Sol1tud9/taskflow/pkg/outbox"
+	"github.com/Sol1tud9/taskflow/pkg/session"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
+const httpShutdownTimeout = 10 * time.Second
+
 type App struct {
-	Config    *config.UserServiceConfig
-	Storage   *postgres.Storage
-	Publisher *publisher.Publisher
-	UserUC    *usecase.UserUseCase
-	TeamUC    *usecase.TeamUseCase
+	Config     *config.UserServiceConfig
+	Storage    *postgres.Storage
+	Sender     *outbox.KafkaSender
+	Relay      *outbox.Relay
+	Redis      *redis.Client
+	UserUC     *usecase.UserUseCase
+	TeamUC     *usecase.TeamUseCase
+	AccountUC  *usecase.ServiceAccountUseCase
+	GRPCServer *grpcserver.Server
+	// MetricsServer serves /metrics on the HTTP port.
+	MetricsServer *http.Server
 }
 
 func NewApp(cfg *config.UserServiceConfig) (*App, error) {
+	registry, err := events.NewRegistry(apievents.Schemas)
+	if err != nil {
+		logger.Error("failed to load event schemas", zap.Error(err))
+		return nil, err
+	}
+
 	storage, err := postgres.NewStorage(cfg.Database)
 	if err != nil {
 		logger.Error("failed to init storage", zap.Error(err))
 		return nil, err
 	}
+	if err := metrics.RegisterPool(storage.Pool(), cfg.Database.Name, ""); err != nil {
+		logger.Error("failed to register pool metrics", zap.Error(err))
+		return nil, err
+	}
+
+	outboxStore := outbox.NewStore(storage.Pool())
+	pub := publisher.NewPublisher(outboxStore, events.NewEncoder(registry, cfg.App.Name), cfg.Kafka.Topics)
 
-	pub := publisher.NewPublisher(cfg.Kafka.Brokers, cfg.Kafka.Topics)
+	sender := outbox.NewKafkaSender(cfg.Kafka.Brokers, cfg.Kafka.Topics)
+	relay := outbox.NewRelay(cfg.App.Name, outboxStore, sender, cfg.Outbox)
 
-	userUC := usecase.NewUserUseCase(storage, pub)
+	// Sessions live in the gateway's Redis; user-service only ends them.
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+
+	userUC := usecase.NewUserUseCase(storage, pub, storage, session.NewRedisStore(redisClient))
 
 	teamRepoAdapter := &teamRepoAdapter{storage: storage}
 	teamMemberRepoAdapter := &teamMemberRepoAdapter{storage: storage}
-	teamUC := usecase.NewTeamUseCase(teamRepoAdapter, teamMemberRepoAdapter, pub)
+	teamUC := usecase.NewTeamUseCase(teamRepoAdapter, teamMemberRepoAdapter, pub, storage)
+
+	accountUC := usecase.NewServiceAccountUseCase(storage, &apiKeyRepoAdapter{storage: storage}, pub, storage)
+
+	grpcServer := grpcserver.New(cfg.Server.GRPCPort, grpc.ChainUnaryInterceptor(events.UnaryServerInterceptor(), actor.UnaryServerInterceptor()))
+	grpcServer.RegisterService(&user_api.UserService_ServiceDesc, server.NewServer(userUC, teamUC, accountUC))
 
 	return &App{
-		Config:    cfg,
-		Storage:   storage,
-		Publisher: pub,
-		UserUC:    userUC,
-		TeamUC:    teamUC,
+		Config:        cfg,
+		Storage:       storage,
+		Sender:        sender,
+		Relay:         relay,
+		Redis:         redisClient,
+		UserUC:        userUC,
+		TeamUC:        teamUC,
+		AccountUC:     accountUC,
+		GRPCServer:    grpcServer,
+		MetricsServer: metrics.NewServer(cfg.Server.HTTPPort),
 	}, nil
 }
 
 func (a *App) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+	defer cancel()
+	if err := a.MetricsServer.Shutdown(ctx); err != nil {
+		logger.Error("failed to shutdown metrics server", zap.Error(err))
+	}
+
+	a.GRPCServer.Stop()
 	a.Storage.Close()
-	_ = a.Publisher.Close()
+	_ = a.Sender.Close()
+	_ = a.Redis.Close()
 }
 
 type teamRepoAdapter struct {
@@ -69,6 +131,14 @@ func (a *teamRepoAdapter) Delete(ctx context.Context, id string) error {
 	return a.storage.DeleteTeam(ctx, id)
 }
 
+func (a *teamRepoAdapter) List(ctx context.Context) ([]*domain.Team, error) {
+	return a.storage.ListTeams(ctx)
+}
+
+func (a *teamRepoAdapter) ListByMember(ctx context.Context, userID string) ([]*domain.Team, error) {
+	return a.storage.ListTeamsByMember(ctx, userID)
+}
+
 type teamMemberRepoAdapter struct {
 	storage *postgres.Storage
 }
@@ -81,7 +151,38 @@ func (a *teamMemberRepoAdapter) GetByTeamID(ctx context.Context, teamID string)
 	return a.storage.GetTeamMembersByTeamID(ctx, teamID)
 }
 
+func (a *teamMemberRepoAdapter) Get(ctx context.Context, teamID, userID string) (*domain.TeamMember, error) {
+	return a.storage.GetTeamMember(ctx, teamID, userID)
+}
+
+func (a *teamMemberRepoAdapter) GetByUserID(ctx context.Context, userID string) ([]*domain.TeamMember, error) {
+	return a.storage.GetTeamMembersByUserID(ctx, userID)
+}
+
 func (a *teamMemberRepoAdapter) Remove(ctx context.Context, teamID, userID string) error {
 	return a.storage.RemoveTeamMember(ctx, teamID, userID)
 }
 
+type apiKeyRepoAdapter struct {
+	storage *postgres.Storage
+}
+
+func (a *apiKeyRepoAdapter) Create(ctx context.Context, key *domain.APIKey) error {
+	return a.storage.CreateAPIKey(ctx, key)
+}
+
+func (a *apiKeyRepoAdapter) GetByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	return a.storage.GetAPIKeyByPrefix(ctx, prefix)
+}
+
+func (a *apiKeyRepoAdapter) ListByUserID(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	return a.storage.ListAPIKeysByUserID(ctx, userID)
+}
+
+func (a *apiKeyRepoAdapter) Revoke(ctx context.Context, userID, id string, at time.Time) error {
+	return a.storage.RevokeAPIKey(ctx, userID, id, at)
+}
+
+func (a *apiKeyRepoAdapter) Touch(ctx context.Context, id string, at time.Time) error {
+	return a.storage.TouchAPIKey(ctx, id, at)
+}
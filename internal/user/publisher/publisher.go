@@ -4,39 +4,53 @@ import (
 	"context"
 
 	"github.com/Sol1tud9/taskflow/internal/domain"
-	"github.com/Sol1tud9/taskflow/pkg/kafka"
+	"github.com/Sol1tud9/taskflow/pkg/events"
+	"github.com/Sol1tud9/taskflow/pkg/outbox"
 )
 
+// Publisher records user and team events in the outbox; the relay delivers them to Kafka.
 type Publisher struct {
-	userCreatedProducer *kafka.Producer
-	userUpdatedProducer *kafka.Producer
-	teamUpdatedProducer *kafka.Producer
+	outbox  *outbox.Store
+	encoder *events.Encoder
+	topics  map[string]string
 }
 
-func NewPublisher(brokers []string, topics map[string]string) *Publisher {
+func NewPublisher(store *outbox.Store, encoder *events.Encoder, topics map[string]string) *Publisher {
 	return &Publisher{
-		userCreatedProducer: kafka.NewProducer(brokers, topics["user_created"]),
-		userUpdatedProducer: kafka.NewProducer(brokers, topics["user_updated"]),
-		teamUpdatedProducer: kafka.NewProducer(brokers, topics["team_updated"]),
+		outbox:  store,
+		encoder: encoder,
+		topics:  topics,
 	}
 }
 
 func (p *Publisher) PublishUserCreated(ctx context.Context, event domain.UserCreatedEvent) error {
-	return p.userCreatedProducer.Publish(ctx, event.UserID, event)
+	return p.publish(ctx, "user_created", domain.EventTypeUserCreated, event.UserID, event.EventID, event)
 }
 
 func (p *Publisher) PublishUserUpdated(ctx context.Context, event domain.UserUpdatedEvent) error {
-	return p.userUpdatedProducer.Publish(ctx, event.UserID, event)
+	return p.publish(ctx, "user_updated", domain.EventTypeUserUpdated, event.UserID, event.EventID, event)
+}
+
+func (p *Publisher) PublishTeamCreated(ctx context.Context, event domain.TeamCreatedEvent) error {
+	return p.publish(ctx, "team_created", domain.EventTypeTeamCreated, event.TeamID, event.EventID, event)
 }
 
 func (p *Publisher) PublishTeamUpdated(ctx context.Context, event domain.TeamUpdatedEvent) error {
-	return p.teamUpdatedProducer.Publish(ctx, event.TeamID, event)
+	return p.publish(ctx, "team_updated", domain.EventTypeTeamUpdated, event.TeamID, event.EventID, event)
+}
+
+func (p *Publisher) PublishTeamMemberAdded(ctx context.Context, event domain.TeamMemberAddedEvent) error {
+	return p.publish(ctx, "team_member_added", domain.EventTypeTeamMemberAdded, event.TeamID, event.EventID, event)
 }
 
-func (p *Publisher) Close() error {
-	_ = p.userCreatedProducer.Close()
-	_ = p.userUpdatedProducer.Close()
-	_ = p.teamUpdatedProducer.Close()
-	return nil
+func (p *Publisher) PublishTeamMemberRemoved(ctx context.Context, event domain.TeamMemberRemovedEvent) error {
+	return p.publish(ctx, "team_member_removed", domain.EventTypeTeamMemberRemoved, event.TeamID, event.EventID, event)
 }
 
+func (p *Publisher) publish(ctx context.Context, topic, eventType, key, eventID string, event interface{}) error {
+	envelope, err := p.encoder.Encode(ctx, eventID, eventType, event)
+	if err != nil {
+		return err
+	}
+	return p.outbox.Add(ctx, p.topics[topic], key, envelope)
+}
@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/Sol1tud9/taskflow/internal/domain"
+)
+
+type APIKeyRepository struct {
+	mock.Mock
+}
+
+func NewAPIKeyRepository(t testing.TB) *APIKeyRepository {
+	mock := &APIKeyRepository{}
+	mock.Mock.Test(t)
+	return mock
+}
+
+func (m *APIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *APIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	args := m.Called(ctx, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *APIKeyRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.APIKey), args.Error(1)
+}
+
+func (m *APIKeyRepository) Revoke(ctx context.Context, userID, id string, at time.Time) error {
+	args := m.Called(ctx, userID, id, at)
+	return args.Error(0)
+}
+
+func (m *APIKeyRepository) Touch(ctx context.Context, id string, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
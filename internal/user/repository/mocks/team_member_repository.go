@@ -31,6 +31,22 @@ func (m *TeamMemberRepository) GetByTeamID(ctx context.Context, teamID string) (
 	return args.Get(0).([]*domain.TeamMember), args.Error(1)
 }
 
+func (m *TeamMemberRepository) Get(ctx context.Context, teamID, userID string) (*domain.TeamMember, error) {
+	args := m.Called(ctx, teamID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TeamMember), args.Error(1)
+}
+
+func (m *TeamMemberRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.TeamMember, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TeamMember), args.Error(1)
+}
+
 func (m *TeamMemberRepository) Remove(ctx context.Context, teamID, userID string) error {
 	args := m.Called(ctx, teamID, userID)
 	return args.Error(0)
@@ -41,3 +41,18 @@ func (m *TeamRepository) Delete(ctx context.Context, id string) error {
 	return args.Error(0)
 }
 
+func (m *TeamRepository) List(ctx context.Context) ([]*domain.Team, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Team), args.Error(1)
+}
+
+func (m *TeamRepository) ListByMember(ctx context.Context, userID string) ([]*domain.Team, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Team), args.Error(1)
+}
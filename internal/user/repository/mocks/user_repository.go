@@ -31,6 +31,14 @@ func (m *UserRepository) GetByID(ctx context.Context, id string) (*domain.User,
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
 func (m *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	args := m.Called(ctx, user)
 	return args.Error(0)
@@ -41,3 +49,19 @@ func (m *UserRepository) Delete(ctx context.Context, id string) error {
 	return args.Error(0)
 }
 
+func (m *UserRepository) List(ctx context.Context) ([]*domain.User, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
+
+func (m *UserRepository) ListByOwner(ctx context.Context, ownerID string) ([]*domain.User, error) {
+	args := m.Called(ctx, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
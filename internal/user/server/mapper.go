@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/pb/models"
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func toUserPB(u *domain.User) *models.User {
+	return &models.User{
+		Id:        u.ID,
+		Email:     u.Email,
+		Name:      u.Name,
+		CreatedAt: toUnix(u.CreatedAt),
+		UpdatedAt: toUnix(u.UpdatedAt),
+		OwnerId:   u.OwnerID,
+	}
+}
+
+func toTeamPB(t *domain.Team) *models.Team {
+	return &models.Team{
+		Id:        t.ID,
+		Name:      t.Name,
+		OwnerId:   t.OwnerID,
+		CreatedAt: toUnix(t.CreatedAt),
+		UpdatedAt: toUnix(t.UpdatedAt),
+	}
+}
+
+func toTeamMemberPB(m *domain.TeamMember) *models.TeamMember {
+	return &models.TeamMember{
+		Id:       m.ID,
+		TeamId:   m.TeamID,
+		UserId:   m.UserID,
+		Role:     m.Role,
+		JoinedAt: toUnix(m.JoinedAt),
+	}
+}
+
+func toAPIKeyPB(k *domain.APIKey) *models.APIKey {
+	pb := &models.APIKey{
+		Id:        k.ID,
+		UserId:    k.UserID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    k.Scopes,
+		CreatedAt: toUnix(k.CreatedAt),
+	}
+	if k.LastUsedAt != nil {
+		pb.LastUsedAt = toUnix(*k.LastUsedAt)
+	}
+	if k.RevokedAt != nil {
+		pb.RevokedAt = toUnix(*k.RevokedAt)
+	}
+	return pb
+}
+
+func toUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func toStatus(err error) error {
+	var validationErr *domain.ValidationError
+
+	switch {
+	case errors.As(err, &validationErr):
+		return withFieldViolations(codes.InvalidArgument, err, validationErr.Fields)
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, domain.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, domain.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// withFieldViolations attaches the rejected fields as a BadRequest detail,
+// which the gateway turns into field-level errors.
+func withFieldViolations(code codes.Code, err error, fields []domain.FieldError) error {
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(fields))
+	for _, f := range fields {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: f.Field, Description: f.Message})
+	}
+
+	st, detailErr := status.New(code, err.Error()).WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if detailErr != nil {
+		return status.Error(code, err.Error())
+	}
+	return st.Err()
+}
@@ -0,0 +1,339 @@
+package server
+
+import (
+	"context"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/internal/pb/models"
+	"github.com/Sol1tud9/taskflow/internal/pb/user_api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultMemberRole = string(domain.RoleMember)
+
+type UserUseCase interface {
+	CreateUser(ctx context.Context, email, name, password string) (*domain.User, error)
+	Authenticate(ctx context.Context, email, password string) (*domain.User, error)
+	GetUser(ctx context.Context, id string) (*domain.User, error)
+	ListUsers(ctx context.Context) ([]*domain.User, error)
+	UpdateUser(ctx context.Context, id, email, name, password, currentPassword string) (*domain.User, error)
+}
+
+type TeamUseCase interface {
+	CreateTeam(ctx context.Context, name, ownerID string) (*domain.Team, error)
+	GetTeam(ctx context.Context, id string) (*domain.Team, error)
+	ListTeams(ctx context.Context) ([]*domain.Team, error)
+	UpdateTeam(ctx context.Context, id, name string) (*domain.Team, error)
+	AddTeamMember(ctx context.Context, teamID, userID, role string) (*domain.TeamMember, error)
+	GetTeamMembers(ctx context.Context, teamID string) ([]*domain.TeamMember, error)
+	ListUserMemberships(ctx context.Context, userID string) ([]*domain.TeamMember, error)
+	RemoveTeamMember(ctx context.Context, teamID, userID string) error
+}
+
+type ServiceAccountUseCase interface {
+	CreateServiceAccount(ctx context.Context, name string) (*domain.User, error)
+	ListServiceAccounts(ctx context.Context) ([]*domain.User, error)
+	CreateAPIKey(ctx context.Context, serviceAccountID, name string, scopes []string) (*domain.APIKey, string, error)
+	ListAPIKeys(ctx context.Context, serviceAccountID string) ([]*domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, serviceAccountID, keyID string) error
+	VerifyAPIKey(ctx context.Context, key string) (*domain.APIKey, error)
+}
+
+type Server struct {
+	user_api.UnimplementedUserServiceServer
+	userUC           UserUseCase
+	teamUC           TeamUseCase
+	serviceAccountUC ServiceAccountUseCase
+}
+
+func NewServer(userUC UserUseCase, teamUC TeamUseCase, serviceAccountUC ServiceAccountUseCase) *Server {
+	return &Server{
+		userUC:           userUC,
+		teamUC:           teamUC,
+		serviceAccountUC: serviceAccountUC,
+	}
+}
+
+func (s *Server) CreateUser(ctx context.Context, req *user_api.CreateUserRequest) (*user_api.CreateUserResponse, error) {
+	if req.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	user, err := s.userUC.CreateUser(ctx, req.GetEmail(), req.GetName(), req.GetPassword())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &user_api.CreateUserResponse{User: toUserPB(user)}, nil
+}
+
+func (s *Server) Authenticate(ctx context.Context, req *user_api.AuthenticateRequest) (*user_api.AuthenticateResponse, error) {
+	if req.GetEmail() == "" || req.GetPassword() == "" {
+		return nil, status.Error(codes.Unauthenticated, domain.ErrInvalidCredentials.Error())
+	}
+
+	user, err := s.userUC.Authenticate(ctx, req.GetEmail(), req.GetPassword())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &user_api.AuthenticateResponse{User: toUserPB(user)}, nil
+}
+
+func (s *Server) GetUser(ctx context.Context, req *user_api.GetUserRequest) (*user_api.GetUserResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	user, err := s.userUC.GetUser(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &user_api.GetUserResponse{User: toUserPB(user)}, nil
+}
+
+func (s *Server) ListUsers(ctx context.Context, _ *user_api.ListUsersRequest) (*user_api.ListUsersResponse, error) {
+	users, err := s.userUC.ListUsers(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	result := make([]*models.User, 0, len(users))
+	for _, u := range users {
+		result = append(result, toUserPB(u))
+	}
+
+	return &user_api.ListUsersResponse{Users: result}, nil
+}
+
+func (s *Server) UpdateUser(ctx context.Context, req *user_api.UpdateUserRequest) (*user_api.UpdateUserResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	user, err := s.userUC.UpdateUser(ctx, req.GetId(), req.GetEmail(), req.GetName(), req.GetPassword(), req.GetCurrentPassword())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &user_api.UpdateUserResponse{User: toUserPB(user)}, nil
+}
+
+func (s *Server) CreateTeam(ctx context.Context, req *user_api.CreateTeamRequest) (*user_api.CreateTeamResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if req.GetOwnerId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "owner_id is required")
+	}
+
+	team, err := s.teamUC.CreateTeam(ctx, req.GetName(), req.GetOwnerId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &user_api.CreateTeamResponse{Team: toTeamPB(team)}, nil
+}
+
+func (s *Server) GetTeam(ctx context.Context, req *user_api.GetTeamRequest) (*user_api.GetTeamResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	team, err := s.teamUC.GetTeam(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &user_api.GetTeamResponse{Team: toTeamPB(team)}, nil
+}
+
+func (s *Server) ListTeams(ctx context.Context, _ *user_api.ListTeamsRequest) (*user_api.ListTeamsResponse, error) {
+	teams, err := s.teamUC.ListTeams(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	result := make([]*models.Team, 0, len(teams))
+	for _, t := range teams {
+		result = append(result, toTeamPB(t))
+	}
+
+	return &user_api.ListTeamsResponse{Teams: result}, nil
+}
+
+func (s *Server) UpdateTeam(ctx context.Context, req *user_api.UpdateTeamRequest) (*user_api.UpdateTeamResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	team, err := s.teamUC.UpdateTeam(ctx, req.GetId(), req.GetName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &user_api.UpdateTeamResponse{Team: toTeamPB(team)}, nil
+}
+
+func (s *Server) AddTeamMember(ctx context.Context, req *user_api.AddTeamMemberRequest) (*user_api.AddTeamMemberResponse, error) {
+	if req.GetTeamId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "team_id is required")
+	}
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	role := req.GetRole()
+	if role == "" {
+		role = defaultMemberRole
+	}
+
+	member, err := s.teamUC.AddTeamMember(ctx, req.GetTeamId(), req.GetUserId(), role)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &user_api.AddTeamMemberResponse{Member: toTeamMemberPB(member)}, nil
+}
+
+func (s *Server) ListUserMemberships(ctx context.Context, req *user_api.ListUserMembershipsRequest) (*user_api.ListUserMembershipsResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	memberships, err := s.teamUC.ListUserMemberships(ctx, req.GetUserId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	result := make([]*models.TeamMember, 0, len(memberships))
+	for _, m := range memberships {
+		result = append(result, toTeamMemberPB(m))
+	}
+
+	return &user_api.ListUserMembershipsResponse{Memberships: result}, nil
+}
+
+func (s *Server) GetTeamMembers(ctx context.Context, req *user_api.GetTeamMembersRequest) (*user_api.GetTeamMembersResponse, error) {
+	if req.GetTeamId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "team_id is required")
+	}
+
+	members, err := s.teamUC.GetTeamMembers(ctx, req.GetTeamId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	result := make([]*models.TeamMember, 0, len(members))
+	for _, m := range members {
+		result = append(result, toTeamMemberPB(m))
+	}
+
+	return &user_api.GetTeamMembersResponse{Members: result}, nil
+}
+
+func (s *Server) RemoveTeamMember(ctx context.Context, req *user_api.RemoveTeamMemberRequest) (*user_api.RemoveTeamMemberResponse, error) {
+	if req.GetTeamId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "team_id is required")
+	}
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	if err := s.teamUC.RemoveTeamMember(ctx, req.GetTeamId(), req.GetUserId()); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &user_api.RemoveTeamMemberResponse{Success: true}, nil
+}
+
+func (s *Server) CreateServiceAccount(ctx context.Context, req *user_api.CreateServiceAccountRequest) (*user_api.CreateServiceAccountResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	account, err := s.serviceAccountUC.CreateServiceAccount(ctx, req.GetName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &user_api.CreateServiceAccountResponse{ServiceAccount: toUserPB(account)}, nil
+}
+
+func (s *Server) ListServiceAccounts(ctx context.Context, _ *user_api.ListServiceAccountsRequest) (*user_api.ListServiceAccountsResponse, error) {
+	accounts, err := s.serviceAccountUC.ListServiceAccounts(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	result := make([]*models.User, 0, len(accounts))
+	for _, a := range accounts {
+		result = append(result, toUserPB(a))
+	}
+
+	return &user_api.ListServiceAccountsResponse{ServiceAccounts: result}, nil
+}
+
+func (s *Server) CreateAPIKey(ctx context.Context, req *user_api.CreateAPIKeyRequest) (*user_api.CreateAPIKeyResponse, error) {
+	if req.GetServiceAccountId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "service_account_id is required")
+	}
+
+	key, plaintext, err := s.serviceAccountUC.CreateAPIKey(ctx, req.GetServiceAccountId(), req.GetName(), req.GetScopes())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &user_api.CreateAPIKeyResponse{ApiKey: toAPIKeyPB(key), Key: plaintext}, nil
+}
+
+func (s *Server) ListAPIKeys(ctx context.Context, req *user_api.ListAPIKeysRequest) (*user_api.ListAPIKeysResponse, error) {
+	if req.GetServiceAccountId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "service_account_id is required")
+	}
+
+	keys, err := s.serviceAccountUC.ListAPIKeys(ctx, req.GetServiceAccountId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	result := make([]*models.APIKey, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, toAPIKeyPB(k))
+	}
+
+	return &user_api.ListAPIKeysResponse{ApiKeys: result}, nil
+}
+
+func (s *Server) RevokeAPIKey(ctx context.Context, req *user_api.RevokeAPIKeyRequest) (*user_api.RevokeAPIKeyResponse, error) {
+	if req.GetServiceAccountId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "service_account_id is required")
+	}
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	if err := s.serviceAccountUC.RevokeAPIKey(ctx, req.GetServiceAccountId(), req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &user_api.RevokeAPIKeyResponse{Success: true}, nil
+}
+
+func (s *Server) VerifyAPIKey(ctx context.Context, req *user_api.VerifyAPIKeyRequest) (*user_api.VerifyAPIKeyResponse, error) {
+	if req.GetKey() == "" {
+		return nil, status.Error(codes.Unauthenticated, domain.ErrInvalidCredentials.Error())
+	}
+
+	key, err := s.serviceAccountUC.VerifyAPIKey(ctx, req.GetKey())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &user_api.VerifyAPIKeyResponse{ApiKey: toAPIKeyPB(key)}, nil
+}
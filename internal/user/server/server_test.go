@@ -0,0 +1,433 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/pkg/actor"
+	"github.com/Sol1tud9/taskflow/internal/pb/user_api"
+	repoMocks "github.com/Sol1tud9/taskflow/internal/user/repository/mocks"
+	userServer "github.com/Sol1tud9/taskflow/internal/user/server"
+	userUsecase "github.com/Sol1tud9/taskflow/internal/user/usecase"
+	usecaseMocks "github.com/Sol1tud9/taskflow/internal/user/usecase/mocks"
+)
+
+const actorID = "actor-1"
+
+type UserServerSuite struct {
+	suite.Suite
+	ctx            context.Context
+	userRepo       *repoMocks.UserRepository
+	teamRepo       *repoMocks.TeamRepository
+	teamMemberRepo *repoMocks.TeamMemberRepository
+	apiKeyRepo     *repoMocks.APIKeyRepository
+	userPublisher  *usecaseMocks.EventPublisher
+	teamPublisher  *usecaseMocks.TeamEventPublisher
+	grpcServer     *grpc.Server
+	conn           *grpc.ClientConn
+	client         user_api.UserServiceClient
+}
+
+func (s *UserServerSuite) SetupTest() {
+	s.ctx = actor.WithUserID(context.Background(), actorID)
+	s.userRepo = repoMocks.NewUserRepository(s.T())
+	s.teamRepo = repoMocks.NewTeamRepository(s.T())
+	s.teamMemberRepo = repoMocks.NewTeamMemberRepository(s.T())
+	s.apiKeyRepo = repoMocks.NewAPIKeyRepository(s.T())
+	s.userPublisher = usecaseMocks.NewEventPublisher(s.T())
+	s.teamPublisher = usecaseMocks.NewTeamEventPublisher(s.T())
+
+	txManager := usecaseMocks.NewTxManager(s.T())
+	txManager.On("WithinTx", mock.Anything, mock.Anything).Return(runInTx)
+
+	userUC := userUsecase.NewUserUseCase(s.userRepo, s.userPublisher, txManager, usecaseMocks.NewSessionStore(s.T()))
+	teamUC := userUsecase.NewTeamUseCase(s.teamRepo, s.teamMemberRepo, s.teamPublisher, txManager)
+	accountUC := userUsecase.NewServiceAccountUseCase(s.userRepo, s.apiKeyRepo, s.userPublisher, txManager)
+
+	lis := bufconn.Listen(1024 * 1024)
+	s.grpcServer = grpc.NewServer(grpc.ChainUnaryInterceptor(actor.UnaryServerInterceptor()))
+	user_api.RegisterUserServiceServer(s.grpcServer, userServer.NewServer(userUC, teamUC, accountUC))
+	go func() {
+		_ = s.grpcServer.Serve(lis)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(actor.UnaryClientInterceptor()),
+	)
+	s.Require().NoError(err)
+	s.conn = conn
+	s.client = user_api.NewUserServiceClient(conn)
+}
+
+// actingAs makes the acting user a member of teamID with role.
+func (s *UserServerSuite) actingAs(teamID string, role domain.TeamRole) {
+	s.teamMemberRepo.On("Get", mock.Anything, teamID, actorID).Return(&domain.TeamMember{
+		TeamID: teamID,
+		UserID: actorID,
+		Role:   string(role),
+	}, nil)
+}
+
+// runInTx stands in for a real transaction by calling fn with the same context.
+func runInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (s *UserServerSuite) TearDownTest() {
+	_ = s.conn.Close()
+	s.grpcServer.Stop()
+}
+
+func (s *UserServerSuite) TestCreateUser_Success() {
+	email := "test@example.com"
+	name := "Test User"
+
+	s.userRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.Email == email && u.Name == name
+	})).Return(nil)
+	s.userPublisher.On("PublishUserCreated", mock.Anything, mock.Anything).Return(nil)
+
+	resp, err := s.client.CreateUser(s.ctx, &user_api.CreateUserRequest{Email: email, Name: name})
+
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), resp.GetUser().GetId())
+	assert.Equal(s.T(), email, resp.GetUser().GetEmail())
+	assert.NotZero(s.T(), resp.GetUser().GetCreatedAt())
+}
+
+func (s *UserServerSuite) TestCreateUser_MissingEmail() {
+	_, err := s.client.CreateUser(s.ctx, &user_api.CreateUserRequest{Name: "Test User"})
+
+	assert.Equal(s.T(), codes.InvalidArgument, status.Code(err))
+}
+
+func (s *UserServerSuite) TestCreateUser_AlreadyExists() {
+	s.userRepo.On("Create", mock.Anything, mock.Anything).Return(domain.ErrAlreadyExists)
+
+	_, err := s.client.CreateUser(s.ctx, &user_api.CreateUserRequest{Email: "test@example.com", Name: "Test User"})
+
+	assert.Equal(s.T(), codes.AlreadyExists, status.Code(err))
+}
+
+func (s *UserServerSuite) TestCreateUser_ShortPassword() {
+	_, err := s.client.CreateUser(s.ctx, &user_api.CreateUserRequest{Email: "test@example.com", Name: "Test User", Password: "short"})
+
+	assert.Equal(s.T(), codes.InvalidArgument, status.Code(err))
+}
+
+func (s *UserServerSuite) TestAuthenticate_Success() {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret password"), bcrypt.MinCost)
+	s.Require().NoError(err)
+	s.userRepo.On("GetByEmail", mock.Anything, "test@example.com").Return(&domain.User{
+		ID:           "user-1",
+		Email:        "test@example.com",
+		PasswordHash: string(hash),
+	}, nil)
+
+	resp, err := s.client.Authenticate(s.ctx, &user_api.AuthenticateRequest{Email: "test@example.com", Password: "secret password"})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "user-1", resp.GetUser().GetId())
+}
+
+func (s *UserServerSuite) TestAuthenticate_UnknownEmail() {
+	s.userRepo.On("GetByEmail", mock.Anything, "nobody@example.com").Return(nil, domain.ErrNotFound)
+
+	_, err := s.client.Authenticate(s.ctx, &user_api.AuthenticateRequest{Email: "nobody@example.com", Password: "secret password"})
+
+	assert.Equal(s.T(), codes.Unauthenticated, status.Code(err))
+}
+
+func (s *UserServerSuite) TestGetUser_Success() {
+	userID := uuid.New().String()
+	s.userRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{
+		ID:        userID,
+		Email:     "test@example.com",
+		Name:      "Test User",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil)
+
+	resp, err := s.client.GetUser(s.ctx, &user_api.GetUserRequest{Id: userID})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), userID, resp.GetUser().GetId())
+	assert.Equal(s.T(), "Test User", resp.GetUser().GetName())
+}
+
+func (s *UserServerSuite) TestGetUser_NotFound() {
+	userID := uuid.New().String()
+	s.userRepo.On("GetByID", mock.Anything, userID).Return(nil, domain.ErrNotFound)
+
+	_, err := s.client.GetUser(s.ctx, &user_api.GetUserRequest{Id: userID})
+
+	assert.Equal(s.T(), codes.NotFound, status.Code(err))
+}
+
+func (s *UserServerSuite) TestUpdateUser_Success() {
+	userID := actorID
+	s.userRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{
+		ID:    userID,
+		Email: "old@example.com",
+		Name:  "Old Name",
+	}, nil)
+	s.userRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.ID == userID && u.Name == "New Name" && u.Email == "old@example.com"
+	})).Return(nil)
+	s.userPublisher.On("PublishUserUpdated", mock.Anything, mock.Anything).Return(nil)
+
+	resp, err := s.client.UpdateUser(s.ctx, &user_api.UpdateUserRequest{Id: userID, Name: "New Name"})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "New Name", resp.GetUser().GetName())
+	assert.Equal(s.T(), "old@example.com", resp.GetUser().GetEmail())
+}
+
+func (s *UserServerSuite) TestUpdateUser_MissingID() {
+	_, err := s.client.UpdateUser(s.ctx, &user_api.UpdateUserRequest{Name: "New Name"})
+
+	assert.Equal(s.T(), codes.InvalidArgument, status.Code(err))
+}
+
+func (s *UserServerSuite) TestUpdateUser_OtherUser() {
+	_, err := s.client.UpdateUser(s.ctx, &user_api.UpdateUserRequest{Id: uuid.New().String(), Name: "New Name"})
+
+	assert.Equal(s.T(), codes.PermissionDenied, status.Code(err))
+}
+
+func (s *UserServerSuite) TestCreateTeam_Success() {
+	ownerID := uuid.New().String()
+
+	s.teamRepo.On("Create", mock.Anything, mock.MatchedBy(func(t *domain.Team) bool {
+		return t.Name == "Test Team" && t.OwnerID == ownerID
+	})).Return(nil)
+	s.teamMemberRepo.On("Add", mock.Anything, mock.MatchedBy(func(m *domain.TeamMember) bool {
+		return m.UserID == ownerID && m.Role == "owner"
+	})).Return(nil)
+	s.teamPublisher.On("PublishTeamCreated", mock.Anything, mock.Anything).Return(nil)
+
+	resp, err := s.client.CreateTeam(s.ctx, &user_api.CreateTeamRequest{Name: "Test Team", OwnerId: ownerID})
+
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), resp.GetTeam().GetId())
+	assert.Equal(s.T(), ownerID, resp.GetTeam().GetOwnerId())
+}
+
+func (s *UserServerSuite) TestCreateTeam_RepositoryError() {
+	s.teamRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("repository error"))
+
+	_, err := s.client.CreateTeam(s.ctx, &user_api.CreateTeamRequest{Name: "Test Team", OwnerId: uuid.New().String()})
+
+	assert.Equal(s.T(), codes.Internal, status.Code(err))
+}
+
+func (s *UserServerSuite) TestGetTeam_Success() {
+	teamID := uuid.New().String()
+	s.actingAs(teamID, domain.RoleViewer)
+	s.teamRepo.On("GetByID", mock.Anything, teamID).Return(&domain.Team{
+		ID:        teamID,
+		Name:      "Test Team",
+		OwnerID:   uuid.New().String(),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil)
+
+	resp, err := s.client.GetTeam(s.ctx, &user_api.GetTeamRequest{Id: teamID})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), teamID, resp.GetTeam().GetId())
+	assert.Equal(s.T(), "Test Team", resp.GetTeam().GetName())
+}
+
+func (s *UserServerSuite) TestGetTeam_NotFound() {
+	teamID := uuid.New().String()
+	s.actingAs(teamID, domain.RoleOwner)
+	s.teamRepo.On("GetByID", mock.Anything, teamID).Return(nil, domain.ErrNotFound)
+
+	_, err := s.client.GetTeam(s.ctx, &user_api.GetTeamRequest{Id: teamID})
+
+	assert.Equal(s.T(), codes.NotFound, status.Code(err))
+}
+
+func (s *UserServerSuite) TestAddTeamMember_DefaultRole() {
+	teamID := uuid.New().String()
+	userID := uuid.New().String()
+
+	s.actingAs(teamID, domain.RoleAdmin)
+	s.teamMemberRepo.On("Add", mock.Anything, mock.MatchedBy(func(m *domain.TeamMember) bool {
+		return m.TeamID == teamID && m.UserID == userID && m.Role == "member"
+	})).Return(nil)
+	s.teamPublisher.On("PublishTeamMemberAdded", mock.Anything, mock.Anything).Return(nil)
+
+	resp, err := s.client.AddTeamMember(s.ctx, &user_api.AddTeamMemberRequest{TeamId: teamID, UserId: userID})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "member", resp.GetMember().GetRole())
+	assert.NotZero(s.T(), resp.GetMember().GetJoinedAt())
+}
+
+func (s *UserServerSuite) TestAddTeamMember_AlreadyMember() {
+	teamID := uuid.New().String()
+	s.actingAs(teamID, domain.RoleOwner)
+	s.teamMemberRepo.On("Add", mock.Anything, mock.Anything).Return(domain.ErrAlreadyExists)
+
+	_, err := s.client.AddTeamMember(s.ctx, &user_api.AddTeamMemberRequest{
+		TeamId: teamID,
+		UserId: uuid.New().String(),
+		Role:   "admin",
+	})
+
+	assert.Equal(s.T(), codes.AlreadyExists, status.Code(err))
+}
+
+func (s *UserServerSuite) TestUpdateTeam_Success() {
+	teamID := uuid.New().String()
+	s.actingAs(teamID, domain.RoleAdmin)
+	s.teamRepo.On("GetByID", mock.Anything, teamID).Return(&domain.Team{
+		ID:      teamID,
+		Name:    "Old Name",
+		OwnerID: uuid.New().String(),
+	}, nil)
+	s.teamRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	s.teamPublisher.On("PublishTeamUpdated", mock.Anything, mock.Anything).Return(nil)
+
+	resp, err := s.client.UpdateTeam(s.ctx, &user_api.UpdateTeamRequest{Id: teamID, Name: "New Name"})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "New Name", resp.GetTeam().GetName())
+}
+
+func (s *UserServerSuite) TestRemoveTeamMember_NotFound() {
+	teamID := uuid.New().String()
+	userID := uuid.New().String()
+	s.actingAs(teamID, domain.RoleAdmin)
+	s.teamMemberRepo.On("Get", mock.Anything, teamID, userID).Return(nil, domain.ErrNotFound)
+
+	_, err := s.client.RemoveTeamMember(s.ctx, &user_api.RemoveTeamMemberRequest{
+		TeamId: teamID,
+		UserId: userID,
+	})
+
+	assert.Equal(s.T(), codes.NotFound, status.Code(err))
+}
+
+func (s *UserServerSuite) TestGetTeamMembers_Success() {
+	teamID := uuid.New().String()
+	s.actingAs(teamID, domain.RoleViewer)
+	s.teamMemberRepo.On("GetByTeamID", mock.Anything, teamID).Return([]*domain.TeamMember{
+		{ID: uuid.New().String(), TeamID: teamID, UserID: uuid.New().String(), Role: "owner", JoinedAt: time.Now()},
+		{ID: uuid.New().String(), TeamID: teamID, UserID: uuid.New().String(), Role: "member", JoinedAt: time.Now()},
+	}, nil)
+
+	resp, err := s.client.GetTeamMembers(s.ctx, &user_api.GetTeamMembersRequest{TeamId: teamID})
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), resp.GetMembers(), 2)
+	assert.Equal(s.T(), "owner", resp.GetMembers()[0].GetRole())
+}
+
+func (s *UserServerSuite) TestGetTeamMembers_MissingTeamID() {
+	_, err := s.client.GetTeamMembers(s.ctx, &user_api.GetTeamMembersRequest{})
+
+	assert.Equal(s.T(), codes.InvalidArgument, status.Code(err))
+}
+
+func (s *UserServerSuite) TestGetTeam_NotMember() {
+	teamID := uuid.New().String()
+	s.teamMemberRepo.On("Get", mock.Anything, teamID, actorID).Return(nil, domain.ErrNotFound)
+
+	_, err := s.client.GetTeam(s.ctx, &user_api.GetTeamRequest{Id: teamID})
+
+	assert.Equal(s.T(), codes.PermissionDenied, status.Code(err))
+}
+
+func (s *UserServerSuite) TestUpdateTeam_ViewerForbidden() {
+	teamID := uuid.New().String()
+	s.actingAs(teamID, domain.RoleViewer)
+
+	_, err := s.client.UpdateTeam(s.ctx, &user_api.UpdateTeamRequest{Id: teamID, Name: "New Name"})
+
+	assert.Equal(s.T(), codes.PermissionDenied, status.Code(err))
+}
+
+func (s *UserServerSuite) TestGetTeam_WithoutActor() {
+	_, err := s.client.GetTeam(context.Background(), &user_api.GetTeamRequest{Id: uuid.New().String()})
+
+	assert.Equal(s.T(), codes.Unauthenticated, status.Code(err))
+}
+
+func (s *UserServerSuite) TestListUserMemberships_Success() {
+	teamID := uuid.New().String()
+	s.teamMemberRepo.On("GetByUserID", mock.Anything, actorID).Return([]*domain.TeamMember{
+		{ID: uuid.New().String(), TeamID: teamID, UserID: actorID, Role: "admin", JoinedAt: time.Now()},
+	}, nil)
+
+	resp, err := s.client.ListUserMemberships(s.ctx, &user_api.ListUserMembershipsRequest{UserId: actorID})
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), resp.GetMemberships(), 1)
+	assert.Equal(s.T(), teamID, resp.GetMemberships()[0].GetTeamId())
+	assert.Equal(s.T(), "admin", resp.GetMemberships()[0].GetRole())
+}
+
+func (s *UserServerSuite) TestCreateAPIKey_ReturnsKeyOnce() {
+	accountID := uuid.New().String()
+	s.userRepo.On("GetByID", mock.Anything, accountID).Return(&domain.User{ID: accountID, OwnerID: actorID}, nil)
+	s.apiKeyRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	resp, err := s.client.CreateAPIKey(s.ctx, &user_api.CreateAPIKeyRequest{
+		ServiceAccountId: accountID,
+		Name:             "ci",
+		Scopes:           []string{domain.ScopeTasksRead},
+	})
+
+	s.Require().NoError(err)
+	assert.True(s.T(), strings.HasPrefix(resp.GetKey(), "tf_"+resp.GetApiKey().GetPrefix()+"_"))
+	assert.Equal(s.T(), []string{domain.ScopeTasksRead}, resp.GetApiKey().GetScopes())
+	assert.Zero(s.T(), resp.GetApiKey().GetRevokedAt())
+}
+
+func (s *UserServerSuite) TestCreateAPIKey_NotOwner() {
+	accountID := uuid.New().String()
+	s.userRepo.On("GetByID", mock.Anything, accountID).Return(&domain.User{ID: accountID, OwnerID: uuid.New().String()}, nil)
+
+	_, err := s.client.CreateAPIKey(s.ctx, &user_api.CreateAPIKeyRequest{
+		ServiceAccountId: accountID,
+		Name:             "ci",
+		Scopes:           []string{domain.ScopeTasksRead},
+	})
+
+	assert.Equal(s.T(), codes.PermissionDenied, status.Code(err))
+}
+
+func (s *UserServerSuite) TestVerifyAPIKey_Unknown() {
+	s.apiKeyRepo.On("GetByPrefix", mock.Anything, "abc").Return(nil, domain.ErrNotFound)
+
+	_, err := s.client.VerifyAPIKey(context.Background(), &user_api.VerifyAPIKeyRequest{Key: "tf_abc_secret"})
+
+	assert.Equal(s.T(), codes.Unauthenticated, status.Code(err))
+}
+
+func TestUserServerSuite(t *testing.T) {
+	suite.Run(t, new(UserServerSuite))
+}
@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+	"github.com/Sol1tud9/taskflow/internal/domain"
+)
+
+// lastUsedResolution is how stale api_keys.last_used_at may get; touching it
+// at most once per interval keeps busy keys from writing on every request.
+const lastUsedResolution = time.Minute
+
+var apiKeyColumns = []string{"id", "user_id", "name", "prefix", "key_hash", "scopes", "created_at", "last_used_at", "revoked_at"}
+
+func (s *Storage) CreateAPIKey(ctx context.Context, key *domain.APIKey) error {
+	query := squirrel.Insert("api_keys").
+		Columns("id", "user_id", "name", "prefix", "key_hash", "scopes", "created_at").
+		Values(key.ID, key.UserID, key.Name, key.Prefix, key.Hash, key.Scopes, key.CreatedAt).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	if _, err := s.conn(ctx).Exec(ctx, sql, args...); err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrAlreadyExists
+		}
+		return errors.Wrap(err, "failed to create api key")
+	}
+
+	return nil
+}
+
+// GetAPIKeyByPrefix returns the key with the given prefix, revoked or not.
+func (s *Storage) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	query := squirrel.Select(apiKeyColumns...).
+		From("api_keys").
+		Where(squirrel.Eq{"prefix": prefix}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	key, err := scanAPIKey(s.conn(ctx).QueryRow(ctx, sql, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get api key")
+	}
+
+	return key, nil
+}
+
+func (s *Storage) ListAPIKeysByUserID(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	query := squirrel.Select(apiKeyColumns...).
+		From("api_keys").
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("created_at DESC").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	rows, err := s.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list api keys")
+	}
+	defer rows.Close()
+
+	var keys []*domain.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan api key")
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey marks the user's key revoked. Revoking a key twice keeps the
+// first revocation time.
+func (s *Storage) RevokeAPIKey(ctx context.Context, userID, id string, at time.Time) error {
+	query := squirrel.Update("api_keys").
+		Set("revoked_at", squirrel.Expr("COALESCE(revoked_at, ?)", at)).
+		Where(squirrel.Eq{"id": id, "user_id": userID}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	tag, err := s.conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to revoke api key")
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// TouchAPIKey records that the key was used at the given time.
+func (s *Storage) TouchAPIKey(ctx context.Context, id string, at time.Time) error {
+	query := squirrel.Update("api_keys").
+		Set("last_used_at", at).
+		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Or{
+			squirrel.Eq{"last_used_at": nil},
+			squirrel.Lt{"last_used_at": at.Add(-lastUsedResolution)},
+		}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	if _, err := s.conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return errors.Wrap(err, "failed to touch api key")
+	}
+
+	return nil
+}
+
+func scanAPIKey(row pgx.Row) (*domain.APIKey, error) {
+	var key domain.APIKey
+	if err := row.Scan(
+		&key.ID, &key.UserID, &key.Name, &key.Prefix, &key.Hash, &key.Scopes,
+		&key.CreatedAt, &key.LastUsedAt, &key.RevokedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pkg/errors"
+	"github.com/Sol1tud9/taskflow/migrations"
 	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/migrate"
+	"github.com/Sol1tud9/taskflow/pkg/pgtx"
 )
 
 type Storage struct {
@@ -28,46 +32,31 @@ func NewStorage(cfg config.DatabaseConfig) (*Storage, error) {
 	}
 
 	storage := &Storage{db: db}
-	if err := storage.initTables(); err != nil {
-		return nil, err
+	if err := migrate.Up(context.Background(), db, migrations.User); err != nil {
+		return nil, errors.Wrap(err, "failed to migrate database")
 	}
 
 	return storage, nil
 }
 
-func (s *Storage) initTables() error {
-	queries := []string{
-		`CREATE TABLE IF NOT EXISTS users (
-			id VARCHAR(36) PRIMARY KEY,
-			email VARCHAR(255) UNIQUE NOT NULL,
-			name VARCHAR(255) NOT NULL,
-			created_at TIMESTAMP NOT NULL,
-			updated_at TIMESTAMP NOT NULL
-		)`,
-		`CREATE TABLE IF NOT EXISTS teams (
-			id VARCHAR(36) PRIMARY KEY,
-			name VARCHAR(255) NOT NULL,
-			owner_id VARCHAR(36) NOT NULL,
-			created_at TIMESTAMP NOT NULL,
-			updated_at TIMESTAMP NOT NULL
-		)`,
-		`CREATE TABLE IF NOT EXISTS team_members (
-			id VARCHAR(36) PRIMARY KEY,
-			team_id VARCHAR(36) NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
-			user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-			role VARCHAR(50) NOT NULL,
-			joined_at TIMESTAMP NOT NULL,
-			UNIQUE(team_id, user_id)
-		)`,
-	}
+// isUniqueViolation reports whether err is a Postgres unique_violation (23505).
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
 
-	for _, query := range queries {
-		if _, err := s.db.Exec(context.Background(), query); err != nil {
-			return errors.Wrap(err, "failed to create table")
-		}
-	}
+// WithinTx runs fn in a transaction that repository calls made with the
+// returned context join.
+func (s *Storage) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return pgtx.WithinTx(ctx, s.db, fn)
+}
+
+func (s *Storage) conn(ctx context.Context) pgtx.Querier {
+	return pgtx.Conn(ctx, s.db)
+}
 
-	return nil
+func (s *Storage) Pool() *pgxpool.Pool {
+	return s.db
 }
 
 func (s *Storage) Close() {
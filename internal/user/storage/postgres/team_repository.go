@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
 	"github.com/pkg/errors"
 	"github.com/Sol1tud9/taskflow/internal/domain"
 )
@@ -19,7 +20,7 @@ func (s *Storage) CreateTeam(ctx context.Context, team *domain.Team) error {
 		return errors.Wrap(err, "failed to build query")
 	}
 
-	if _, err := s.db.Exec(ctx, sql, args...); err != nil {
+	if _, err := s.conn(ctx).Exec(ctx, sql, args...); err != nil {
 		return errors.Wrap(err, "failed to create team")
 	}
 
@@ -38,9 +39,12 @@ func (s *Storage) GetTeamByID(ctx context.Context, id string) (*domain.Team, err
 	}
 
 	var team domain.Team
-	err = s.db.QueryRow(ctx, sql, args...).Scan(
+	err = s.conn(ctx).QueryRow(ctx, sql, args...).Scan(
 		&team.ID, &team.Name, &team.OwnerID, &team.CreatedAt, &team.UpdatedAt,
 	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get team")
 	}
@@ -61,9 +65,13 @@ func (s *Storage) UpdateTeam(ctx context.Context, team *domain.Team) error {
 		return errors.Wrap(err, "failed to build query")
 	}
 
-	if _, err := s.db.Exec(ctx, sql, args...); err != nil {
+	tag, err := s.conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
 		return errors.Wrap(err, "failed to update team")
 	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
 
 	return nil
 }
@@ -78,7 +86,7 @@ func (s *Storage) DeleteTeam(ctx context.Context, id string) error {
 		return errors.Wrap(err, "failed to build query")
 	}
 
-	if _, err := s.db.Exec(ctx, sql, args...); err != nil {
+	if _, err := s.conn(ctx).Exec(ctx, sql, args...); err != nil {
 		return errors.Wrap(err, "failed to delete team")
 	}
 
@@ -96,7 +104,10 @@ func (s *Storage) AddTeamMember(ctx context.Context, member *domain.TeamMember)
 		return errors.Wrap(err, "failed to build query")
 	}
 
-	if _, err := s.db.Exec(ctx, sql, args...); err != nil {
+	if _, err := s.conn(ctx).Exec(ctx, sql, args...); err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrAlreadyExists
+		}
 		return errors.Wrap(err, "failed to add team member")
 	}
 
@@ -114,7 +125,7 @@ func (s *Storage) GetTeamMembersByTeamID(ctx context.Context, teamID string) ([]
 		return nil, errors.Wrap(err, "failed to build query")
 	}
 
-	rows, err := s.db.Query(ctx, sql, args...)
+	rows, err := s.conn(ctx).Query(ctx, sql, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get team members")
 	}
@@ -132,6 +143,60 @@ func (s *Storage) GetTeamMembersByTeamID(ctx context.Context, teamID string) ([]
 	return members, nil
 }
 
+// GetTeamMember returns userID's membership in teamID, or
+// domain.ErrNotFound if the user is not a member.
+func (s *Storage) GetTeamMember(ctx context.Context, teamID, userID string) (*domain.TeamMember, error) {
+	query := squirrel.Select("id", "team_id", "user_id", "role", "joined_at").
+		From("team_members").
+		Where(squirrel.Eq{"team_id": teamID, "user_id": userID}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	var m domain.TeamMember
+	err = s.conn(ctx).QueryRow(ctx, sql, args...).Scan(&m.ID, &m.TeamID, &m.UserID, &m.Role, &m.JoinedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get team member")
+	}
+
+	return &m, nil
+}
+
+func (s *Storage) GetTeamMembersByUserID(ctx context.Context, userID string) ([]*domain.TeamMember, error) {
+	query := squirrel.Select("id", "team_id", "user_id", "role", "joined_at").
+		From("team_members").
+		Where(squirrel.Eq{"user_id": userID}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	rows, err := s.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user memberships")
+	}
+	defer rows.Close()
+
+	var members []*domain.TeamMember
+	for rows.Next() {
+		var m domain.TeamMember
+		if err := rows.Scan(&m.ID, &m.TeamID, &m.UserID, &m.Role, &m.JoinedAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan team member")
+		}
+		members = append(members, &m)
+	}
+
+	return members, nil
+}
+
 func (s *Storage) RemoveTeamMember(ctx context.Context, teamID, userID string) error {
 	query := squirrel.Delete("team_members").
 		Where(squirrel.And{
@@ -145,9 +210,13 @@ func (s *Storage) RemoveTeamMember(ctx context.Context, teamID, userID string) e
 		return errors.Wrap(err, "failed to build query")
 	}
 
-	if _, err := s.db.Exec(ctx, sql, args...); err != nil {
+	tag, err := s.conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
 		return errors.Wrap(err, "failed to remove team member")
 	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
 
 	return nil
 }
@@ -164,7 +233,7 @@ func (s *Storage) ListTeams(ctx context.Context) ([]*domain.Team, error) {
 		return nil, errors.Wrap(err, "failed to build query")
 	}
 
-	rows, err := s.db.Query(ctx, sql, args...)
+	rows, err := s.conn(ctx).Query(ctx, sql, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list teams")
 	}
@@ -182,3 +251,35 @@ func (s *Storage) ListTeams(ctx context.Context) ([]*domain.Team, error) {
 	return teams, nil
 }
 
+// ListTeamsByMember lists the teams userID belongs to.
+func (s *Storage) ListTeamsByMember(ctx context.Context, userID string) ([]*domain.Team, error) {
+	query := squirrel.Select("t.id", "t.name", "t.owner_id", "t.created_at", "t.updated_at").
+		From("teams t").
+		Join("team_members m ON m.team_id = t.id").
+		Where(squirrel.Eq{"m.user_id": userID}).
+		OrderBy("t.created_at DESC").
+		Limit(100).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	rows, err := s.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list teams")
+	}
+	defer rows.Close()
+
+	var teams []*domain.Team
+	for rows.Next() {
+		var t domain.Team
+		if err := rows.Scan(&t.ID, &t.Name, &t.OwnerID, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan team")
+		}
+		teams = append(teams, &t)
+	}
+
+	return teams, nil
+}
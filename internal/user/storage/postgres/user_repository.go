@@ -4,14 +4,19 @@ import (
 	"context"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
 	"github.com/pkg/errors"
 	"github.com/Sol1tud9/taskflow/internal/domain"
 )
 
 func (s *Storage) Create(ctx context.Context, user *domain.User) error {
 	query := squirrel.Insert("users").
-		Columns("id", "email", "name", "created_at", "updated_at").
-		Values(user.ID, user.Email, user.Name, user.CreatedAt, user.UpdatedAt).
+		Columns("id", "email", "name", "password_hash", "owner_id", "created_at", "updated_at").
+		Values(
+			user.ID, user.Email, user.Name,
+			squirrel.Expr("NULLIF(?, '')", user.PasswordHash), squirrel.Expr("NULLIF(?, '')", user.OwnerID),
+			user.CreatedAt, user.UpdatedAt,
+		).
 		PlaceholderFormat(squirrel.Dollar)
 
 	sql, args, err := query.ToSql()
@@ -19,7 +24,10 @@ func (s *Storage) Create(ctx context.Context, user *domain.User) error {
 		return errors.Wrap(err, "failed to build query")
 	}
 
-	if _, err := s.db.Exec(ctx, sql, args...); err != nil {
+	if _, err := s.conn(ctx).Exec(ctx, sql, args...); err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrAlreadyExists
+		}
 		return errors.Wrap(err, "failed to create user")
 	}
 
@@ -27,7 +35,7 @@ func (s *Storage) Create(ctx context.Context, user *domain.User) error {
 }
 
 func (s *Storage) GetByID(ctx context.Context, id string) (*domain.User, error) {
-	query := squirrel.Select("id", "email", "name", "created_at", "updated_at").
+	query := squirrel.Select("id", "email", "name", "COALESCE(owner_id, '')", "created_at", "updated_at").
 		From("users").
 		Where(squirrel.Eq{"id": id}).
 		PlaceholderFormat(squirrel.Dollar)
@@ -38,9 +46,39 @@ func (s *Storage) GetByID(ctx context.Context, id string) (*domain.User, error)
 	}
 
 	var user domain.User
-	err = s.db.QueryRow(ctx, sql, args...).Scan(
-		&user.ID, &user.Email, &user.Name, &user.CreatedAt, &user.UpdatedAt,
+	err = s.conn(ctx).QueryRow(ctx, sql, args...).Scan(
+		&user.ID, &user.Email, &user.Name, &user.OwnerID, &user.CreatedAt, &user.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+
+	return &user, nil
+}
+
+// GetByEmail returns the user with the given email together with its
+// password hash, which is empty for users that have no password set.
+func (s *Storage) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	query := squirrel.Select("id", "email", "name", "COALESCE(owner_id, '')", "COALESCE(password_hash, '')", "created_at", "updated_at").
+		From("users").
+		Where(squirrel.Eq{"email": email}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	var user domain.User
+	err = s.conn(ctx).QueryRow(ctx, sql, args...).Scan(
+		&user.ID, &user.Email, &user.Name, &user.OwnerID, &user.PasswordHash, &user.CreatedAt, &user.UpdatedAt,
 	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get user")
 	}
@@ -55,15 +93,22 @@ func (s *Storage) Update(ctx context.Context, user *domain.User) error {
 		Set("updated_at", user.UpdatedAt).
 		Where(squirrel.Eq{"id": user.ID}).
 		PlaceholderFormat(squirrel.Dollar)
+	if user.PasswordHash != "" {
+		query = query.Set("password_hash", user.PasswordHash)
+	}
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return errors.Wrap(err, "failed to build query")
 	}
 
-	if _, err := s.db.Exec(ctx, sql, args...); err != nil {
+	tag, err := s.conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
 		return errors.Wrap(err, "failed to update user")
 	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
 
 	return nil
 }
@@ -78,7 +123,7 @@ func (s *Storage) Delete(ctx context.Context, id string) error {
 		return errors.Wrap(err, "failed to build query")
 	}
 
-	if _, err := s.db.Exec(ctx, sql, args...); err != nil {
+	if _, err := s.conn(ctx).Exec(ctx, sql, args...); err != nil {
 		return errors.Wrap(err, "failed to delete user")
 	}
 
@@ -86,7 +131,7 @@ func (s *Storage) Delete(ctx context.Context, id string) error {
 }
 
 func (s *Storage) List(ctx context.Context) ([]*domain.User, error) {
-	query := squirrel.Select("id", "email", "name", "created_at", "updated_at").
+	query := squirrel.Select("id", "email", "name", "COALESCE(owner_id, '')", "created_at", "updated_at").
 		From("users").
 		OrderBy("created_at DESC").
 		Limit(100).
@@ -97,7 +142,27 @@ func (s *Storage) List(ctx context.Context) ([]*domain.User, error) {
 		return nil, errors.Wrap(err, "failed to build query")
 	}
 
-	rows, err := s.db.Query(ctx, sql, args...)
+	return s.queryUsers(ctx, sql, args)
+}
+
+// ListByOwner returns the service accounts managed by ownerID.
+func (s *Storage) ListByOwner(ctx context.Context, ownerID string) ([]*domain.User, error) {
+	query := squirrel.Select("id", "email", "name", "COALESCE(owner_id, '')", "created_at", "updated_at").
+		From("users").
+		Where(squirrel.Eq{"owner_id": ownerID}).
+		OrderBy("created_at DESC").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	return s.queryUsers(ctx, sql, args)
+}
+
+func (s *Storage) queryUsers(ctx context.Context, sql string, args []interface{}) ([]*domain.User, error) {
+	rows, err := s.conn(ctx).Query(ctx, sql, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list users")
 	}
@@ -106,7 +171,7 @@ func (s *Storage) List(ctx context.Context) ([]*domain.User, error) {
 	var users []*domain.User
 	for rows.Next() {
 		var u domain.User
-		if err := rows.Scan(&u.ID, &u.Email, &u.Name, &u.CreatedAt, &u.UpdatedAt); err != nil {
+		if err := rows.Scan(&u.ID, &u.Email, &u.Name, &u.OwnerID, &u.CreatedAt, &u.UpdatedAt); err != nil {
 			return nil, errors.Wrap(err, "failed to scan user")
 		}
 		users = append(users, &u)
@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type SessionStore struct {
+	mock.Mock
+}
+
+func NewSessionStore(t testing.TB) *SessionStore {
+	mock := &SessionStore{}
+	mock.Mock.Test(t)
+	return mock
+}
+
+func (m *SessionStore) DeleteUser(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -18,8 +18,22 @@ func NewTeamEventPublisher(t testing.TB) *TeamEventPublisher {
 	return mock
 }
 
+func (m *TeamEventPublisher) PublishTeamCreated(ctx context.Context, event domain.TeamCreatedEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
 func (m *TeamEventPublisher) PublishTeamUpdated(ctx context.Context, event domain.TeamUpdatedEvent) error {
 	args := m.Called(ctx, event)
 	return args.Error(0)
 }
 
+func (m *TeamEventPublisher) PublishTeamMemberAdded(ctx context.Context, event domain.TeamMemberAddedEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *TeamEventPublisher) PublishTeamMemberRemoved(ctx context.Context, event domain.TeamMemberRemovedEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
\ No newline at end of file
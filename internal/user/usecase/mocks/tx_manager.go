@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type TxManager struct {
+	mock.Mock
+}
+
+func NewTxManager(t testing.TB) *TxManager {
+	mock := &TxManager{}
+	mock.Mock.Test(t)
+	return mock
+}
+
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	args := m.Called(ctx, fn)
+	if rf, ok := args.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		return rf(ctx, fn)
+	}
+	return args.Error(0)
+}
+
@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/pkg/actor"
+)
+
+// API keys look like "tf_<prefix>_<secret>". The prefix is stored in the
+// clear to find the key; the secret only goes into the stored hash.
+const (
+	apiKeyTag          = "tf"
+	apiKeyPrefixBytes  = 6
+	apiKeySecretBytes  = 32
+	serviceAccountHost = "service-accounts.invalid"
+)
+
+type ServiceAccountRepository interface {
+	Create(ctx context.Context, user *domain.User) error
+	GetByID(ctx context.Context, id string) (*domain.User, error)
+	ListByOwner(ctx context.Context, ownerID string) ([]*domain.User, error)
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *domain.APIKey) error
+	// GetByPrefix returns domain.ErrNotFound if no key has the prefix.
+	GetByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error)
+	ListByUserID(ctx context.Context, userID string) ([]*domain.APIKey, error)
+	Revoke(ctx context.Context, userID, id string, at time.Time) error
+	// Touch records a use of the key; it may skip the write if the key was
+	// used recently.
+	Touch(ctx context.Context, id string, at time.Time) error
+}
+
+// ServiceAccountUseCase manages service accounts and their API keys. A
+// service account belongs to the user who created it, and only that user
+// can manage it. Service accounts act through API keys with the team roles
+// they are given like any other user.
+type ServiceAccountUseCase struct {
+	userRepo   ServiceAccountRepository
+	apiKeyRepo APIKeyRepository
+	publisher  EventPublisher
+	txManager  TxManager
+	now        func() time.Time
+}
+
+func NewServiceAccountUseCase(
+	userRepo ServiceAccountRepository,
+	apiKeyRepo APIKeyRepository,
+	publisher EventPublisher,
+	txManager TxManager,
+) *ServiceAccountUseCase {
+	return &ServiceAccountUseCase{
+		userRepo:   userRepo,
+		apiKeyRepo: apiKeyRepo,
+		publisher:  publisher,
+		txManager:  txManager,
+		now:        time.Now,
+	}
+}
+
+// CreateServiceAccount creates a service account owned by the acting user.
+// Service accounts cannot create service accounts.
+func (uc *ServiceAccountUseCase) CreateServiceAccount(ctx context.Context, name string) (*domain.User, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, &domain.ValidationError{Fields: []domain.FieldError{{Field: "name", Message: "name is required"}}}
+	}
+
+	ownerID, err := uc.humanActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	id := uuid.New().String()
+	account := &domain.User{
+		ID: id,
+		// Users need a unique email; service accounts get one under a
+		// reserved domain that no mail is delivered to.
+		Email:     fmt.Sprintf("%s@%s", id, serviceAccountHost),
+		Name:      name,
+		OwnerID:   ownerID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.userRepo.Create(ctx, account); err != nil {
+			return err
+		}
+
+		return uc.publisher.PublishUserCreated(ctx, domain.UserCreatedEvent{
+			EventID:   uuid.New().String(),
+			UserID:    account.ID,
+			Email:     account.Email,
+			Name:      account.Name,
+			CreatedAt: account.CreatedAt,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// ListServiceAccounts returns the acting user's service accounts.
+func (uc *ServiceAccountUseCase) ListServiceAccounts(ctx context.Context) ([]*domain.User, error) {
+	ownerID, ok := actor.UserID(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthenticated
+	}
+	return uc.userRepo.ListByOwner(ctx, ownerID)
+}
+
+// CreateAPIKey issues a key for the service account and returns it with the
+// key itself, which is not stored and cannot be shown again.
+func (uc *ServiceAccountUseCase) CreateAPIKey(ctx context.Context, serviceAccountID, name string, scopes []string) (*domain.APIKey, string, error) {
+	if err := validateAPIKey(name, scopes); err != nil {
+		return nil, "", err
+	}
+	if err := uc.authorizeOwner(ctx, serviceAccountID); err != nil {
+		return nil, "", err
+	}
+
+	prefix, err := randomBytes(apiKeyPrefixBytes)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := randomBytes(apiKeySecretBytes)
+	if err != nil {
+		return nil, "", err
+	}
+	// The prefix is hex so that it never contains the "_" separator.
+	plaintext := fmt.Sprintf("%s_%s_%s", apiKeyTag, hex.EncodeToString(prefix), base64.RawURLEncoding.EncodeToString(secret))
+
+	key := &domain.APIKey{
+		ID:        uuid.New().String(),
+		UserID:    serviceAccountID,
+		Name:      name,
+		Prefix:    hex.EncodeToString(prefix),
+		Scopes:    scopes,
+		CreatedAt: uc.now(),
+		Hash:      hashAPIKey(plaintext),
+	}
+	if err := uc.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, "", err
+	}
+
+	return key, plaintext, nil
+}
+
+func (uc *ServiceAccountUseCase) ListAPIKeys(ctx context.Context, serviceAccountID string) ([]*domain.APIKey, error) {
+	if err := uc.authorizeOwner(ctx, serviceAccountID); err != nil {
+		return nil, err
+	}
+	return uc.apiKeyRepo.ListByUserID(ctx, serviceAccountID)
+}
+
+// RevokeAPIKey stops the key from authenticating. Revoked keys stay listed.
+func (uc *ServiceAccountUseCase) RevokeAPIKey(ctx context.Context, serviceAccountID, keyID string) error {
+	if err := uc.authorizeOwner(ctx, serviceAccountID); err != nil {
+		return err
+	}
+	return uc.apiKeyRepo.Revoke(ctx, serviceAccountID, keyID, uc.now())
+}
+
+// VerifyAPIKey returns the active key matching plaintext and records its
+// use. Malformed, unknown and revoked keys all yield
+// domain.ErrInvalidCredentials.
+func (uc *ServiceAccountUseCase) VerifyAPIKey(ctx context.Context, plaintext string) (*domain.APIKey, error) {
+	tag, rest, _ := strings.Cut(plaintext, "_")
+	prefix, _, ok := strings.Cut(rest, "_")
+	if tag != apiKeyTag || !ok || prefix == "" {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	key, err := uc.apiKeyRepo.GetByPrefix(ctx, prefix)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(key.Hash), []byte(hashAPIKey(plaintext))) != 1 {
+		return nil, domain.ErrInvalidCredentials
+	}
+	if key.RevokedAt != nil {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	// The last-used time is informational; failing to record it must not
+	// fail the request the key authenticates.
+	now := uc.now()
+	if err := uc.apiKeyRepo.Touch(ctx, key.ID, now); err == nil {
+		key.LastUsedAt = &now
+	}
+
+	return key, nil
+}
+
+// humanActor returns the acting user, who must not be a service account.
+func (uc *ServiceAccountUseCase) humanActor(ctx context.Context) (string, error) {
+	userID, ok := actor.UserID(ctx)
+	if !ok {
+		return "", domain.ErrUnauthenticated
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if user.IsServiceAccount() {
+		return "", domain.ErrForbidden
+	}
+
+	return userID, nil
+}
+
+// authorizeOwner checks that id is a service account owned by the acting
+// user.
+func (uc *ServiceAccountUseCase) authorizeOwner(ctx context.Context, id string) error {
+	ownerID, ok := actor.UserID(ctx)
+	if !ok {
+		return domain.ErrUnauthenticated
+	}
+
+	account, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if account.OwnerID != ownerID {
+		return domain.ErrForbidden
+	}
+
+	return nil
+}
+
+func validateAPIKey(name string, scopes []string) error {
+	verr := &domain.ValidationError{}
+	if strings.TrimSpace(name) == "" {
+		verr.Add("name", "name is required")
+	}
+	if len(scopes) == 0 {
+		verr.Add("scopes", "at least one scope is required")
+	}
+	for _, scope := range scopes {
+		if !isAPIKeyScope(scope) {
+			verr.Add("scopes", fmt.Sprintf("unknown scope %q; use %s", scope, strings.Join(domain.APIKeyScopes, ", ")))
+		}
+	}
+	return verr.Err()
+}
+
+func isAPIKeyScope(scope string) bool {
+	for _, s := range domain.APIKeyScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, errors.Wrap(err, "failed to generate api key")
+	}
+	return b, nil
+}
+
+// hashAPIKey hashes a key for storage. Keys carry 256 bits of randomness, so
+// a fast hash is enough and keeps verification cheap on every request.
+func hashAPIKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
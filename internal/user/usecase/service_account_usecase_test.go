@@ -0,0 +1,179 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/Sol1tud9/taskflow/internal/domain"
+	repoMocks "github.com/Sol1tud9/taskflow/internal/user/repository/mocks"
+	userUsecase "github.com/Sol1tud9/taskflow/internal/user/usecase"
+	usecaseMocks "github.com/Sol1tud9/taskflow/internal/user/usecase/mocks"
+	"github.com/Sol1tud9/taskflow/pkg/actor"
+)
+
+type ServiceAccountUseCaseSuite struct {
+	suite.Suite
+	ctx        context.Context
+	userRepo   *repoMocks.UserRepository
+	apiKeyRepo *repoMocks.APIKeyRepository
+	publisher  *usecaseMocks.EventPublisher
+	useCase    *userUsecase.ServiceAccountUseCase
+	accountID  string
+}
+
+func (s *ServiceAccountUseCaseSuite) SetupTest() {
+	s.ctx = actor.WithUserID(context.Background(), actorID)
+	s.userRepo = repoMocks.NewUserRepository(s.T())
+	s.apiKeyRepo = repoMocks.NewAPIKeyRepository(s.T())
+	s.publisher = usecaseMocks.NewEventPublisher(s.T())
+	txManager := usecaseMocks.NewTxManager(s.T())
+	txManager.On("WithinTx", s.ctx, mock.Anything).Return(runInTx)
+	s.useCase = userUsecase.NewServiceAccountUseCase(s.userRepo, s.apiKeyRepo, s.publisher, txManager)
+
+	s.accountID = uuid.New().String()
+	s.userRepo.On("GetByID", s.ctx, s.accountID).Return(&domain.User{ID: s.accountID, OwnerID: actorID}, nil).Maybe()
+}
+
+// createKey issues a key for s.accountID and returns it with the stored
+// record.
+func (s *ServiceAccountUseCaseSuite) createKey(scopes ...string) (string, *domain.APIKey) {
+	var stored *domain.APIKey
+	s.apiKeyRepo.On("Create", s.ctx, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*domain.APIKey)
+	}).Return(nil).Once()
+
+	key, plaintext, err := s.useCase.CreateAPIKey(s.ctx, s.accountID, "ci", scopes)
+	s.Require().NoError(err)
+	s.Require().Same(stored, key)
+	return plaintext, stored
+}
+
+func (s *ServiceAccountUseCaseSuite) TestCreateServiceAccount_Success() {
+	s.userRepo.On("GetByID", s.ctx, actorID).Return(&domain.User{ID: actorID}, nil)
+	s.userRepo.On("Create", s.ctx, mock.MatchedBy(func(u *domain.User) bool {
+		return u.Name == "ci-bot" && u.OwnerID == actorID && u.PasswordHash == ""
+	})).Return(nil)
+	s.publisher.On("PublishUserCreated", s.ctx, mock.Anything).Return(nil)
+
+	account, err := s.useCase.CreateServiceAccount(s.ctx, "ci-bot")
+
+	s.Require().NoError(err)
+	assert.True(s.T(), account.IsServiceAccount())
+	assert.True(s.T(), strings.HasPrefix(account.Email, account.ID+"@"))
+}
+
+func (s *ServiceAccountUseCaseSuite) TestCreateServiceAccount_ByServiceAccount() {
+	s.userRepo.On("GetByID", s.ctx, actorID).Return(&domain.User{ID: actorID, OwnerID: uuid.New().String()}, nil)
+
+	_, err := s.useCase.CreateServiceAccount(s.ctx, "nested")
+
+	assert.ErrorIs(s.T(), err, domain.ErrForbidden)
+	s.userRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *ServiceAccountUseCaseSuite) TestCreateAPIKey_StoresOnlyHash() {
+	plaintext, stored := s.createKey(domain.ScopeTasksRead, domain.ScopeTasksWrite)
+
+	assert.True(s.T(), strings.HasPrefix(plaintext, "tf_"+stored.Prefix+"_"))
+	assert.NotContains(s.T(), stored.Hash, plaintext)
+	assert.Len(s.T(), stored.Hash, 64)
+	assert.Equal(s.T(), s.accountID, stored.UserID)
+	assert.Equal(s.T(), []string{domain.ScopeTasksRead, domain.ScopeTasksWrite}, stored.Scopes)
+}
+
+func (s *ServiceAccountUseCaseSuite) TestCreateAPIKey_InvalidScopes() {
+	_, _, err := s.useCase.CreateAPIKey(s.ctx, s.accountID, "ci", []string{"tasks:read", "admin"})
+
+	var validationErr *domain.ValidationError
+	s.Require().True(errors.As(err, &validationErr))
+	assert.Equal(s.T(), "scopes", validationErr.Fields[0].Field)
+	s.apiKeyRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *ServiceAccountUseCaseSuite) TestCreateAPIKey_NotOwner() {
+	otherAccount := uuid.New().String()
+	s.userRepo.On("GetByID", s.ctx, otherAccount).Return(&domain.User{ID: otherAccount, OwnerID: uuid.New().String()}, nil)
+
+	_, _, err := s.useCase.CreateAPIKey(s.ctx, otherAccount, "ci", []string{domain.ScopeTasksRead})
+
+	assert.ErrorIs(s.T(), err, domain.ErrForbidden)
+}
+
+func (s *ServiceAccountUseCaseSuite) TestListAPIKeys_NotAServiceAccount() {
+	humanID := uuid.New().String()
+	s.userRepo.On("GetByID", s.ctx, humanID).Return(&domain.User{ID: humanID}, nil)
+
+	_, err := s.useCase.ListAPIKeys(s.ctx, humanID)
+
+	assert.ErrorIs(s.T(), err, domain.ErrForbidden)
+}
+
+func (s *ServiceAccountUseCaseSuite) TestVerifyAPIKey_Success() {
+	plaintext, stored := s.createKey(domain.ScopeTasksRead)
+	s.apiKeyRepo.On("GetByPrefix", mock.Anything, stored.Prefix).Return(stored, nil)
+	s.apiKeyRepo.On("Touch", mock.Anything, stored.ID, mock.Anything).Return(nil)
+
+	key, err := s.useCase.VerifyAPIKey(context.Background(), plaintext)
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), s.accountID, key.UserID)
+	assert.True(s.T(), key.HasScope(domain.ScopeTasksRead))
+	assert.NotNil(s.T(), key.LastUsedAt)
+}
+
+func (s *ServiceAccountUseCaseSuite) TestVerifyAPIKey_TouchFailureIgnored() {
+	plaintext, stored := s.createKey(domain.ScopeTasksRead)
+	s.apiKeyRepo.On("GetByPrefix", mock.Anything, stored.Prefix).Return(stored, nil)
+	s.apiKeyRepo.On("Touch", mock.Anything, stored.ID, mock.Anything).Return(errors.New("connection reset"))
+
+	_, err := s.useCase.VerifyAPIKey(context.Background(), plaintext)
+
+	assert.NoError(s.T(), err)
+}
+
+func (s *ServiceAccountUseCaseSuite) TestVerifyAPIKey_Rejects() {
+	plaintext, stored := s.createKey(domain.ScopeTasksRead)
+	revokedAt := time.Now()
+	revokedPlaintext, revokedStored := s.createKey(domain.ScopeTasksRead)
+	revokedStored.RevokedAt = &revokedAt
+
+	s.apiKeyRepo.On("GetByPrefix", mock.Anything, stored.Prefix).Return(stored, nil)
+	s.apiKeyRepo.On("GetByPrefix", mock.Anything, revokedStored.Prefix).Return(revokedStored, nil)
+	s.apiKeyRepo.On("GetByPrefix", mock.Anything, "unknown").Return(nil, domain.ErrNotFound)
+
+	tests := map[string]string{
+		"malformed":      "not-a-key",
+		"wrong tag":      strings.Replace(plaintext, "tf_", "xx_", 1),
+		"unknown prefix": "tf_unknown_secret",
+		"wrong secret":   plaintext[:len(plaintext)-4] + "AAAA",
+		"revoked":        revokedPlaintext,
+	}
+
+	for name, key := range tests {
+		s.Run(name, func() {
+			_, err := s.useCase.VerifyAPIKey(context.Background(), key)
+			assert.ErrorIs(s.T(), err, domain.ErrInvalidCredentials)
+		})
+	}
+	s.apiKeyRepo.AssertNotCalled(s.T(), "Touch", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ServiceAccountUseCaseSuite) TestRevokeAPIKey() {
+	keyID := uuid.New().String()
+	s.apiKeyRepo.On("Revoke", s.ctx, s.accountID, keyID, mock.Anything).Return(nil)
+
+	err := s.useCase.RevokeAPIKey(s.ctx, s.accountID, keyID)
+
+	assert.NoError(s.T(), err)
+}
+
+func TestServiceAccountUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(ServiceAccountUseCaseSuite))
+}
@@ -5,9 +5,10 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
 	"github.com/Sol1tud9/taskflow/internal/domain"
-	"github.com/Sol1tud9/taskflow/pkg/logger"
-	"go.uber.org/zap"
+	"github.com/Sol1tud9/taskflow/pkg/actor"
 )
 
 type TeamRepository interface {
@@ -15,33 +16,44 @@ type TeamRepository interface {
 	GetByID(ctx context.Context, id string) (*domain.Team, error)
 	Update(ctx context.Context, team *domain.Team) error
 	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]*domain.Team, error)
+	ListByMember(ctx context.Context, userID string) ([]*domain.Team, error)
 }
 
 type TeamMemberRepository interface {
 	Add(ctx context.Context, member *domain.TeamMember) error
+	// Get returns domain.ErrNotFound if userID is not a member of teamID.
+	Get(ctx context.Context, teamID, userID string) (*domain.TeamMember, error)
 	GetByTeamID(ctx context.Context, teamID string) ([]*domain.TeamMember, error)
+	GetByUserID(ctx context.Context, userID string) ([]*domain.TeamMember, error)
 	Remove(ctx context.Context, teamID, userID string) error
 }
 
 type TeamEventPublisher interface {
+	PublishTeamCreated(ctx context.Context, event domain.TeamCreatedEvent) error
 	PublishTeamUpdated(ctx context.Context, event domain.TeamUpdatedEvent) error
+	PublishTeamMemberAdded(ctx context.Context, event domain.TeamMemberAddedEvent) error
+	PublishTeamMemberRemoved(ctx context.Context, event domain.TeamMemberRemovedEvent) error
 }
 
 type TeamUseCase struct {
 	teamRepo       TeamRepository
 	teamMemberRepo TeamMemberRepository
 	publisher      TeamEventPublisher
+	txManager      TxManager
 }
 
 func NewTeamUseCase(
 	teamRepo TeamRepository,
 	teamMemberRepo TeamMemberRepository,
 	publisher TeamEventPublisher,
+	txManager TxManager,
 ) *TeamUseCase {
 	return &TeamUseCase{
 		teamRepo:       teamRepo,
 		teamMemberRepo: teamMemberRepo,
 		publisher:      publisher,
+		txManager:      txManager,
 	}
 }
 
@@ -55,39 +67,111 @@ func (uc *TeamUseCase) CreateTeam(ctx context.Context, name, ownerID string) (*d
 		UpdatedAt: now,
 	}
 
-	if err := uc.teamRepo.Create(ctx, team); err != nil {
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.teamRepo.Create(ctx, team); err != nil {
+			return err
+		}
+
+		ownerMember := &domain.TeamMember{
+			ID:       uuid.New().String(),
+			TeamID:   team.ID,
+			UserID:   ownerID,
+			Role:     string(domain.RoleOwner),
+			JoinedAt: now,
+		}
+		if err := uc.teamMemberRepo.Add(ctx, ownerMember); err != nil {
+			return err
+		}
+
+		return uc.publisher.PublishTeamCreated(ctx, domain.TeamCreatedEvent{
+			EventID:   uuid.New().String(),
+			ActorID:   ownerID,
+			TeamID:    team.ID,
+			Name:      team.Name,
+			OwnerID:   team.OwnerID,
+			CreatedAt: team.CreatedAt,
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	ownerMember := &domain.TeamMember{
-		ID:       uuid.New().String(),
-		TeamID:   team.ID,
-		UserID:   ownerID,
-		Role:     "owner",
-		JoinedAt: now,
+	return team, nil
+}
+
+func (uc *TeamUseCase) GetTeam(ctx context.Context, id string) (*domain.Team, error) {
+	if _, err := uc.authorize(ctx, id, domain.PermissionViewTeam); err != nil {
+		return nil, err
+	}
+	return uc.teamRepo.GetByID(ctx, id)
+}
+
+// ListTeams lists the teams the acting user belongs to.
+func (uc *TeamUseCase) ListTeams(ctx context.Context) ([]*domain.Team, error) {
+	userID, ok := actor.UserID(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthenticated
 	}
-	if err := uc.teamMemberRepo.Add(ctx, ownerMember); err != nil {
+	return uc.teamRepo.ListByMember(ctx, userID)
+}
+
+func (uc *TeamUseCase) UpdateTeam(ctx context.Context, id, name string) (*domain.Team, error) {
+	caller, err := uc.authorize(ctx, id, domain.PermissionManageTeam)
+	if err != nil {
 		return nil, err
 	}
 
-	event := domain.TeamUpdatedEvent{
-		TeamID:    team.ID,
-		Name:      team.Name,
-		OwnerID:   team.OwnerID,
-		UpdatedAt: team.UpdatedAt,
+	team, err := uc.teamRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
-	if err := uc.publisher.PublishTeamUpdated(ctx, event); err != nil {
-		logger.Error("failed to publish team.updated event", zap.Error(err), zap.String("team_id", team.ID))
+
+	if name != "" {
+		team.Name = name
 	}
+	team.UpdatedAt = time.Now()
 
-	return team, nil
-}
+	err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.teamRepo.Update(ctx, team); err != nil {
+			return err
+		}
 
-func (uc *TeamUseCase) GetTeam(ctx context.Context, id string) (*domain.Team, error) {
-	return uc.teamRepo.GetByID(ctx, id)
+		return uc.publisher.PublishTeamUpdated(ctx, domain.TeamUpdatedEvent{
+			EventID:   uuid.New().String(),
+			ActorID:   caller.UserID,
+			TeamID:    team.ID,
+			Name:      team.Name,
+			OwnerID:   team.OwnerID,
+			UpdatedAt: team.UpdatedAt,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return team, nil
 }
 
+// AddTeamMember needs PermissionManageMembers, and PermissionManageAdmins
+// to add an admin. The owner role cannot be granted.
 func (uc *TeamUseCase) AddTeamMember(ctx context.Context, teamID, userID, role string) (*domain.TeamMember, error) {
+	teamRole := domain.TeamRole(role)
+	if !teamRole.Valid() || teamRole == domain.RoleOwner {
+		return nil, &domain.ValidationError{Fields: []domain.FieldError{
+			{Field: "role", Message: "must be one of admin, member, viewer"},
+		}}
+	}
+
+	caller, err := uc.authorize(ctx, teamID, domain.PermissionManageMembers)
+	if err != nil {
+		return nil, err
+	}
+	if teamRole == domain.RoleAdmin {
+		if err := checkRole(caller, domain.PermissionManageAdmins); err != nil {
+			return nil, err
+		}
+	}
+
 	member := &domain.TeamMember{
 		ID:       uuid.New().String(),
 		TeamID:   teamID,
@@ -96,14 +180,118 @@ func (uc *TeamUseCase) AddTeamMember(ctx context.Context, teamID, userID, role s
 		JoinedAt: time.Now(),
 	}
 
-	if err := uc.teamMemberRepo.Add(ctx, member); err != nil {
+	err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.teamMemberRepo.Add(ctx, member); err != nil {
+			return err
+		}
+
+		return uc.publisher.PublishTeamMemberAdded(ctx, domain.TeamMemberAddedEvent{
+			EventID:  uuid.New().String(),
+			ActorID:  caller.UserID,
+			TeamID:   member.TeamID,
+			UserID:   member.UserID,
+			Role:     member.Role,
+			JoinedAt: member.JoinedAt,
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return member, nil
 }
 
+// RemoveTeamMember needs the same permission as adding the member's role.
+// Members may always leave a team, except for its owner, who cannot be
+// removed.
+func (uc *TeamUseCase) RemoveTeamMember(ctx context.Context, teamID, userID string) error {
+	caller, err := uc.authorize(ctx, teamID, domain.PermissionViewTeam)
+	if err != nil {
+		return err
+	}
+
+	target, err := uc.teamMemberRepo.Get(ctx, teamID, userID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case domain.TeamRole(target.Role) == domain.RoleOwner:
+		return errors.Wrap(domain.ErrForbidden, "the team owner cannot be removed")
+	case userID == caller.UserID:
+	case domain.TeamRole(target.Role) == domain.RoleAdmin:
+		if err := checkRole(caller, domain.PermissionManageAdmins); err != nil {
+			return err
+		}
+	default:
+		if err := checkRole(caller, domain.PermissionManageMembers); err != nil {
+			return err
+		}
+	}
+
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.teamMemberRepo.Remove(ctx, teamID, userID); err != nil {
+			return err
+		}
+
+		return uc.publisher.PublishTeamMemberRemoved(ctx, domain.TeamMemberRemovedEvent{
+			EventID:   uuid.New().String(),
+			ActorID:   caller.UserID,
+			TeamID:    teamID,
+			UserID:    userID,
+			RemovedAt: time.Now(),
+		})
+	})
+}
+
 func (uc *TeamUseCase) GetTeamMembers(ctx context.Context, teamID string) ([]*domain.TeamMember, error) {
+	if _, err := uc.authorize(ctx, teamID, domain.PermissionViewTeam); err != nil {
+		return nil, err
+	}
 	return uc.teamMemberRepo.GetByTeamID(ctx, teamID)
 }
 
+// ListUserMemberships returns the teams userID belongs to with the role in
+// each. Users may only list their own memberships; task-service calls this
+// on behalf of the acting user to authorize task access.
+func (uc *TeamUseCase) ListUserMemberships(ctx context.Context, userID string) ([]*domain.TeamMember, error) {
+	callerID, ok := actor.UserID(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthenticated
+	}
+	if callerID != userID {
+		return nil, errors.Wrap(domain.ErrForbidden, "memberships of other users are private")
+	}
+	return uc.teamMemberRepo.GetByUserID(ctx, userID)
+}
+
+// authorize returns the acting user's membership in teamID if their role
+// grants perm. Non-members get domain.ErrForbidden, including for teams that
+// do not exist, so team IDs cannot be probed.
+func (uc *TeamUseCase) authorize(ctx context.Context, teamID string, perm domain.Permission) (*domain.TeamMember, error) {
+	userID, ok := actor.UserID(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	member, err := uc.teamMemberRepo.Get(ctx, teamID, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, errors.Wrap(domain.ErrForbidden, "not a member of the team")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkRole(member, perm); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+func checkRole(member *domain.TeamMember, perm domain.Permission) error {
+	if !domain.TeamRole(member.Role).Can(perm) {
+		return errors.Wrapf(domain.ErrForbidden, "role %q does not allow %s", member.Role, perm)
+	}
+	return nil
+}
+
@@ -11,26 +11,41 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/Sol1tud9/taskflow/internal/domain"
+	"github.com/Sol1tud9/taskflow/pkg/actor"
 	repoMocks "github.com/Sol1tud9/taskflow/internal/user/repository/mocks"
 	userUsecase "github.com/Sol1tud9/taskflow/internal/user/usecase"
 	usecaseMocks "github.com/Sol1tud9/taskflow/internal/user/usecase/mocks"
 )
 
+const actorID = "actor-1"
+
 type TeamUseCaseSuite struct {
 	suite.Suite
 	ctx              context.Context
 	teamRepo         *repoMocks.TeamRepository
 	teamMemberRepo   *repoMocks.TeamMemberRepository
 	publisher        *usecaseMocks.TeamEventPublisher
+	txManager        *usecaseMocks.TxManager
 	teamUseCase      *userUsecase.TeamUseCase
 }
 
 func (s *TeamUseCaseSuite) SetupTest() {
-	s.ctx = context.Background()
+	s.ctx = actor.WithUserID(context.Background(), actorID)
 	s.teamRepo = repoMocks.NewTeamRepository(s.T())
 	s.teamMemberRepo = repoMocks.NewTeamMemberRepository(s.T())
 	s.publisher = usecaseMocks.NewTeamEventPublisher(s.T())
-	s.teamUseCase = userUsecase.NewTeamUseCase(s.teamRepo, s.teamMemberRepo, s.publisher)
+	s.txManager = usecaseMocks.NewTxManager(s.T())
+	s.txManager.On("WithinTx", s.ctx, mock.Anything).Return(runInTx)
+	s.teamUseCase = userUsecase.NewTeamUseCase(s.teamRepo, s.teamMemberRepo, s.publisher, s.txManager)
+}
+
+// actingAs makes the acting user a member of teamID with role.
+func (s *TeamUseCaseSuite) actingAs(teamID string, role domain.TeamRole) {
+	s.teamMemberRepo.On("Get", s.ctx, teamID, actorID).Return(&domain.TeamMember{
+		TeamID: teamID,
+		UserID: actorID,
+		Role:   string(role),
+	}, nil)
 }
 
 func (s *TeamUseCaseSuite) TestCreateTeam_Success() {
@@ -50,8 +65,8 @@ func (s *TeamUseCaseSuite) TestCreateTeam_Success() {
 		return m.UserID == ownerID && m.Role == "owner"
 	})).Return(nil)
 
-	s.publisher.On("PublishTeamUpdated", s.ctx, mock.MatchedBy(func(e domain.TeamUpdatedEvent) bool {
-		return e.Name == name && e.OwnerID == ownerID
+	s.publisher.On("PublishTeamCreated", s.ctx, mock.MatchedBy(func(e domain.TeamCreatedEvent) bool {
+		return e.EventID != "" && e.ActorID == ownerID && e.Name == name && e.OwnerID == ownerID
 	})).Return(nil)
 
 	result, err := s.teamUseCase.CreateTeam(s.ctx, name, ownerID)
@@ -77,6 +92,19 @@ func (s *TeamUseCaseSuite) TestCreateTeam_RepositoryError() {
 	assert.Equal(s.T(), repoErr, err)
 }
 
+func (s *TeamUseCaseSuite) TestCreateTeam_MemberError() {
+	memberErr := errors.New("member insert failed")
+
+	s.teamRepo.On("Create", s.ctx, mock.Anything).Return(nil)
+	s.teamMemberRepo.On("Add", s.ctx, mock.Anything).Return(memberErr)
+
+	result, err := s.teamUseCase.CreateTeam(s.ctx, "Test Team", uuid.New().String())
+
+	assert.Nil(s.T(), result)
+	assert.Equal(s.T(), memberErr, err)
+	s.publisher.AssertNotCalled(s.T(), "PublishTeamCreated", mock.Anything, mock.Anything)
+}
+
 func (s *TeamUseCaseSuite) TestGetTeam_Success() {
 	teamID := uuid.New().String()
 	expectedTeam := &domain.Team{
@@ -87,6 +115,7 @@ func (s *TeamUseCaseSuite) TestGetTeam_Success() {
 		UpdatedAt: time.Now(),
 	}
 
+	s.actingAs(teamID, domain.RoleViewer)
 	s.teamRepo.On("GetByID", s.ctx, teamID).Return(expectedTeam, nil)
 
 	result, err := s.teamUseCase.GetTeam(s.ctx, teamID)
@@ -102,6 +131,7 @@ func (s *TeamUseCaseSuite) TestAddTeamMember_Success() {
 	userID := uuid.New().String()
 	role := "member"
 
+	s.actingAs(teamID, domain.RoleAdmin)
 	s.teamMemberRepo.On("Add", s.ctx, mock.MatchedBy(func(m *domain.TeamMember) bool {
 		return m.TeamID == teamID && m.UserID == userID && m.Role == role
 	})).Return(nil).Run(func(args mock.Arguments) {
@@ -110,6 +140,10 @@ func (s *TeamUseCaseSuite) TestAddTeamMember_Success() {
 		member.JoinedAt = time.Now()
 	})
 
+	s.publisher.On("PublishTeamMemberAdded", s.ctx, mock.MatchedBy(func(e domain.TeamMemberAddedEvent) bool {
+		return e.EventID != "" && e.ActorID == actorID && e.TeamID == teamID && e.UserID == userID && e.Role == role
+	})).Return(nil)
+
 	result, err := s.teamUseCase.AddTeamMember(s.ctx, teamID, userID, role)
 
 	assert.NoError(s.T(), err)
@@ -119,6 +153,84 @@ func (s *TeamUseCaseSuite) TestAddTeamMember_Success() {
 	assert.Equal(s.T(), role, result.Role)
 }
 
+func (s *TeamUseCaseSuite) TestAddTeamMember_AlreadyExists() {
+	teamID := uuid.New().String()
+	s.actingAs(teamID, domain.RoleOwner)
+	s.teamMemberRepo.On("Add", s.ctx, mock.Anything).Return(domain.ErrAlreadyExists)
+
+	result, err := s.teamUseCase.AddTeamMember(s.ctx, teamID, uuid.New().String(), "member")
+
+	assert.Nil(s.T(), result)
+	assert.ErrorIs(s.T(), err, domain.ErrAlreadyExists)
+	s.publisher.AssertNotCalled(s.T(), "PublishTeamMemberAdded", mock.Anything, mock.Anything)
+}
+
+func (s *TeamUseCaseSuite) TestUpdateTeam_Success() {
+	team := &domain.Team{
+		ID:        uuid.New().String(),
+		Name:      "Old Name",
+		OwnerID:   uuid.New().String(),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	s.actingAs(team.ID, domain.RoleAdmin)
+	s.teamRepo.On("GetByID", s.ctx, team.ID).Return(team, nil)
+	s.teamRepo.On("Update", s.ctx, mock.MatchedBy(func(t *domain.Team) bool {
+		return t.ID == team.ID && t.Name == "New Name"
+	})).Return(nil)
+	s.publisher.On("PublishTeamUpdated", s.ctx, mock.MatchedBy(func(e domain.TeamUpdatedEvent) bool {
+		return e.EventID != "" && e.ActorID == actorID && e.TeamID == team.ID && e.Name == "New Name" && e.OwnerID == team.OwnerID
+	})).Return(nil)
+
+	result, err := s.teamUseCase.UpdateTeam(s.ctx, team.ID, "New Name")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "New Name", result.Name)
+}
+
+func (s *TeamUseCaseSuite) TestUpdateTeam_NotFound() {
+	teamID := uuid.New().String()
+
+	s.actingAs(teamID, domain.RoleOwner)
+	s.teamRepo.On("GetByID", s.ctx, teamID).Return(nil, domain.ErrNotFound)
+
+	result, err := s.teamUseCase.UpdateTeam(s.ctx, teamID, "New Name")
+
+	assert.Nil(s.T(), result)
+	assert.ErrorIs(s.T(), err, domain.ErrNotFound)
+	s.publisher.AssertNotCalled(s.T(), "PublishTeamUpdated", mock.Anything, mock.Anything)
+}
+
+func (s *TeamUseCaseSuite) TestRemoveTeamMember_Success() {
+	teamID := uuid.New().String()
+	userID := uuid.New().String()
+
+	s.actingAs(teamID, domain.RoleAdmin)
+	s.teamMemberRepo.On("Get", s.ctx, teamID, userID).Return(&domain.TeamMember{TeamID: teamID, UserID: userID, Role: "member"}, nil)
+	s.teamMemberRepo.On("Remove", s.ctx, teamID, userID).Return(nil)
+	s.publisher.On("PublishTeamMemberRemoved", s.ctx, mock.MatchedBy(func(e domain.TeamMemberRemovedEvent) bool {
+		return e.EventID != "" && e.ActorID == actorID && e.TeamID == teamID && e.UserID == userID
+	})).Return(nil)
+
+	err := s.teamUseCase.RemoveTeamMember(s.ctx, teamID, userID)
+
+	assert.NoError(s.T(), err)
+}
+
+func (s *TeamUseCaseSuite) TestRemoveTeamMember_NotFound() {
+	teamID := uuid.New().String()
+	userID := uuid.New().String()
+
+	s.actingAs(teamID, domain.RoleAdmin)
+	s.teamMemberRepo.On("Get", s.ctx, teamID, userID).Return(nil, domain.ErrNotFound)
+
+	err := s.teamUseCase.RemoveTeamMember(s.ctx, teamID, userID)
+
+	assert.ErrorIs(s.T(), err, domain.ErrNotFound)
+	s.publisher.AssertNotCalled(s.T(), "PublishTeamMemberRemoved", mock.Anything, mock.Anything)
+}
+
 func (s *TeamUseCaseSuite) TestGetTeamMembers_Success() {
 	teamID := uuid.New().String()
 	expectedMembers := []*domain.TeamMember{
@@ -138,6 +250,7 @@ func (s *TeamUseCaseSuite) TestGetTeamMembers_Success() {
 		},
 	}
 
+	s.actingAs(teamID, domain.RoleViewer)
 	s.teamMemberRepo.On("GetByTeamID", s.ctx, teamID).Return(expectedMembers, nil)
 
 	result, err := s.teamUseCase.GetTeamMembers(s.ctx, teamID)
@@ -148,6 +261,177 @@ func (s *TeamUseCaseSuite) TestGetTeamMembers_Success() {
 	assert.Equal(s.T(), expectedMembers[0].ID, result[0].ID)
 }
 
+func (s *TeamUseCaseSuite) TestListTeams_OnlyMemberTeams() {
+	teams := []*domain.Team{{ID: uuid.New().String(), Name: "Mine"}}
+	s.teamRepo.On("ListByMember", s.ctx, actorID).Return(teams, nil)
+
+	result, err := s.teamUseCase.ListTeams(s.ctx)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), teams, result)
+}
+
+func (s *TeamUseCaseSuite) TestWithoutActor() {
+	_, err := s.teamUseCase.GetTeam(context.Background(), uuid.New().String())
+
+	assert.ErrorIs(s.T(), err, domain.ErrUnauthenticated)
+}
+
+func (s *TeamUseCaseSuite) TestAddTeamMember_InvalidRole() {
+	for _, role := range []string{"owner", "superuser"} {
+		_, err := s.teamUseCase.AddTeamMember(s.ctx, uuid.New().String(), uuid.New().String(), role)
+
+		var validationErr *domain.ValidationError
+		assert.ErrorAs(s.T(), err, &validationErr, role)
+	}
+}
+
+func (s *TeamUseCaseSuite) TestListUserMemberships_OnlyOwn() {
+	memberships := []*domain.TeamMember{{TeamID: uuid.New().String(), UserID: actorID, Role: "member"}}
+	s.teamMemberRepo.On("GetByUserID", s.ctx, actorID).Return(memberships, nil)
+
+	result, err := s.teamUseCase.ListUserMemberships(s.ctx, actorID)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), memberships, result)
+
+	_, err = s.teamUseCase.ListUserMemberships(s.ctx, uuid.New().String())
+	assert.ErrorIs(s.T(), err, domain.ErrForbidden)
+}
+
+// TestPermissionMatrix runs each team operation as every role, and as a
+// non-member (role ""), and checks which of them are allowed.
+func (s *TeamUseCaseSuite) TestPermissionMatrix() {
+	const (
+		teamID   = "team-1"
+		memberID = "member-1"
+		adminID  = "admin-1"
+		ownerID  = "owner-1"
+	)
+
+	operations := []struct {
+		name    string
+		run     func() error
+		allowed []domain.TeamRole
+	}{
+		{
+			name: "view team",
+			run: func() error {
+				_, err := s.teamUseCase.GetTeam(s.ctx, teamID)
+				return err
+			},
+			allowed: []domain.TeamRole{domain.RoleOwner, domain.RoleAdmin, domain.RoleMember, domain.RoleViewer},
+		},
+		{
+			name: "list members",
+			run: func() error {
+				_, err := s.teamUseCase.GetTeamMembers(s.ctx, teamID)
+				return err
+			},
+			allowed: []domain.TeamRole{domain.RoleOwner, domain.RoleAdmin, domain.RoleMember, domain.RoleViewer},
+		},
+		{
+			name: "rename team",
+			run: func() error {
+				_, err := s.teamUseCase.UpdateTeam(s.ctx, teamID, "New Name")
+				return err
+			},
+			allowed: []domain.TeamRole{domain.RoleOwner, domain.RoleAdmin},
+		},
+		{
+			name: "add member",
+			run: func() error {
+				_, err := s.teamUseCase.AddTeamMember(s.ctx, teamID, "new-user", "member")
+				return err
+			},
+			allowed: []domain.TeamRole{domain.RoleOwner, domain.RoleAdmin},
+		},
+		{
+			name: "add viewer",
+			run: func() error {
+				_, err := s.teamUseCase.AddTeamMember(s.ctx, teamID, "new-user", "viewer")
+				return err
+			},
+			allowed: []domain.TeamRole{domain.RoleOwner, domain.RoleAdmin},
+		},
+		{
+			name: "add admin",
+			run: func() error {
+				_, err := s.teamUseCase.AddTeamMember(s.ctx, teamID, "new-user", "admin")
+				return err
+			},
+			allowed: []domain.TeamRole{domain.RoleOwner},
+		},
+		{
+			name:    "remove member",
+			run:     func() error { return s.teamUseCase.RemoveTeamMember(s.ctx, teamID, memberID) },
+			allowed: []domain.TeamRole{domain.RoleOwner, domain.RoleAdmin},
+		},
+		{
+			name:    "remove admin",
+			run:     func() error { return s.teamUseCase.RemoveTeamMember(s.ctx, teamID, adminID) },
+			allowed: []domain.TeamRole{domain.RoleOwner},
+		},
+		{
+			name:    "remove owner",
+			run:     func() error { return s.teamUseCase.RemoveTeamMember(s.ctx, teamID, ownerID) },
+			allowed: nil,
+		},
+		{
+			name:    "leave team",
+			run:     func() error { return s.teamUseCase.RemoveTeamMember(s.ctx, teamID, actorID) },
+			allowed: []domain.TeamRole{domain.RoleAdmin, domain.RoleMember, domain.RoleViewer},
+		},
+	}
+
+	roles := append([]domain.TeamRole{""}, domain.TeamRoles...)
+
+	for _, op := range operations {
+		for _, role := range roles {
+			name := op.name + "/" + string(role)
+			if role == "" {
+				name = op.name + "/non-member"
+			}
+
+			s.Run(name, func() {
+				s.SetupTest()
+				if role == "" {
+					s.teamMemberRepo.On("Get", s.ctx, teamID, actorID).Return(nil, domain.ErrNotFound)
+				} else {
+					s.actingAs(teamID, role)
+				}
+				for userID, targetRole := range map[string]string{memberID: "member", adminID: "admin", ownerID: "owner"} {
+					s.teamMemberRepo.On("Get", s.ctx, teamID, userID).Return(&domain.TeamMember{TeamID: teamID, UserID: userID, Role: targetRole}, nil).Maybe()
+				}
+				s.teamRepo.On("GetByID", s.ctx, teamID).Return(&domain.Team{ID: teamID}, nil).Maybe()
+				s.teamRepo.On("Update", s.ctx, mock.Anything).Return(nil).Maybe()
+				s.teamMemberRepo.On("GetByTeamID", s.ctx, teamID).Return([]*domain.TeamMember{}, nil).Maybe()
+				s.teamMemberRepo.On("Add", s.ctx, mock.Anything).Return(nil).Maybe()
+				s.teamMemberRepo.On("Remove", s.ctx, teamID, mock.Anything).Return(nil).Maybe()
+				s.publisher.On("PublishTeamUpdated", s.ctx, mock.Anything).Return(nil).Maybe()
+				s.publisher.On("PublishTeamMemberAdded", s.ctx, mock.Anything).Return(nil).Maybe()
+				s.publisher.On("PublishTeamMemberRemoved", s.ctx, mock.Anything).Return(nil).Maybe()
+
+				err := op.run()
+
+				if containsRole(op.allowed, role) {
+					assert.NoError(s.T(), err)
+				} else {
+					assert.ErrorIs(s.T(), err, domain.ErrForbidden)
+				}
+			})
+		}
+	}
+}
+
+func containsRole(roles []domain.TeamRole, role domain.TeamRole) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
 func TestTeamUseCaseSuite(t *testing.T) {
 	suite.Run(t, new(TeamUseCaseSuite))
 }
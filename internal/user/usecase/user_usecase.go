@@ -3,19 +3,34 @@ package usecase
 import (
 	"context"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+
 	"github.com/Sol1tud9/taskflow/internal/domain"
-	"github.com/Sol1tud9/taskflow/pkg/logger"
-	"go.uber.org/zap"
+	"github.com/Sol1tud9/taskflow/pkg/actor"
+)
+
+const (
+	minPasswordLength = 8
+	// maxPasswordLength is the bcrypt input limit; longer passwords would be
+	// silently truncated, so they are rejected instead.
+	maxPasswordLength = 72
 )
 
+// dummyHash is compared against when no user matches the email, so that a
+// failed login takes the same time whether or not the account exists.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("taskflow-dummy-password"), bcrypt.DefaultCost)
 
 type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) error
 	GetByID(ctx context.Context, id string) (*domain.User, error)
+	GetByEmail(ctx context.Context, email string) (*domain.User, error)
 	Update(ctx context.Context, user *domain.User) error
 	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]*domain.User, error)
 }
 
 type EventPublisher interface {
@@ -23,42 +38,94 @@ type EventPublisher interface {
 	PublishUserUpdated(ctx context.Context, event domain.UserUpdatedEvent) error
 }
 
+// SessionStore ends login sessions; it is implemented by
+// session.RedisStore, which the gateway issues sessions from.
+type SessionStore interface {
+	DeleteUser(ctx context.Context, userID string) error
+}
+
+// TxManager runs fn in a single database transaction. Repositories and the
+// outbox-backed publishers join it through the context passed to fn.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type UserUseCase struct {
 	userRepo  UserRepository
 	publisher EventPublisher
+	txManager TxManager
+	sessions  SessionStore
 }
 
-func NewUserUseCase(userRepo UserRepository, publisher EventPublisher) *UserUseCase {
+func NewUserUseCase(userRepo UserRepository, publisher EventPublisher, txManager TxManager, sessions SessionStore) *UserUseCase {
 	return &UserUseCase{
 		userRepo:  userRepo,
 		publisher: publisher,
+		txManager: txManager,
+		sessions:  sessions,
 	}
 }
 
-func (uc *UserUseCase) CreateUser(ctx context.Context, email, name string) (*domain.User, error) {
+// CreateUser registers a user. The password is optional; a user created
+// without one cannot log in until a password is set through UpdateUser.
+func (uc *UserUseCase) CreateUser(ctx context.Context, email, name, password string) (*domain.User, error) {
+	hash, err := hashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	user := &domain.User{
-		ID:        uuid.New().String(),
-		Email:     email,
-		Name:      name,
-		CreatedAt: now,
-		UpdatedAt: now,
+		ID:           uuid.New().String(),
+		Email:        email,
+		Name:         name,
+		PasswordHash: hash,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+
+		return uc.publisher.PublishUserCreated(ctx, domain.UserCreatedEvent{
+			EventID:   uuid.New().String(),
+			UserID:    user.ID,
+			Email:     user.Email,
+			Name:      user.Name,
+			CreatedAt: user.CreatedAt,
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if err := uc.userRepo.Create(ctx, user); err != nil {
+	return user, nil
+}
+
+// Authenticate returns the user whose email and password match, or
+// domain.ErrInvalidCredentials. Unknown emails and users without a password
+// are reported the same way as a wrong password.
+func (uc *UserUseCase) Authenticate(ctx context.Context, email, password string) (*domain.User, error) {
+	user, err := uc.userRepo.GetByEmail(ctx, email)
+	if errors.Is(err, domain.ErrNotFound) {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err != nil {
 		return nil, err
 	}
 
-	event := domain.UserCreatedEvent{
-		UserID:    user.ID,
-		Email:     user.Email,
-		Name:      user.Name,
-		CreatedAt: user.CreatedAt,
+	if user.PasswordHash == "" {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return nil, domain.ErrInvalidCredentials
 	}
-	if err := uc.publisher.PublishUserCreated(ctx, event); err != nil {
-		logger.Error("failed to publish user.created event", zap.Error(err), zap.String("user_id", user.ID))
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, domain.ErrInvalidCredentials
 	}
 
+	user.PasswordHash = ""
 	return user, nil
 }
 
@@ -66,11 +133,37 @@ func (uc *UserUseCase) GetUser(ctx context.Context, id string) (*domain.User, er
 	return uc.userRepo.GetByID(ctx, id)
 }
 
-func (uc *UserUseCase) UpdateUser(ctx context.Context, id, email, name string) (*domain.User, error) {
+func (uc *UserUseCase) ListUsers(ctx context.Context) ([]*domain.User, error) {
+	return uc.userRepo.List(ctx)
+}
+
+// UpdateUser changes the non-empty fields. A non-empty password replaces
+// the stored hash; service accounts cannot have one.
+// UpdateUser changes the acting user's profile. Changing the password
+// requires the current one and ends all of the user's sessions.
+func (uc *UserUseCase) UpdateUser(ctx context.Context, id, email, name, password, currentPassword string) (*domain.User, error) {
+	actorID, ok := actor.UserID(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthenticated
+	}
+	if actorID != id {
+		return nil, domain.ErrForbidden
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := uc.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if hash != "" {
+		if err := checkPasswordChange(user, currentPassword); err != nil {
+			return nil, err
+		}
+	}
 
 	if email != "" {
 		user.Email = email
@@ -78,22 +171,80 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, id, email, name string) (
 	if name != "" {
 		user.Name = name
 	}
+	user.PasswordHash = hash
 	user.UpdatedAt = time.Now()
 
-	if err := uc.userRepo.Update(ctx, user); err != nil {
+	err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+
+		if err := uc.publisher.PublishUserUpdated(ctx, domain.UserUpdatedEvent{
+			EventID:   uuid.New().String(),
+			UserID:    user.ID,
+			Email:     user.Email,
+			Name:      user.Name,
+			UpdatedAt: user.UpdatedAt,
+		}); err != nil {
+			return err
+		}
+
+		// Sessions end before the new password is committed, so a failure
+		// here leaves the old password in place rather than old sessions
+		// alive under the new one.
+		if hash != "" {
+			return uc.sessions.DeleteUser(ctx, user.ID)
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	event := domain.UserUpdatedEvent{
-		UserID:    user.ID,
-		Email:     user.Email,
-		Name:      user.Name,
-		UpdatedAt: user.UpdatedAt,
+	return user, nil
+}
+
+// checkPasswordChange allows setting a password on users who may have one
+// if they know their current password; users without one may set it
+// directly.
+func checkPasswordChange(user *domain.User, currentPassword string) error {
+	if user.IsServiceAccount() {
+		return &domain.ValidationError{Fields: []domain.FieldError{{Field: "password", Message: "service accounts sign in with API keys"}}}
 	}
-	if err := uc.publisher.PublishUserUpdated(ctx, event); err != nil {
-		logger.Error("failed to publish user.updated event", zap.Error(err), zap.String("user_id", user.ID))
+	if user.PasswordHash == "" {
+		return nil
 	}
-
-	return user, nil
+	if currentPassword == "" {
+		return &domain.ValidationError{Fields: []domain.FieldError{{Field: "current_password", Message: "current_password is required to change the password"}}}
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(currentPassword)) != nil {
+		return &domain.ValidationError{Fields: []domain.FieldError{{Field: "current_password", Message: "current_password is incorrect"}}}
+	}
+	return nil
 }
 
+// hashPassword validates and hashes password. An empty password yields an
+// empty hash, meaning "no password".
+func hashPassword(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+
+	var verr domain.ValidationError
+	if utf8.RuneCountInString(password) < minPasswordLength {
+		verr.Add("password", "must be at least 8 characters")
+	}
+	if len(password) > maxPasswordLength {
+		verr.Add("password", "must be at most 72 bytes")
+	}
+	if err := verr.Err(); err != nil {
+		return "", err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash password")
+	}
+
+	return string(hash), nil
+}
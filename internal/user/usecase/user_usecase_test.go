@@ -10,10 +10,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
 	"github.com/Sol1tud9/taskflow/internal/domain"
 	repoMocks "github.com/Sol1tud9/taskflow/internal/user/repository/mocks"
 	userUsecase "github.com/Sol1tud9/taskflow/internal/user/usecase"
 	usecaseMocks "github.com/Sol1tud9/taskflow/internal/user/usecase/mocks"
+	"github.com/Sol1tud9/taskflow/pkg/actor"
 )
 
 type UserUseCaseSuite struct {
@@ -21,14 +23,24 @@ type UserUseCaseSuite struct {
 	ctx            context.Context
 	userRepo       *repoMocks.UserRepository
 	publisher      *usecaseMocks.EventPublisher
+	txManager      *usecaseMocks.TxManager
+	sessions       *usecaseMocks.SessionStore
 	userUseCase    *userUsecase.UserUseCase
 }
 
+// runInTx stands in for a real transaction by calling fn with the same context.
+func runInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 func (s *UserUseCaseSuite) SetupTest() {
-	s.ctx = context.Background()
+	s.ctx = actor.WithUserID(context.Background(), actorID)
 	s.userRepo = repoMocks.NewUserRepository(s.T())
 	s.publisher = usecaseMocks.NewEventPublisher(s.T())
-	s.userUseCase = userUsecase.NewUserUseCase(s.userRepo, s.publisher)
+	s.txManager = usecaseMocks.NewTxManager(s.T())
+	s.sessions = usecaseMocks.NewSessionStore(s.T())
+	s.txManager.On("WithinTx", s.ctx, mock.Anything).Return(runInTx)
+	s.userUseCase = userUsecase.NewUserUseCase(s.userRepo, s.publisher, s.txManager, s.sessions)
 }
 
 func (s *UserUseCaseSuite) TestCreateUser_Success() {
@@ -56,7 +68,7 @@ func (s *UserUseCaseSuite) TestCreateUser_Success() {
 		return e.Email == email && e.Name == name
 	})).Return(nil)
 
-	result, err := s.userUseCase.CreateUser(s.ctx, email, name)
+	result, err := s.userUseCase.CreateUser(s.ctx, email, name, "")
 
 	assert.NoError(s.T(), err)
 	assert.NotNil(s.T(), result)
@@ -72,13 +84,38 @@ func (s *UserUseCaseSuite) TestCreateUser_RepositoryError() {
 
 	s.userRepo.On("Create", s.ctx, mock.Anything).Return(repoErr)
 
-	result, err := s.userUseCase.CreateUser(s.ctx, email, name)
+	result, err := s.userUseCase.CreateUser(s.ctx, email, name, "")
 
 	assert.Error(s.T(), err)
 	assert.Nil(s.T(), result)
 	assert.Equal(s.T(), repoErr, err)
 }
 
+func (s *UserUseCaseSuite) TestCreateUser_PublishError() {
+	outboxErr := errors.New("outbox insert failed")
+
+	s.userRepo.On("Create", s.ctx, mock.Anything).Return(nil)
+	s.publisher.On("PublishUserCreated", s.ctx, mock.Anything).Return(outboxErr)
+
+	result, err := s.userUseCase.CreateUser(s.ctx, "test@example.com", "Test User", "")
+
+	assert.Nil(s.T(), result)
+	assert.Equal(s.T(), outboxErr, err)
+}
+
+func (s *UserUseCaseSuite) TestCreateUser_TransactionError() {
+	txErr := errors.New("failed to commit transaction")
+
+	txManager := usecaseMocks.NewTxManager(s.T())
+	txManager.On("WithinTx", s.ctx, mock.Anything).Return(txErr)
+	userUseCase := userUsecase.NewUserUseCase(s.userRepo, s.publisher, txManager, s.sessions)
+
+	result, err := userUseCase.CreateUser(s.ctx, "test@example.com", "Test User", "")
+
+	assert.Nil(s.T(), result)
+	assert.Equal(s.T(), txErr, err)
+}
+
 func (s *UserUseCaseSuite) TestGetUser_Success() {
 	userID := uuid.New().String()
 	expectedUser := &domain.User{
@@ -113,7 +150,7 @@ func (s *UserUseCaseSuite) TestGetUser_NotFound() {
 }
 
 func (s *UserUseCaseSuite) TestUpdateUser_Success() {
-	userID := uuid.New().String()
+	userID := actorID
 	newEmail := "new@example.com"
 	newName := "New Name"
 
@@ -133,7 +170,7 @@ func (s *UserUseCaseSuite) TestUpdateUser_Success() {
 		return e.UserID == userID && e.Email == newEmail && e.Name == newName
 	})).Return(nil)
 
-	result, err := s.userUseCase.UpdateUser(s.ctx, userID, newEmail, newName)
+	result, err := s.userUseCase.UpdateUser(s.ctx, userID, newEmail, newName, "", "")
 
 	assert.NoError(s.T(), err)
 	assert.NotNil(s.T(), result)
@@ -142,7 +179,7 @@ func (s *UserUseCaseSuite) TestUpdateUser_Success() {
 }
 
 func (s *UserUseCaseSuite) TestUpdateUser_PartialUpdate() {
-	userID := uuid.New().String()
+	userID := actorID
 	newEmail := "new@example.com"
 
 	existingUser := &domain.User{
@@ -159,7 +196,7 @@ func (s *UserUseCaseSuite) TestUpdateUser_PartialUpdate() {
 	})).Return(nil)
 	s.publisher.On("PublishUserUpdated", s.ctx, mock.Anything).Return(nil)
 
-	result, err := s.userUseCase.UpdateUser(s.ctx, userID, newEmail, "")
+	result, err := s.userUseCase.UpdateUser(s.ctx, userID, newEmail, "", "", "")
 
 	assert.NoError(s.T(), err)
 	assert.NotNil(s.T(), result)
@@ -168,18 +205,174 @@ func (s *UserUseCaseSuite) TestUpdateUser_PartialUpdate() {
 }
 
 func (s *UserUseCaseSuite) TestUpdateUser_NotFound() {
-	userID := uuid.New().String()
+	userID := actorID
 	repoErr := errors.New("user not found")
 
 	s.userRepo.On("GetByID", s.ctx, userID).Return(nil, repoErr)
 
-	result, err := s.userUseCase.UpdateUser(s.ctx, userID, "new@example.com", "New Name")
+	result, err := s.userUseCase.UpdateUser(s.ctx, userID, "new@example.com", "New Name", "", "")
 
 	assert.Error(s.T(), err)
 	assert.Nil(s.T(), result)
 	assert.Equal(s.T(), repoErr, err)
 }
 
+func (s *UserUseCaseSuite) TestCreateUser_HashesPassword() {
+	password := "correct horse battery"
+
+	s.userRepo.On("Create", s.ctx, mock.MatchedBy(func(u *domain.User) bool {
+		return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+	})).Return(nil)
+	s.publisher.On("PublishUserCreated", s.ctx, mock.Anything).Return(nil)
+
+	result, err := s.userUseCase.CreateUser(s.ctx, "test@example.com", "Test User", password)
+
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), result)
+}
+
+func (s *UserUseCaseSuite) TestCreateUser_ShortPassword() {
+	result, err := s.userUseCase.CreateUser(s.ctx, "test@example.com", "Test User", "short")
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(s.T(), err, &validationErr)
+	assert.Equal(s.T(), "password", validationErr.Fields[0].Field)
+	assert.Nil(s.T(), result)
+	s.userRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *UserUseCaseSuite) TestUpdateUser_ChangesPassword() {
+	password := "new password 123"
+	current, err := bcrypt.GenerateFromPassword([]byte("old password 123"), bcrypt.MinCost)
+	s.Require().NoError(err)
+
+	s.userRepo.On("GetByID", s.ctx, actorID).Return(&domain.User{ID: actorID, Email: "test@example.com", Name: "Test User", PasswordHash: string(current)}, nil)
+	s.userRepo.On("Update", s.ctx, mock.MatchedBy(func(u *domain.User) bool {
+		return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+	})).Return(nil)
+	s.publisher.On("PublishUserUpdated", s.ctx, mock.Anything).Return(nil)
+	s.sessions.On("DeleteUser", s.ctx, actorID).Return(nil)
+
+	_, err = s.userUseCase.UpdateUser(s.ctx, actorID, "", "", password, "old password 123")
+
+	assert.NoError(s.T(), err)
+	s.sessions.AssertCalled(s.T(), "DeleteUser", s.ctx, actorID)
+}
+
+func (s *UserUseCaseSuite) TestUpdateUser_WrongCurrentPassword() {
+	current, err := bcrypt.GenerateFromPassword([]byte("old password 123"), bcrypt.MinCost)
+	s.Require().NoError(err)
+	s.userRepo.On("GetByID", s.ctx, actorID).Return(&domain.User{ID: actorID, PasswordHash: string(current)}, nil)
+
+	for name, currentPassword := range map[string]string{"missing": "", "wrong": "guess password"} {
+		s.Run(name, func() {
+			_, err := s.userUseCase.UpdateUser(s.ctx, actorID, "", "", "new password 123", currentPassword)
+
+			var validationErr *domain.ValidationError
+			s.Require().True(errors.As(err, &validationErr))
+			assert.Equal(s.T(), "current_password", validationErr.Fields[0].Field)
+		})
+	}
+	s.userRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	s.sessions.AssertNotCalled(s.T(), "DeleteUser", mock.Anything, mock.Anything)
+}
+
+func (s *UserUseCaseSuite) TestUpdateUser_SessionsNotEnded() {
+	s.userRepo.On("GetByID", s.ctx, actorID).Return(&domain.User{ID: actorID}, nil)
+	s.userRepo.On("Update", s.ctx, mock.Anything).Return(nil)
+	s.publisher.On("PublishUserUpdated", s.ctx, mock.Anything).Return(nil)
+	s.sessions.On("DeleteUser", s.ctx, actorID).Return(errors.New("connection refused"))
+
+	_, err := s.userUseCase.UpdateUser(s.ctx, actorID, "", "", "new password 123", "")
+
+	assert.Error(s.T(), err)
+}
+
+func (s *UserUseCaseSuite) TestUpdateUser_OtherUser() {
+	_, err := s.userUseCase.UpdateUser(s.ctx, uuid.New().String(), "", "", "new password 123", "")
+
+	assert.ErrorIs(s.T(), err, domain.ErrForbidden)
+	s.userRepo.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything)
+}
+
+func (s *UserUseCaseSuite) TestUpdateUser_WithoutActor() {
+	_, err := s.userUseCase.UpdateUser(context.Background(), actorID, "", "New Name", "", "")
+
+	assert.ErrorIs(s.T(), err, domain.ErrUnauthenticated)
+}
+
+func (s *UserUseCaseSuite) TestAuthenticate() {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret password"), bcrypt.MinCost)
+	s.Require().NoError(err)
+
+	stored := func() *domain.User {
+		return &domain.User{ID: "user-1", Email: "test@example.com", PasswordHash: string(hash)}
+	}
+
+	tests := []struct {
+		name     string
+		setup    func()
+		password string
+		wantErr  error
+	}{
+		{
+			name:     "valid credentials",
+			setup:    func() { s.userRepo.On("GetByEmail", s.ctx, "test@example.com").Return(stored(), nil) },
+			password: "secret password",
+		},
+		{
+			name:     "wrong password",
+			setup:    func() { s.userRepo.On("GetByEmail", s.ctx, "test@example.com").Return(stored(), nil) },
+			password: "wrong password",
+			wantErr:  domain.ErrInvalidCredentials,
+		},
+		{
+			name:     "unknown email",
+			setup:    func() { s.userRepo.On("GetByEmail", s.ctx, "test@example.com").Return(nil, domain.ErrNotFound) },
+			password: "secret password",
+			wantErr:  domain.ErrInvalidCredentials,
+		},
+		{
+			name: "no password set",
+			setup: func() {
+				s.userRepo.On("GetByEmail", s.ctx, "test@example.com").Return(&domain.User{ID: "user-1"}, nil)
+			},
+			password: "secret password",
+			wantErr:  domain.ErrInvalidCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			tt.setup()
+
+			user, err := s.userUseCase.Authenticate(s.ctx, "test@example.com", tt.password)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(s.T(), err, tt.wantErr)
+				assert.Nil(s.T(), user)
+				return
+			}
+			assert.NoError(s.T(), err)
+			assert.Equal(s.T(), "user-1", user.ID)
+			assert.Empty(s.T(), user.PasswordHash)
+		})
+	}
+}
+
+func (s *UserUseCaseSuite) TestUpdateUser_ServiceAccountPassword() {
+	account := &domain.User{ID: actorID, Name: "ci-bot", OwnerID: uuid.New().String()}
+	s.userRepo.On("GetByID", s.ctx, account.ID).Return(account, nil)
+
+	_, err := s.userUseCase.UpdateUser(s.ctx, account.ID, "", "", "a new password", "")
+
+	var validationErr *domain.ValidationError
+	s.Require().True(errors.As(err, &validationErr))
+	assert.Equal(s.T(), "password", validationErr.Fields[0].Field)
+	s.userRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+}
+
 func TestUserUseCaseSuite(t *testing.T) {
 	suite.Run(t, new(UserUseCaseSuite))
 }
@@ -0,0 +1,8 @@
+-- Only read on shard 0, which holds the routing metadata.
+CREATE TABLE IF NOT EXISTS activity_bucket_map (
+    bucket_id INT PRIMARY KEY,
+    shard_id INT NOT NULL,
+    migrating_to INT,
+    draining_from INT,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
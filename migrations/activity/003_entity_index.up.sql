@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS activity_entity_index (
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id VARCHAR(36) NOT NULL,
+    bucket_id INT NOT NULL,
+    entity_bucket INT NOT NULL,
+    PRIMARY KEY (entity_type, entity_id, bucket_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_activity_entity_index_bucket ON activity_entity_index(entity_bucket);
@@ -0,0 +1,22 @@
+ALTER TABLE activities RENAME TO activities_partitioned;
+
+CREATE TABLE activities (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id VARCHAR(36) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    metadata TEXT,
+    created_at TIMESTAMP NOT NULL
+);
+
+INSERT INTO activities (id, user_id, entity_type, entity_id, action, metadata, created_at)
+SELECT id, user_id, entity_type, entity_id, action, metadata, created_at FROM activities_partitioned
+ON CONFLICT DO NOTHING;
+
+DROP TABLE activities_partitioned;
+
+CREATE INDEX IF NOT EXISTS idx_activities_user_id ON activities(user_id);
+CREATE INDEX IF NOT EXISTS idx_activities_entity ON activities(entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_activities_created_at ON activities(created_at);
+CREATE INDEX IF NOT EXISTS idx_activities_action ON activities(action);
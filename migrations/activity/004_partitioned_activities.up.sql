@@ -0,0 +1,48 @@
+-- Turns activities into a table range-partitioned by month on created_at.
+-- Existing rows are copied into monthly partitions; rows outside every
+-- partition land in activities_default.
+
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_class WHERE oid = to_regclass('activities') AND relkind = 'r') THEN
+        ALTER TABLE activities RENAME TO activities_legacy;
+        ALTER TABLE activities_legacy RENAME CONSTRAINT activities_pkey TO activities_legacy_pkey;
+        DROP INDEX IF EXISTS idx_activities_user_id, idx_activities_entity, idx_activities_created_at, idx_activities_action;
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS activities (
+    id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id VARCHAR(36) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    metadata TEXT,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+CREATE TABLE IF NOT EXISTS activities_default PARTITION OF activities DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_activities_user_id ON activities(user_id);
+CREATE INDEX IF NOT EXISTS idx_activities_entity ON activities(entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_activities_created_at ON activities(created_at);
+CREATE INDEX IF NOT EXISTS idx_activities_action ON activities(action);
+
+DO $$
+DECLARE
+    month DATE;
+BEGIN
+    IF to_regclass('activities_legacy') IS NOT NULL THEN
+        FOR month IN SELECT DISTINCT date_trunc('month', created_at)::date FROM activities_legacy LOOP
+            EXECUTE format('CREATE TABLE IF NOT EXISTS %I PARTITION OF activities FOR VALUES FROM (%L) TO (%L)',
+                'activities_' || to_char(month, 'YYYY_MM'), month, (month + INTERVAL '1 month')::date);
+        END LOOP;
+
+        INSERT INTO activities (id, user_id, entity_type, entity_id, action, metadata, created_at)
+        SELECT id, user_id, entity_type, entity_id, action, metadata, created_at FROM activities_legacy
+        ON CONFLICT DO NOTHING;
+
+        DROP TABLE activities_legacy;
+    END IF;
+END $$;
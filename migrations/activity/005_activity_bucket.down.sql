@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_activities_bucket_missing;
+DROP INDEX IF EXISTS idx_activities_bucket;
+
+ALTER TABLE activities DROP COLUMN IF EXISTS bucket_id;
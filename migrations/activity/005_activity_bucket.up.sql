@@ -0,0 +1,8 @@
+-- Stores each activity's bucket so a rebalance can select a bucket's rows by
+-- index. Rows written before this migration have no bucket until
+-- activity-admin bucket-backfill fills it in.
+
+ALTER TABLE activities ADD COLUMN IF NOT EXISTS bucket_id INT;
+
+CREATE INDEX IF NOT EXISTS idx_activities_bucket ON activities(bucket_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_activities_bucket_missing ON activities(user_id) WHERE bucket_id IS NULL;
@@ -0,0 +1,27 @@
+// Package migrations holds the versioned SQL schema of every service. Files
+// are named <version>_<name>.up.sql and are applied in version order by
+// pkg/migrate when a service starts; the matching .down.sql files are for
+// rolling back by hand.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed user/*.sql task/*.sql activity/*.sql
+var files embed.FS
+
+var (
+	User     = sub("user")
+	Task     = sub("task")
+	Activity = sub("activity")
+)
+
+func sub(dir string) fs.FS {
+	fsys, err := fs.Sub(files, dir)
+	if err != nil {
+		panic(err)
+	}
+	return fsys
+}
@@ -0,0 +1,5 @@
+-- Keyset pagination walks these in both directions.
+CREATE INDEX IF NOT EXISTS idx_tasks_created_at_id ON tasks(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_tasks_updated_at_id ON tasks(updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_tasks_due_date_id ON tasks(due_date, id);
+CREATE INDEX IF NOT EXISTS idx_tasks_priority ON tasks(priority);
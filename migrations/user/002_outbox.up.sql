@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS outbox (
+    id VARCHAR(36) PRIMARY KEY,
+    topic VARCHAR(255) NOT NULL,
+    message_key VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL,
+    published_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(created_at) WHERE published_at IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_hash TEXT;
@@ -0,0 +1,7 @@
+-- failed_at marks messages the relay gave up on; claimed_until lets a relay
+-- send a batch without holding row locks.
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS failed_at TIMESTAMP;
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMP;
+
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(created_at) WHERE published_at IS NULL AND failed_at IS NULL;
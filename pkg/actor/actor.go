@@ -0,0 +1,55 @@
+// Package actor carries the ID of the user on whose behalf a request runs.
+// The gateway sets it from the caller's token and forwards it to backend
+// services as gRPC metadata, where use cases read it for authorization.
+// Backend services trust the metadata, so their gRPC ports must only be
+// reachable from inside the deployment.
+package actor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKey is the gRPC metadata key the actor ID travels in.
+const MetadataKey = "x-actor-id"
+
+type actorKey struct{}
+
+// WithUserID stores the acting user's ID in ctx; an empty ID leaves ctx
+// unchanged.
+func WithUserID(ctx context.Context, userID string) context.Context {
+	if userID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// UserID returns the acting user's ID stored by WithUserID.
+func UserID(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(actorKey{}).(string)
+	return userID, ok
+}
+
+// UnaryServerInterceptor picks the actor up from incoming gRPC metadata.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(MetadataKey); len(values) > 0 {
+				ctx = WithUserID(ctx, values[0])
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// UnaryClientInterceptor forwards the actor from ctx as outgoing gRPC metadata.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if userID, ok := UserID(ctx); ok {
+			ctx = metadata.AppendToOutgoingContext(ctx, MetadataKey, userID)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
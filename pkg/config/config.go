@@ -14,6 +14,9 @@ type AppConfig struct {
 type ServerConfig struct {
 	GRPCPort int `mapstructure:"grpc_port"`
 	HTTPPort int `mapstructure:"http_port"`
+	// MetricsPort is where the gateway serves /metrics, apart from its
+	// public API port.
+	MetricsPort int `mapstructure:"metrics_port"`
 }
 
 type DatabaseConfig struct {
@@ -54,10 +57,46 @@ type ShardingConfig struct {
 	ShardCount   int           `mapstructure:"shard_count"`   
 	Shards       []ShardConfig `mapstructure:"shards"`
 	BucketMapping map[int]int  `mapstructure:"bucket_mapping"` 
+	// MappingRefreshMs is how often the bucket map is reloaded from the
+	// metadata table; bucket_mapping only seeds that table.
+	MappingRefreshMs int `mapstructure:"mapping_refresh_ms"`
+	// QueryTimeoutMs bounds each shard's part of a cross-shard read and
+	// QueryDeadlineMs the read as a whole.
+	QueryTimeoutMs  int                `mapstructure:"query_timeout_ms"`
+	QueryDeadlineMs int                `mapstructure:"query_deadline_ms"`
+	Partitioning    PartitioningConfig `mapstructure:"partitioning"`
+}
+
+// PartitioningConfig controls the monthly partitions of the activities table.
+type PartitioningConfig struct {
+	// PremakeMonths is how many months ahead partitions are created.
+	PremakeMonths int `mapstructure:"premake_months"`
+	// RetentionMonths is how many full months are kept before a partition
+	// is detached and archived; 0 keeps everything.
+	RetentionMonths      int `mapstructure:"retention_months"`
+	CheckIntervalMinutes int `mapstructure:"check_interval_minutes"`
+}
+
+type OutboxConfig struct {
+	PollIntervalMs int `mapstructure:"poll_interval_ms"`
+	BatchSize      int `mapstructure:"batch_size"`
+	MaxBackoffMs   int `mapstructure:"max_backoff_ms"`
+	RetentionHours int `mapstructure:"retention_hours"`
+	// MaxAttempts is how many times a message is sent before it is marked
+	// failed and skipped.
+	MaxAttempts int `mapstructure:"max_attempts"`
+}
+
+type ConsumerRetryConfig struct {
+	MaxAttempts      int `mapstructure:"max_attempts"`
+	InitialBackoffMs int `mapstructure:"initial_backoff_ms"`
+	MaxBackoffMs     int `mapstructure:"max_backoff_ms"`
 }
 
 type ServiceEndpoint struct {
-	GRPCAddr string `mapstructure:"grpc_addr"`
+	GRPCAddr   string `mapstructure:"grpc_addr"`
+	TimeoutMs  int    `mapstructure:"timeout_ms"`
+	MaxRetries int    `mapstructure:"max_retries"`
 }
 
 type ServicesConfig struct {
@@ -71,34 +110,88 @@ type UserServiceConfig struct {
 	Server   ServerConfig   `mapstructure:"server"`
 	Database DatabaseConfig `mapstructure:"database"`
 	Kafka    KafkaConfig    `mapstructure:"kafka"`
+	Outbox   OutboxConfig   `mapstructure:"outbox"`
 	Redis    RedisConfig    `mapstructure:"redis"`
 }
 
 type TaskServiceConfig struct {
-	App      AppConfig      `mapstructure:"app"`
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Kafka    KafkaConfig    `mapstructure:"kafka"`
-	Redis    RedisConfig    `mapstructure:"redis"`
+	App      AppConfig           `mapstructure:"app"`
+	Server   ServerConfig        `mapstructure:"server"`
+	Database DatabaseConfig      `mapstructure:"database"`
+	Kafka    KafkaConfig         `mapstructure:"kafka"`
+	Outbox   OutboxConfig        `mapstructure:"outbox"`
+	Redis    RedisConfig         `mapstructure:"redis"`
+	Workflow TaskWorkflowsConfig `mapstructure:"workflow"`
+	// UserService is where team memberships are looked up for authorization.
+	UserService ServiceEndpoint `mapstructure:"user_service"`
+}
+
+// WorkflowConfig maps each task status to the statuses it may change to.
+type WorkflowConfig map[string][]string
+
+// TaskWorkflowsConfig holds the default task workflow and per-team
+// overrides keyed by team ID.
+type TaskWorkflowsConfig struct {
+	Default WorkflowConfig            `mapstructure:"default"`
+	Teams   map[string]WorkflowConfig `mapstructure:"teams"`
 }
 
 type ActivityServiceConfig struct {
-	App      AppConfig      `mapstructure:"app"`
-	Server   ServerConfig   `mapstructure:"server"`
-	Sharding ShardingConfig `mapstructure:"sharding"`
-	Kafka    KafkaConfig    `mapstructure:"kafka"`
-	Redis    RedisConfig    `mapstructure:"redis"`
+	App      AppConfig           `mapstructure:"app"`
+	Server   ServerConfig        `mapstructure:"server"`
+	Sharding ShardingConfig      `mapstructure:"sharding"`
+	Kafka    KafkaConfig         `mapstructure:"kafka"`
+	Retry    ConsumerRetryConfig `mapstructure:"retry"`
+	Redis    RedisConfig         `mapstructure:"redis"`
+}
+
+// SigningKeyConfig is one HMAC key used to sign access and refresh tokens.
+type SigningKeyConfig struct {
+	ID     string `mapstructure:"id"`
+	Secret string `mapstructure:"secret"`
+}
+
+// AuthConfig configures the gateway's token issuing. New tokens are signed
+// with ActiveKey; tokens signed with any key in Keys are still accepted, so a
+// key can be rotated out once the tokens it signed have expired.
+type AuthConfig struct {
+	Issuer     string             `mapstructure:"issuer"`
+	AccessTTL  int                `mapstructure:"access_ttl"`
+	RefreshTTL int                `mapstructure:"refresh_ttl"`
+	ActiveKey  string             `mapstructure:"active_key"`
+	Keys       []SigningKeyConfig `mapstructure:"keys"`
+}
+
+// RateLimitRuleConfig sets the limit for requests whose path is Path or
+// lies under it and, if Method is set, whose method is Method.
+type RateLimitRuleConfig struct {
+	Method        string `mapstructure:"method"`
+	Path          string `mapstructure:"path"`
+	Requests      int    `mapstructure:"requests"`
+	PeriodSeconds int    `mapstructure:"period_seconds"`
+}
+
+// RateLimitConfig configures the gateway's per-client rate limits. Each
+// client may make Requests requests per PeriodSeconds to routes that no rule
+// in Routes matches; each rule has its own allowance. On top of that, each
+// IP address may make IPRequests requests per IPPeriodSeconds in total,
+// counted before authentication; zero disables the IP limit.
+type RateLimitConfig struct {
+	Enabled         bool                  `mapstructure:"enabled"`
+	Requests        int                   `mapstructure:"requests"`
+	PeriodSeconds   int                   `mapstructure:"period_seconds"`
+	Routes          []RateLimitRuleConfig `mapstructure:"routes"`
+	IPRequests      int                   `mapstructure:"ip_requests"`
+	IPPeriodSeconds int                   `mapstructure:"ip_period_seconds"`
 }
 
 type GatewayConfig struct {
-	App          AppConfig      `mapstructure:"app"`
-	Server       ServerConfig   `mapstructure:"server"`
-	Services     ServicesConfig `mapstructure:"services"`
-	Redis        RedisConfig    `mapstructure:"redis"`
-	UserDB       DatabaseConfig `mapstructure:"user_db"`
-	TaskDB       DatabaseConfig `mapstructure:"task_db"`
-	ActivityDB   ShardingConfig `mapstructure:"activity_db"`
-	Kafka        KafkaConfig    `mapstructure:"kafka"`
+	App       AppConfig       `mapstructure:"app"`
+	Server    ServerConfig    `mapstructure:"server"`
+	Services  ServicesConfig  `mapstructure:"services"`
+	Redis     RedisConfig     `mapstructure:"redis"`
+	Auth      AuthConfig      `mapstructure:"auth"`
+	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
 }
 
 func Load[T any](path string) (*T, error) {
@@ -0,0 +1,109 @@
+// Package events defines the envelope every Kafka event is wrapped in and
+// the schema registry used to validate payloads on both ends.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Envelope carries an event payload together with the metadata consumers
+// need to route and evolve it.
+type Envelope struct {
+	EventID       string            `json:"event_id"`
+	Type          string            `json:"type"`
+	SchemaVersion int               `json:"schema_version"`
+	OccurredAt    time.Time         `json:"occurred_at"`
+	Producer      string            `json:"producer"`
+	Trace         map[string]string `json:"trace,omitempty"`
+	Payload       json.RawMessage   `json:"payload"`
+}
+
+// Encoder wraps payloads in envelopes stamped with the latest registered
+// schema version of their type.
+type Encoder struct {
+	registry *Registry
+	producer string
+}
+
+func NewEncoder(registry *Registry, producer string) *Encoder {
+	return &Encoder{
+		registry: registry,
+		producer: producer,
+	}
+}
+
+// Encode validates payload against the latest schema of eventType and
+// returns the envelope to publish. Trace context is taken from ctx. The v1
+// schemas leave event_id optional so that legacy messages still decode, but
+// every new event must have one.
+func (e *Encoder) Encode(ctx context.Context, eventID, eventType string, payload interface{}) (*Envelope, error) {
+	if eventID == "" {
+		return nil, errors.Errorf("event %q has no ID", eventType)
+	}
+
+	version, ok := e.registry.Latest(eventType)
+	if !ok {
+		return nil, errors.Wrapf(ErrUnknownSchema, "event type %q", eventType)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal event payload")
+	}
+
+	if err := e.registry.Validate(eventType, version, data); err != nil {
+		return nil, err
+	}
+
+	return &Envelope{
+		EventID:       eventID,
+		Type:          eventType,
+		SchemaVersion: version,
+		OccurredAt:    time.Now().UTC(),
+		Producer:      e.producer,
+		Trace:         TraceFromContext(ctx),
+		Payload:       data,
+	}, nil
+}
+
+// Decoder unwraps and validates envelopes read from Kafka.
+type Decoder struct {
+	registry *Registry
+}
+
+func NewDecoder(registry *Registry) *Decoder {
+	return &Decoder{registry: registry}
+}
+
+// Decode parses data read from a topic that carries eventType. Messages
+// published before the envelope was introduced are bare version 1 payloads
+// and are wrapped on the fly; the oldest of them have no event_id.
+func (d *Decoder) Decode(eventType string, data []byte) (*Envelope, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal event")
+	}
+
+	if envelope.Type == "" {
+		envelope = Envelope{
+			EventID:       envelope.EventID,
+			Type:          eventType,
+			SchemaVersion: 1,
+			Payload:       data,
+		}
+	}
+
+	if envelope.Type != eventType {
+		return nil, errors.Errorf("unexpected event type %q, want %q", envelope.Type, eventType)
+	}
+
+	if err := d.registry.Validate(envelope.Type, envelope.SchemaVersion, envelope.Payload); err != nil {
+		return nil, err
+	}
+
+	return &envelope, nil
+}
@@ -0,0 +1,138 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	apievents "github.com/Sol1tud9/taskflow/api/events"
+)
+
+type taskCreated struct {
+	EventID   string    `json:"event_id"`
+	TaskID    string    `json:"task_id"`
+	CreatorID string    `json:"creator_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type EventsSuite struct {
+	suite.Suite
+	ctx      context.Context
+	registry *Registry
+	encoder  *Encoder
+	decoder  *Decoder
+}
+
+func (s *EventsSuite) SetupTest() {
+	s.ctx = context.Background()
+
+	registry, err := NewRegistry(apievents.Schemas)
+	s.Require().NoError(err)
+
+	s.registry = registry
+	s.encoder = NewEncoder(registry, "task-service")
+	s.decoder = NewDecoder(registry)
+}
+
+func (s *EventsSuite) TestRegistry_LatestVersion() {
+	version, ok := s.registry.Latest("task.updated")
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), 2, version)
+
+	version, ok = s.registry.Latest("task.created")
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), 1, version)
+
+	_, ok = s.registry.Latest("task.archived")
+	assert.False(s.T(), ok)
+}
+
+func (s *EventsSuite) TestRegistry_RejectsBadFileName() {
+	_, err := NewRegistry(fstest.MapFS{"task.created.json": {Data: []byte(`{}`)}})
+
+	assert.Error(s.T(), err)
+}
+
+func (s *EventsSuite) TestEncode_WrapsPayload() {
+	ctx := WithTrace(s.ctx, map[string]string{"x-request-id": "req-1", "traceparent": ""})
+	event := taskCreated{EventID: "evt-1", TaskID: "task-1", CreatorID: "user-1", CreatedAt: time.Now()}
+
+	envelope, err := s.encoder.Encode(ctx, event.EventID, "task.created", event)
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), "evt-1", envelope.EventID)
+	assert.Equal(s.T(), "task.created", envelope.Type)
+	assert.Equal(s.T(), 1, envelope.SchemaVersion)
+	assert.Equal(s.T(), "task-service", envelope.Producer)
+	assert.Equal(s.T(), map[string]string{"x-request-id": "req-1"}, envelope.Trace)
+	assert.False(s.T(), envelope.OccurredAt.IsZero())
+}
+
+func (s *EventsSuite) TestEncode_RejectsInvalidPayload() {
+	_, err := s.encoder.Encode(s.ctx, "evt-1", "task.created", taskCreated{EventID: "evt-1", TaskID: "task-1"})
+
+	assert.ErrorIs(s.T(), err, ErrInvalidEvent)
+}
+
+func (s *EventsSuite) TestEncode_RejectsUnknownType() {
+	_, err := s.encoder.Encode(s.ctx, "evt-1", "task.archived", struct{}{})
+
+	assert.ErrorIs(s.T(), err, ErrUnknownSchema)
+}
+
+func (s *EventsSuite) TestDecode_RoundTrip() {
+	event := taskCreated{EventID: "evt-1", TaskID: "task-1", CreatorID: "user-1", CreatedAt: time.Now()}
+	envelope, err := s.encoder.Encode(s.ctx, event.EventID, "task.created", event)
+	s.Require().NoError(err)
+	data, _ := json.Marshal(envelope)
+
+	decoded, err := s.decoder.Decode("task.created", data)
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), "task-service", decoded.Producer)
+	assert.JSONEq(s.T(), string(envelope.Payload), string(decoded.Payload))
+}
+
+func (s *EventsSuite) TestDecode_LegacyPayload() {
+	data := []byte(`{"event_id":"evt-1","task_id":"task-1","creator_id":"user-1","created_at":"2026-01-01T00:00:00Z"}`)
+
+	decoded, err := s.decoder.Decode("task.created", data)
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), "evt-1", decoded.EventID)
+	assert.Equal(s.T(), 1, decoded.SchemaVersion)
+	assert.JSONEq(s.T(), string(data), string(decoded.Payload))
+}
+
+func (s *EventsSuite) TestDecode_LegacyPayloadWithoutEventID() {
+	data := []byte(`{"task_id":"task-1","creator_id":"user-1","created_at":"2026-01-01T00:00:00Z"}`)
+
+	decoded, err := s.decoder.Decode("task.created", data)
+
+	s.Require().NoError(err)
+	assert.Empty(s.T(), decoded.EventID)
+	assert.Equal(s.T(), 1, decoded.SchemaVersion)
+}
+
+func (s *EventsSuite) TestEncode_RequiresEventID() {
+	_, err := s.encoder.Encode(s.ctx, "", "task.created", taskCreated{TaskID: "task-1", CreatorID: "user-1", CreatedAt: time.Now()})
+
+	assert.Error(s.T(), err)
+}
+
+func (s *EventsSuite) TestDecode_TypeMismatch() {
+	data := []byte(`{"event_id":"evt-1","type":"user.created","schema_version":1,"payload":{}}`)
+
+	_, err := s.decoder.Decode("task.created", data)
+
+	assert.Error(s.T(), err)
+}
+
+func TestEventsSuite(t *testing.T) {
+	suite.Run(t, new(EventsSuite))
+}
@@ -0,0 +1,104 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+var (
+	ErrUnknownSchema = errors.New("unknown event schema")
+	ErrInvalidEvent  = errors.New("event does not match schema")
+)
+
+var schemaFileName = regexp.MustCompile(`^(.+)\.v([0-9]+)\.json$`)
+
+type schemaKey struct {
+	eventType string
+	version   int
+}
+
+// Registry is a local schema registry: one compiled JSON Schema per event
+// type and version.
+type Registry struct {
+	schemas map[schemaKey]*jsonschema.Schema
+	latest  map[string]int
+}
+
+// NewRegistry compiles every <type>.v<version>.json file at the root of fsys.
+func NewRegistry(fsys fs.FS) (*Registry, error) {
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list event schemas")
+	}
+
+	r := &Registry{
+		schemas: make(map[schemaKey]*jsonschema.Schema),
+		latest:  make(map[string]int),
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+
+	for _, file := range files {
+		match := schemaFileName.FindStringSubmatch(path.Base(file))
+		if match == nil {
+			return nil, errors.Errorf("event schema %s is not named <type>.v<version>.json", file)
+		}
+		version, _ := strconv.Atoi(match[2])
+
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read event schema %s", file)
+		}
+
+		url := "mem://events/" + file
+		if err := compiler.AddResource(url, bytes.NewReader(data)); err != nil {
+			return nil, errors.Wrapf(err, "failed to load event schema %s", file)
+		}
+		schema, err := compiler.Compile(url)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compile event schema %s", file)
+		}
+
+		key := schemaKey{eventType: match[1], version: version}
+		r.schemas[key] = schema
+		if version > r.latest[key.eventType] {
+			r.latest[key.eventType] = version
+		}
+	}
+
+	return r, nil
+}
+
+// Latest returns the newest registered version of eventType.
+func (r *Registry) Latest(eventType string) (int, bool) {
+	version, ok := r.latest[eventType]
+	return version, ok
+}
+
+// Validate checks payload against the schema registered for eventType at version.
+func (r *Registry) Validate(eventType string, version int, payload []byte) error {
+	schema, ok := r.schemas[schemaKey{eventType: eventType, version: version}]
+	if !ok {
+		return errors.Wrapf(ErrUnknownSchema, "%s v%d", eventType, version)
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(payload, &doc); err != nil {
+		return errors.Wrapf(ErrInvalidEvent, "%s v%d: %v", eventType, version, err)
+	}
+
+	if err := schema.Validate(doc); err != nil {
+		return errors.Wrap(ErrInvalidEvent, fmt.Sprintf("%s v%d: %v", eventType, version, err))
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package events
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TraceKeys lists the request headers / gRPC metadata keys copied into the
+// envelope so an event can be correlated with the request that caused it.
+var TraceKeys = []string{"traceparent", "tracestate", "x-request-id"}
+
+type traceKey struct{}
+
+// WithTrace stores trace context in ctx; empty values are dropped.
+func WithTrace(ctx context.Context, trace map[string]string) context.Context {
+	clean := make(map[string]string, len(trace))
+	for k, v := range trace {
+		if v != "" {
+			clean[k] = v
+		}
+	}
+	if len(clean) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, traceKey{}, clean)
+}
+
+func TraceFromContext(ctx context.Context) map[string]string {
+	trace, _ := ctx.Value(traceKey{}).(map[string]string)
+	return trace
+}
+
+// UnaryServerInterceptor picks trace context up from incoming gRPC metadata.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			trace := make(map[string]string)
+			for _, key := range TraceKeys {
+				if values := md.Get(key); len(values) > 0 {
+					trace[key] = values[0]
+				}
+			}
+			ctx = WithTrace(ctx, trace)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// UnaryClientInterceptor forwards trace context from ctx as outgoing gRPC metadata.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		for key, value := range TraceFromContext(ctx) {
+			ctx = metadata.AppendToOutgoingContext(ctx, key, value)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
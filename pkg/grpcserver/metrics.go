@@ -0,0 +1,46 @@
+package grpcserver
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	handledTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "RPCs completed by the server, by status code.",
+	}, []string{"grpc_service", "grpc_method", "grpc_code"})
+
+	handlingSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Latency of RPCs handled by the server.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_service", "grpc_method"})
+)
+
+// unaryMetrics records the latency and status code of every unary RPC.
+func unaryMetrics(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	service, method := splitMethod(info.FullMethod)
+	handlingSeconds.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+	handledTotal.WithLabelValues(service, method, status.Code(err).String()).Inc()
+
+	return resp, err
+}
+
+// splitMethod splits "/package.Service/Method" into service and method.
+func splitMethod(fullMethod string) (string, string) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return "unknown", fullMethod
+	}
+	return service, method
+}
@@ -0,0 +1,32 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryMetrics_RecordsCode(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/taskflow.task.TaskService/GetTask"}
+	handled := handledTotal.WithLabelValues("taskflow.task.TaskService", "GetTask", codes.NotFound.String())
+	before := testutil.ToFloat64(handled)
+
+	_, err := unaryMetrics(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "task not found")
+	})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, before+1, testutil.ToFloat64(handled))
+}
+
+func TestSplitMethod(t *testing.T) {
+	service, method := splitMethod("/grpc.health.v1.Health/Check")
+
+	assert.Equal(t, "grpc.health.v1.Health", service)
+	assert.Equal(t, "Check", method)
+}
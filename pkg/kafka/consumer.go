@@ -38,6 +38,27 @@ func (c *Consumer) Read(ctx context.Context) (kafka.Message, error) {
 	return msg, nil
 }
 
+// Fetch reads the next message without committing its offset; call Commit
+// once the message has been handled.
+func (c *Consumer) Fetch(ctx context.Context) (kafka.Message, error) {
+	msg, err := c.reader.FetchMessage(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Error("failed to fetch message from kafka", zap.Error(err))
+		}
+		return kafka.Message{}, err
+	}
+	return msg, nil
+}
+
+func (c *Consumer) Commit(ctx context.Context, msg kafka.Message) error {
+	if err := c.reader.CommitMessages(ctx, msg); err != nil {
+		logger.Error("failed to commit kafka message", zap.Error(err), zap.String("topic", msg.Topic))
+		return err
+	}
+	return nil
+}
+
 func (c *Consumer) Close() error {
 	return c.reader.Close()
 }
@@ -0,0 +1,19 @@
+package kafka
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	publishDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kafka_producer_publish_duration_seconds",
+		Help:    "Latency of writing a message to Kafka, including failed writes.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"topic"})
+
+	publishErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_producer_publish_errors_total",
+		Help: "Messages that could not be written to Kafka.",
+	}, []string{"topic"})
+)
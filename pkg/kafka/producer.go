@@ -44,12 +44,22 @@ func (p *Producer) Publish(ctx context.Context, key string, value interface{}) e
 		return err
 	}
 
+	return p.PublishBytes(ctx, key, data)
+}
+
+// PublishBytes writes an already encoded value with optional headers.
+func (p *Producer) PublishBytes(ctx context.Context, key string, data []byte, headers ...kafka.Header) error {
 	msg := kafka.Message{
-		Key:   []byte(key),
-		Value: data,
+		Key:     []byte(key),
+		Value:   data,
+		Headers: headers,
 	}
 
-	if err := p.writer.WriteMessages(ctx, msg); err != nil {
+	start := time.Now()
+	err := p.writer.WriteMessages(ctx, msg)
+	publishDuration.WithLabelValues(p.topic).Observe(time.Since(start).Seconds())
+	if err != nil {
+		publishErrorsTotal.WithLabelValues(p.topic).Inc()
 		logger.Error("failed to write message to kafka", zap.Error(err), zap.String("topic", p.topic))
 		return err
 	}
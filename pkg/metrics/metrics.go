@@ -0,0 +1,34 @@
+// Package metrics exposes the Prometheus metrics of a taskflow binary.
+// Packages register their own metrics with promauto; this package serves
+// them and adds collectors for shared resources such as connection pools.
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path is where every service serves its metrics.
+const Path = "/metrics"
+
+// Handler serves the metrics in the default registry.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// NewServer returns an HTTP server that serves only the metrics, for services
+// that have no HTTP API of their own and for the gateway, whose API port is
+// public.
+func NewServer(port int) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle(Path, Handler())
+
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
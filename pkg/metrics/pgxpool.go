@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolCollector reports the statistics of one pgx pool, read when the
+// metrics are scraped.
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	totalConns      *prometheus.Desc
+	acquiredConns   *prometheus.Desc
+	idleConns       *prometheus.Desc
+	maxConns        *prometheus.Desc
+	acquires        *prometheus.Desc
+	emptyAcquires   *prometheus.Desc
+	canceled        *prometheus.Desc
+	acquireDuration *prometheus.Desc
+}
+
+// RegisterPool reports pool's statistics labelled with the database it
+// connects to and, for activity shards, the shard number.
+func RegisterPool(pool *pgxpool.Pool, db, shard string) error {
+	labels := prometheus.Labels{"db": db, "shard": shard}
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc("pgxpool_"+name, help, nil, labels)
+	}
+
+	return prometheus.Register(&poolCollector{
+		pool:            pool,
+		totalConns:      desc("total_conns", "Connections in the pool."),
+		acquiredConns:   desc("acquired_conns", "Connections currently in use."),
+		idleConns:       desc("idle_conns", "Idle connections in the pool."),
+		maxConns:        desc("max_conns", "Maximum size of the pool."),
+		acquires:        desc("acquires_total", "Connections acquired from the pool."),
+		emptyAcquires:   desc("empty_acquires_total", "Acquires that waited because the pool had no idle connection."),
+		canceled:        desc("canceled_acquires_total", "Acquires canceled by their context."),
+		acquireDuration: desc("acquire_duration_seconds_total", "Time spent acquiring connections."),
+	})
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.totalConns
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.maxConns
+	ch <- c.acquires
+	ch <- c.emptyAcquires
+	ch <- c.canceled
+	ch <- c.acquireDuration
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceled, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
@@ -0,0 +1,193 @@
+package migrate
+
+import (
+	"context"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+// lockKey is the advisory lock held while migrating, so service instances
+// starting together apply migrations one at a time.
+const lockKey int64 = 0x7461736b666c6f77 // "taskflow"
+
+var fileName = regexp.MustCompile(`^([0-9]+)_(.+)\.up\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	SQL     string
+}
+
+// Load reads the <version>_<name>.up.sql files at the root of fsys in
+// version order.
+func Load(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "*.up.sql")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list migrations")
+	}
+
+	migrations := make([]Migration, 0, len(files))
+	seen := make(map[int64]string)
+	for _, file := range files {
+		match := fileName.FindStringSubmatch(path.Base(file))
+		if match == nil {
+			return nil, errors.Errorf("migration %s is not named <version>_<name>.up.sql", file)
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid version in %s", file)
+		}
+		if other, ok := seen[version]; ok {
+			return nil, errors.Errorf("migrations %s and %s share version %d", other, file, version)
+		}
+		seen[version] = file
+
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read migration %s", file)
+		}
+
+		migrations = append(migrations, Migration{Version: version, Name: match[2], SQL: string(data)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up applies the migrations in fsys that db has not seen yet. Each runs in
+// its own transaction together with its schema_migrations row.
+func Up(ctx context.Context, db *pgxpool.Pool, fsys fs.FS) error {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return err
+	}
+
+	conn, err := db.Acquire(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to acquire connection")
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return errors.Wrap(err, "failed to take migration lock")
+	}
+	defer conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey)
+
+	if err := ensureTable(ctx, conn.Conn(), migrations); err != nil {
+		return err
+	}
+
+	applied, err := appliedVersions(ctx, conn.Conn())
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+
+		err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, m.SQL); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, m.Version, m.Name)
+			return err
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to apply migration %d_%s", m.Version, m.Name)
+		}
+		logger.Info("migration applied", zap.Int64("version", m.Version), zap.String("name", m.Name))
+	}
+
+	return nil
+}
+
+func ensureTable(ctx context.Context, conn *pgx.Conn, migrations []Migration) error {
+	var legacy bool
+	err := conn.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = 'schema_migrations' AND column_name = 'dirty'
+		)`).Scan(&legacy)
+	if err != nil {
+		return errors.Wrap(err, "failed to inspect schema_migrations")
+	}
+	if legacy {
+		return adoptLegacyTable(ctx, conn, migrations)
+	}
+
+	_, err = conn.Exec(ctx, createTableQuery)
+	return errors.Wrap(err, "failed to create schema_migrations")
+}
+
+const createTableQuery = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version BIGINT PRIMARY KEY,
+	name TEXT NOT NULL,
+	applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
+// adoptLegacyTable converts the single-row schema_migrations table written by
+// the golang-migrate CLI, which older setups ran by hand, into one row per
+// applied version.
+func adoptLegacyTable(ctx context.Context, conn *pgx.Conn, migrations []Migration) error {
+	var (
+		current int64
+		dirty   bool
+	)
+	err := conn.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&current, &dirty)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return errors.Wrap(err, "failed to read legacy schema_migrations")
+	}
+	if dirty {
+		return errors.Errorf("database is dirty at migration %d; fix it by hand before starting", current)
+	}
+
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DROP TABLE schema_migrations`); err != nil {
+			return errors.Wrap(err, "failed to drop legacy schema_migrations")
+		}
+		if _, err := tx.Exec(ctx, createTableQuery); err != nil {
+			return errors.Wrap(err, "failed to create schema_migrations")
+		}
+		for _, m := range migrations {
+			if m.Version > current {
+				break
+			}
+			if _, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, m.Version, m.Name); err != nil {
+				return errors.Wrap(err, "failed to record migration")
+			}
+		}
+		logger.Info("adopted legacy schema_migrations table", zap.Int64("version", current))
+		return nil
+	})
+}
+
+func appliedVersions(ctx context.Context, conn *pgx.Conn) (map[int64]struct{}, error) {
+	rows, err := conn.Query(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read schema_migrations")
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]struct{})
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, errors.Wrap(err, "failed to scan schema_migrations")
+		}
+		applied[version] = struct{}{}
+	}
+
+	return applied, rows.Err()
+}
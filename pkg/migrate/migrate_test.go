@@ -0,0 +1,70 @@
+package migrate
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Sol1tud9/taskflow/migrations"
+)
+
+type LoadTestSuite struct {
+	suite.Suite
+}
+
+func (s *LoadTestSuite) TestSortsByVersion() {
+	fsys := fstest.MapFS{
+		"010_tenth.up.sql":    {Data: []byte("SELECT 10;")},
+		"002_second.up.sql":   {Data: []byte("SELECT 2;")},
+		"002_second.down.sql": {Data: []byte("SELECT -2;")},
+		"001_init.up.sql":     {Data: []byte("SELECT 1;")},
+		"README.md":           {Data: []byte("docs")},
+	}
+
+	migrations, err := Load(fsys)
+	s.Require().NoError(err)
+	s.Require().Len(migrations, 3)
+
+	s.Equal(Migration{Version: 1, Name: "init", SQL: "SELECT 1;"}, migrations[0])
+	s.Equal(int64(2), migrations[1].Version)
+	s.Equal("second", migrations[1].Name)
+	s.Equal(int64(10), migrations[2].Version)
+}
+
+func (s *LoadTestSuite) TestRejectsDuplicateVersions() {
+	fsys := fstest.MapFS{
+		"001_init.up.sql": {Data: []byte("SELECT 1;")},
+		"1_again.up.sql":  {Data: []byte("SELECT 1;")},
+	}
+
+	_, err := Load(fsys)
+	s.Error(err)
+}
+
+func (s *LoadTestSuite) TestRejectsUnversionedFiles() {
+	fsys := fstest.MapFS{
+		"init.up.sql": {Data: []byte("SELECT 1;")},
+	}
+
+	_, err := Load(fsys)
+	s.Error(err)
+}
+
+func (s *LoadTestSuite) TestEmbeddedMigrations() {
+	for name, fsys := range map[string]fs.FS{
+		"user":     migrations.User,
+		"task":     migrations.Task,
+		"activity": migrations.Activity,
+	} {
+		loaded, err := Load(fsys)
+		s.Require().NoError(err, name)
+		s.Require().NotEmpty(loaded, name)
+		s.Equal(int64(1), loaded[0].Version, name)
+	}
+}
+
+func TestLoadTestSuite(t *testing.T) {
+	suite.Run(t, new(LoadTestSuite))
+}
@@ -0,0 +1,40 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/Sol1tud9/taskflow/pkg/kafka"
+)
+
+// KafkaSender publishes relayed messages with one producer per topic.
+type KafkaSender struct {
+	producers map[string]*kafka.Producer
+}
+
+func NewKafkaSender(brokers []string, topics map[string]string) *KafkaSender {
+	producers := make(map[string]*kafka.Producer, len(topics))
+	for _, topic := range topics {
+		if _, ok := producers[topic]; !ok {
+			producers[topic] = kafka.NewProducer(brokers, topic)
+		}
+	}
+
+	return &KafkaSender{producers: producers}
+}
+
+func (s *KafkaSender) Send(ctx context.Context, topic, key string, payload []byte) error {
+	producer, ok := s.producers[topic]
+	if !ok {
+		return errors.Errorf("no producer configured for topic %q", topic)
+	}
+	return producer.PublishBytes(ctx, key, payload)
+}
+
+func (s *KafkaSender) Close() error {
+	for _, producer := range s.producers {
+		_ = producer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package outbox
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	pendingMessages = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "outbox_pending_messages",
+		Help: "Number of outbox messages not yet published to Kafka.",
+	}, []string{"service"})
+
+	relayLagSeconds = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "outbox_relay_lag_seconds",
+		Help: "Age of the oldest unpublished outbox message.",
+	}, []string{"service"})
+
+	publishedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "outbox_published_total",
+		Help: "Outbox messages published to Kafka.",
+	}, []string{"service", "topic"})
+
+	publishErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "outbox_publish_errors_total",
+		Help: "Failed attempts to publish outbox messages to Kafka.",
+	}, []string{"service", "topic"})
+
+	failedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "outbox_failed_total",
+		Help: "Outbox messages given up on after max_attempts failed attempts.",
+	}, []string{"service", "topic"})
+
+	failedMessages = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "outbox_failed_messages",
+		Help: "Number of failed outbox messages waiting for an operator.",
+	}, []string{"service"})
+)
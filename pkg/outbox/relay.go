@@ -0,0 +1,235 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+const (
+	defaultPollInterval = 500 * time.Millisecond
+	defaultBatchSize    = 100
+	defaultMaxBackoff   = 30 * time.Second
+	defaultRetention    = 24 * time.Hour
+	defaultMaxAttempts  = 10
+	cleanupInterval     = time.Hour
+	// standbyInterval is how often a relay without the lock retries it.
+	standbyInterval = 5 * time.Second
+)
+
+// Sender delivers a relayed message to the broker.
+type Sender interface {
+	Send(ctx context.Context, topic, key string, payload []byte) error
+}
+
+type store interface {
+	TryLock(ctx context.Context) (bool, error)
+	Unlock(ctx context.Context) error
+	Process(ctx context.Context, limit, maxAttempts int, fn func(ctx context.Context, msg Message) error) (int, error)
+	Pending(ctx context.Context) (int, time.Time, error)
+	Failed(ctx context.Context) (int, error)
+	DeletePublished(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Relay drains the outbox to Kafka. Messages are marked published only
+// after the broker accepted them, so delivery is at-least-once. When several
+// replicas run, only the one holding the store's lock relays, which keeps
+// events of one key in order; the others stand by.
+type Relay struct {
+	service      string
+	store        store
+	sender       Sender
+	pollInterval time.Duration
+	batchSize    int
+	maxBackoff   time.Duration
+	retention    time.Duration
+	maxAttempts  int
+	failures     int
+	leading      bool
+}
+
+func NewRelay(service string, store store, sender Sender, cfg config.OutboxConfig) *Relay {
+	r := &Relay{
+		service:      service,
+		store:        store,
+		sender:       sender,
+		pollInterval: time.Duration(cfg.PollIntervalMs) * time.Millisecond,
+		batchSize:    cfg.BatchSize,
+		maxBackoff:   time.Duration(cfg.MaxBackoffMs) * time.Millisecond,
+		retention:    time.Duration(cfg.RetentionHours) * time.Hour,
+		maxAttempts:  cfg.MaxAttempts,
+	}
+
+	if r.pollInterval <= 0 {
+		r.pollInterval = defaultPollInterval
+	}
+	if r.batchSize <= 0 {
+		r.batchSize = defaultBatchSize
+	}
+	if r.maxBackoff <= 0 {
+		r.maxBackoff = defaultMaxBackoff
+	}
+	if r.retention <= 0 {
+		r.retention = defaultRetention
+	}
+	if r.maxAttempts <= 0 {
+		r.maxAttempts = defaultMaxAttempts
+	}
+
+	return r
+}
+
+// Run relays messages until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	logger.Info("outbox relay started", zap.String("service", r.service))
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	cleanup := time.NewTicker(cleanupInterval)
+	defer cleanup.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.stepDown()
+			logger.Info("outbox relay stopped", zap.String("service", r.service))
+			return
+		case <-cleanup.C:
+			r.cleanup(ctx)
+		case <-timer.C:
+			timer.Reset(r.tick(ctx))
+		}
+	}
+}
+
+// tick relays one batch and returns how long to wait before the next one.
+func (r *Relay) tick(ctx context.Context) time.Duration {
+	if !r.lead(ctx) {
+		return standbyInterval
+	}
+
+	published, err := r.store.Process(ctx, r.batchSize, r.maxAttempts, r.send)
+	r.observeLag(ctx)
+	r.observeFailed(ctx)
+
+	if err != nil {
+		r.failures++
+		delay := r.backoff()
+		logger.Error("failed to relay outbox messages",
+			zap.Error(err),
+			zap.String("service", r.service),
+			zap.Int("failures", r.failures),
+			zap.Duration("retry_in", delay),
+		)
+		return delay
+	}
+
+	r.failures = 0
+	if published == r.batchSize {
+		return 0
+	}
+	return r.pollInterval
+}
+
+// lead reports whether this relay holds the lock, taking it if it is free.
+func (r *Relay) lead(ctx context.Context) bool {
+	leading, err := r.store.TryLock(ctx)
+	if err != nil {
+		logger.Error("failed to take outbox relay lock", zap.Error(err), zap.String("service", r.service))
+		leading = false
+	}
+	if leading != r.leading {
+		logger.Info("outbox relay lock changed", zap.String("service", r.service), zap.Bool("leading", leading))
+		r.leading = leading
+	}
+	return leading
+}
+
+func (r *Relay) stepDown() {
+	if !r.leading {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := r.store.Unlock(ctx); err != nil {
+		logger.Error("failed to release outbox relay lock", zap.Error(err), zap.String("service", r.service))
+	}
+	r.leading = false
+}
+
+func (r *Relay) send(ctx context.Context, msg Message) error {
+	if err := r.sender.Send(ctx, msg.Topic, msg.Key, msg.Payload); err != nil {
+		publishErrorsTotal.WithLabelValues(r.service, msg.Topic).Inc()
+		if msg.Attempts+1 >= r.maxAttempts {
+			failedTotal.WithLabelValues(r.service, msg.Topic).Inc()
+			logger.Error("outbox message failed permanently, skipping it",
+				zap.Error(err),
+				zap.String("service", r.service),
+				zap.String("id", msg.ID),
+				zap.String("topic", msg.Topic),
+				zap.String("key", msg.Key),
+				zap.Int("attempts", msg.Attempts+1),
+			)
+		}
+		return err
+	}
+
+	publishedTotal.WithLabelValues(r.service, msg.Topic).Inc()
+	return nil
+}
+
+func (r *Relay) backoff() time.Duration {
+	shift := r.failures
+	if shift > 16 {
+		shift = 16
+	}
+
+	delay := r.pollInterval << shift
+	if delay <= 0 || delay > r.maxBackoff {
+		return r.maxBackoff
+	}
+	return delay
+}
+
+func (r *Relay) observeLag(ctx context.Context) {
+	pending, oldest, err := r.store.Pending(ctx)
+	if err != nil {
+		logger.Error("failed to read outbox lag", zap.Error(err), zap.String("service", r.service))
+		return
+	}
+
+	pendingMessages.WithLabelValues(r.service).Set(float64(pending))
+	if oldest.IsZero() {
+		relayLagSeconds.WithLabelValues(r.service).Set(0)
+		return
+	}
+	relayLagSeconds.WithLabelValues(r.service).Set(time.Since(oldest).Seconds())
+}
+
+func (r *Relay) observeFailed(ctx context.Context) {
+	failed, err := r.store.Failed(ctx)
+	if err != nil {
+		logger.Error("failed to count failed outbox messages", zap.Error(err), zap.String("service", r.service))
+		return
+	}
+
+	failedMessages.WithLabelValues(r.service).Set(float64(failed))
+}
+
+func (r *Relay) cleanup(ctx context.Context) {
+	deleted, err := r.store.DeletePublished(ctx, time.Now().Add(-r.retention))
+	if err != nil {
+		logger.Error("failed to clean up outbox", zap.Error(err), zap.String("service", r.service))
+		return
+	}
+
+	if deleted > 0 {
+		logger.Info("outbox cleaned up", zap.String("service", r.service), zap.Int64("deleted", deleted))
+	}
+}
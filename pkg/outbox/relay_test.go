@@ -0,0 +1,209 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Sol1tud9/taskflow/pkg/config"
+	"github.com/Sol1tud9/taskflow/pkg/logger"
+)
+
+type fakeStore struct {
+	pending   []Message
+	published []Message
+	failed    []Message
+	oldest    time.Time
+	// lockedElsewhere means another relay holds the lock.
+	lockedElsewhere bool
+	locked          bool
+}
+
+func (f *fakeStore) TryLock(ctx context.Context) (bool, error) {
+	f.locked = !f.lockedElsewhere
+	return f.locked, nil
+}
+
+func (f *fakeStore) Unlock(ctx context.Context) error {
+	f.locked = false
+	return nil
+}
+
+func (f *fakeStore) Process(ctx context.Context, limit, maxAttempts int, fn func(ctx context.Context, msg Message) error) (int, error) {
+	count := 0
+	for len(f.pending) > 0 && count < limit {
+		msg := f.pending[0]
+		if err := fn(ctx, msg); err != nil {
+			f.pending[0].Attempts++
+			if f.pending[0].Attempts >= maxAttempts {
+				f.failed = append(f.failed, f.pending[0])
+				f.pending = f.pending[1:]
+			}
+			return count, err
+		}
+		f.published = append(f.published, msg)
+		f.pending = f.pending[1:]
+		count++
+	}
+	return count, nil
+}
+
+func (f *fakeStore) Pending(ctx context.Context) (int, time.Time, error) {
+	if len(f.pending) == 0 {
+		return 0, time.Time{}, nil
+	}
+	return len(f.pending), f.oldest, nil
+}
+
+func (f *fakeStore) Failed(ctx context.Context) (int, error) {
+	return len(f.failed), nil
+}
+
+func (f *fakeStore) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+type fakeSender struct {
+	err error
+	// failKey, when set, fails only the messages with that key.
+	failKey string
+	sent    []string
+}
+
+func (f *fakeSender) Send(ctx context.Context, topic, key string, payload []byte) error {
+	if f.err != nil && (f.failKey == "" || f.failKey == key) {
+		return f.err
+	}
+	f.sent = append(f.sent, key)
+	return nil
+}
+
+type RelaySuite struct {
+	suite.Suite
+	ctx    context.Context
+	store  *fakeStore
+	sender *fakeSender
+	relay  *Relay
+}
+
+func (s *RelaySuite) SetupSuite() {
+	_ = logger.Init("error")
+}
+
+func (s *RelaySuite) SetupTest() {
+	s.ctx = context.Background()
+	s.store = &fakeStore{oldest: time.Now().Add(-time.Minute)}
+	s.sender = &fakeSender{}
+	s.relay = NewRelay("test-"+s.T().Name(), s.store, s.sender, config.OutboxConfig{
+		PollIntervalMs: 100,
+		BatchSize:      2,
+		MaxBackoffMs:   1000,
+		MaxAttempts:    5,
+	})
+}
+
+func (s *RelaySuite) TestTick_PublishesInOrder() {
+	s.store.pending = []Message{
+		{ID: "1", Topic: "task.created", Key: "a"},
+		{ID: "2", Topic: "task.updated", Key: "a"},
+	}
+
+	delay := s.relay.tick(s.ctx)
+
+	assert.Equal(s.T(), []string{"a", "a"}, s.sender.sent)
+	assert.Empty(s.T(), s.store.pending)
+	assert.Equal(s.T(), time.Duration(0), delay, "a full batch is followed by another one immediately")
+	assert.Equal(s.T(), 1.0, testutil.ToFloat64(publishedTotal.WithLabelValues(s.relay.service, "task.created")))
+}
+
+func (s *RelaySuite) TestTick_StandsByWithoutLock() {
+	s.store.lockedElsewhere = true
+	s.store.pending = []Message{{ID: "1", Topic: "task.created", Key: "a"}}
+
+	delay := s.relay.tick(s.ctx)
+
+	assert.Equal(s.T(), standbyInterval, delay)
+	assert.Empty(s.T(), s.sender.sent)
+	assert.Len(s.T(), s.store.pending, 1)
+}
+
+func (s *RelaySuite) TestStepDown_ReleasesLock() {
+	s.relay.tick(s.ctx)
+	s.Require().True(s.store.locked)
+
+	s.relay.stepDown()
+
+	assert.False(s.T(), s.store.locked)
+	assert.False(s.T(), s.relay.leading)
+}
+
+func (s *RelaySuite) TestTick_WaitsPollIntervalWhenDrained() {
+	s.store.pending = []Message{{ID: "1", Topic: "task.created", Key: "a"}}
+
+	delay := s.relay.tick(s.ctx)
+
+	assert.Equal(s.T(), 100*time.Millisecond, delay)
+	assert.Equal(s.T(), 0.0, testutil.ToFloat64(pendingMessages.WithLabelValues(s.relay.service)))
+	assert.Equal(s.T(), 0.0, testutil.ToFloat64(relayLagSeconds.WithLabelValues(s.relay.service)))
+}
+
+func (s *RelaySuite) TestTick_BacksOffOnFailure() {
+	s.store.pending = []Message{{ID: "1", Topic: "task.created", Key: "a"}}
+	s.sender.err = errors.New("kafka unavailable")
+
+	assert.Equal(s.T(), 200*time.Millisecond, s.relay.tick(s.ctx))
+	assert.Equal(s.T(), 400*time.Millisecond, s.relay.tick(s.ctx))
+	assert.Equal(s.T(), 800*time.Millisecond, s.relay.tick(s.ctx))
+	assert.Equal(s.T(), 1000*time.Millisecond, s.relay.tick(s.ctx))
+
+	assert.Len(s.T(), s.store.pending, 1)
+	assert.Equal(s.T(), 4, s.store.pending[0].Attempts)
+	assert.Equal(s.T(), 4.0, testutil.ToFloat64(publishErrorsTotal.WithLabelValues(s.relay.service, "task.created")))
+	assert.Equal(s.T(), 1.0, testutil.ToFloat64(pendingMessages.WithLabelValues(s.relay.service)))
+	assert.GreaterOrEqual(s.T(), testutil.ToFloat64(relayLagSeconds.WithLabelValues(s.relay.service)), 60.0)
+}
+
+func (s *RelaySuite) TestTick_ResetsBackoffAfterRecovery() {
+	s.store.pending = []Message{{ID: "1", Topic: "task.created", Key: "a"}}
+	s.sender.err = errors.New("kafka unavailable")
+	s.relay.tick(s.ctx)
+	s.relay.tick(s.ctx)
+
+	s.sender.err = nil
+	assert.Equal(s.T(), 100*time.Millisecond, s.relay.tick(s.ctx))
+
+	s.store.pending = []Message{{ID: "2", Topic: "task.created", Key: "b"}}
+	s.sender.err = errors.New("kafka unavailable")
+	assert.Equal(s.T(), 200*time.Millisecond, s.relay.tick(s.ctx))
+}
+
+func (s *RelaySuite) TestTick_SkipsMessageAfterMaxAttempts() {
+	s.store.pending = []Message{
+		{ID: "1", Topic: "task.created", Key: "a"},
+		{ID: "2", Topic: "task.created", Key: "b"},
+	}
+	s.sender.err = errors.New("message too large")
+	s.sender.failKey = "a"
+
+	for i := 0; i < 5; i++ {
+		s.relay.tick(s.ctx)
+	}
+	assert.Empty(s.T(), s.sender.sent, "later messages wait while the first one is retried")
+
+	s.relay.tick(s.ctx)
+
+	assert.Equal(s.T(), []string{"b"}, s.sender.sent)
+	s.Require().Len(s.store.failed, 1)
+	assert.Equal(s.T(), "1", s.store.failed[0].ID)
+	assert.Equal(s.T(), 1.0, testutil.ToFloat64(failedTotal.WithLabelValues(s.relay.service, "task.created")))
+	assert.Equal(s.T(), 1.0, testutil.ToFloat64(failedMessages.WithLabelValues(s.relay.service)))
+}
+
+func TestRelaySuite(t *testing.T) {
+	suite.Run(t, new(RelaySuite))
+}
@@ -0,0 +1,377 @@
+// Package outbox implements the transactional outbox: events are stored in
+// the same transaction as the entity change and relayed to Kafka afterwards.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+
+	"github.com/Sol1tud9/taskflow/pkg/pgtx"
+)
+
+type Message struct {
+	ID        string
+	Topic     string
+	Key       string
+	Payload   []byte
+	Attempts  int
+	CreatedAt time.Time
+}
+
+// claimTTL is how long a relay owns the messages it claimed. Messages of a
+// relay that died mid-batch are picked up again once their claim expires.
+const claimTTL = time.Minute
+
+// relayLockKey is the advisory lock held by the one relay that drains the
+// outbox of a database.
+const relayLockKey = 0x6f7574626f78
+
+type Store struct {
+	db *pgxpool.Pool
+	// leader is the connection holding relayLockKey, if this store holds it.
+	leader *pgxpool.Conn
+}
+
+func NewStore(db *pgxpool.Pool) *Store {
+	return &Store{db: db}
+}
+
+// Add stores value as a pending message. It joins the transaction carried
+// by ctx, so the message is committed or rolled back with the entity change.
+func (s *Store) Add(ctx context.Context, topic, key string, value interface{}) error {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal outbox payload")
+	}
+
+	query := squirrel.Insert("outbox").
+		Columns("id", "topic", "message_key", "payload", "created_at").
+		Values(uuid.New().String(), topic, key, payload, time.Now()).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	if _, err := pgtx.Conn(ctx, s.db).Exec(ctx, sql, args...); err != nil {
+		return errors.Wrap(err, "failed to add outbox message")
+	}
+
+	return nil
+}
+
+// TryLock takes the relay lock unless another relay holds it, and reports
+// whether this store holds it now. The lock lives on a dedicated connection,
+// so Postgres releases it if that connection or the process dies.
+func (s *Store) TryLock(ctx context.Context) (bool, error) {
+	if s.leader != nil {
+		if err := s.leader.Ping(ctx); err == nil {
+			return true, nil
+		}
+		s.leader.Release()
+		s.leader = nil
+	}
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to acquire connection")
+	}
+
+	var locked bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, relayLockKey).Scan(&locked); err != nil {
+		conn.Release()
+		return false, errors.Wrap(err, "failed to take relay lock")
+	}
+	if !locked {
+		conn.Release()
+		return false, nil
+	}
+
+	s.leader = conn
+	return true, nil
+}
+
+// Unlock releases the relay lock if this store holds it.
+func (s *Store) Unlock(ctx context.Context) error {
+	if s.leader == nil {
+		return nil
+	}
+	defer func() {
+		s.leader.Release()
+		s.leader = nil
+	}()
+
+	if _, err := s.leader.Exec(ctx, `SELECT pg_advisory_unlock($1)`, relayLockKey); err != nil {
+		return errors.Wrap(err, "failed to release relay lock")
+	}
+	return nil
+}
+
+// Process claims up to limit pending messages, passes them to fn in
+// creation order and marks the delivered ones as published. Messages are
+// claimed in a short transaction, so fn runs without holding row locks.
+// Process stops at the first failure so that later events for the same key
+// are not sent ahead of it; the failed message keeps its attempt count and
+// last error. That order only holds across batches if a single relay runs
+// per database, which the relay ensures with TryLock. A message that has
+// failed maxAttempts times is marked failed and no longer relayed, so it
+// cannot block the outbox forever.
+func (s *Store) Process(ctx context.Context, limit, maxAttempts int, fn func(ctx context.Context, msg Message) error) (int, error) {
+	messages, err := s.claim(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		published []string
+		failed    *Message
+		sendErr   error
+	)
+	for i := range messages {
+		if sendErr = fn(ctx, messages[i]); sendErr != nil {
+			failed = &messages[i]
+			break
+		}
+		published = append(published, messages[i].ID)
+	}
+
+	err = pgtx.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		if err := s.markPublished(ctx, published); err != nil {
+			return err
+		}
+		if failed == nil {
+			return nil
+		}
+		if err := s.markFailed(ctx, failed.ID, sendErr, failed.Attempts+1 >= maxAttempts); err != nil {
+			return err
+		}
+		return s.release(ctx, messages[len(published)+1:])
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(published), sendErr
+}
+
+// claim locks up to limit pending messages that no other relay has claimed
+// and claims them for claimTTL.
+func (s *Store) claim(ctx context.Context, limit int) ([]Message, error) {
+	var messages []Message
+
+	err := pgtx.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		var err error
+		messages, err = s.lockPending(ctx, limit)
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+
+		ids := make([]string, 0, len(messages))
+		for _, msg := range messages {
+			ids = append(ids, msg.ID)
+		}
+
+		query := squirrel.Update("outbox").
+			Set("claimed_until", time.Now().Add(claimTTL)).
+			Where(squirrel.Eq{"id": ids}).
+			PlaceholderFormat(squirrel.Dollar)
+
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return errors.Wrap(err, "failed to build query")
+		}
+
+		if _, err := pgtx.Conn(ctx, s.db).Exec(ctx, sql, args...); err != nil {
+			return errors.Wrap(err, "failed to claim outbox messages")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (s *Store) lockPending(ctx context.Context, limit int) ([]Message, error) {
+	query := squirrel.Select("id", "topic", "message_key", "payload", "attempts", "created_at").
+		From("outbox").
+		Where(squirrel.Eq{"published_at": nil, "failed_at": nil}).
+		Where(squirrel.Or{squirrel.Eq{"claimed_until": nil}, squirrel.Lt{"claimed_until": time.Now()}}).
+		OrderBy("created_at", "id").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	rows, err := pgtx.Conn(ctx, s.db).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch outbox messages")
+	}
+	defer rows.Close()
+
+	var messages []Message
+	for rows.Next() {
+		var m Message
+		if err := rows.Scan(&m.ID, &m.Topic, &m.Key, &m.Payload, &m.Attempts, &m.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan outbox message")
+		}
+		messages = append(messages, m)
+	}
+
+	return messages, rows.Err()
+}
+
+func (s *Store) markPublished(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := squirrel.Update("outbox").
+		Set("published_at", time.Now()).
+		Where(squirrel.Eq{"id": ids}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	if _, err := pgtx.Conn(ctx, s.db).Exec(ctx, sql, args...); err != nil {
+		return errors.Wrap(err, "failed to mark outbox messages published")
+	}
+
+	return nil
+}
+
+// markFailed records a failed attempt and releases the message for the next
+// batch, or gives up on it for good when exhausted is set.
+func (s *Store) markFailed(ctx context.Context, id string, cause error, exhausted bool) error {
+	query := squirrel.Update("outbox").
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("last_error", cause.Error()).
+		Set("claimed_until", nil).
+		Where(squirrel.Eq{"id": id}).
+		PlaceholderFormat(squirrel.Dollar)
+	if exhausted {
+		query = query.Set("failed_at", time.Now())
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	if _, err := pgtx.Conn(ctx, s.db).Exec(ctx, sql, args...); err != nil {
+		return errors.Wrap(err, "failed to mark outbox message failed")
+	}
+
+	return nil
+}
+
+// release drops the claim on messages that were not attempted, so that the
+// next batch sends them in order after the failed one.
+func (s *Store) release(ctx context.Context, messages []Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		ids = append(ids, msg.ID)
+	}
+
+	query := squirrel.Update("outbox").
+		Set("claimed_until", nil).
+		Where(squirrel.Eq{"id": ids}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	if _, err := pgtx.Conn(ctx, s.db).Exec(ctx, sql, args...); err != nil {
+		return errors.Wrap(err, "failed to release outbox messages")
+	}
+
+	return nil
+}
+
+// Pending returns the number of unpublished messages and the creation time
+// of the oldest one (zero when the outbox is drained).
+func (s *Store) Pending(ctx context.Context) (int, time.Time, error) {
+	query := squirrel.Select("COUNT(*)", "MIN(created_at)").
+		From("outbox").
+		Where(squirrel.Eq{"published_at": nil, "failed_at": nil}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, time.Time{}, errors.Wrap(err, "failed to build query")
+	}
+
+	var (
+		count  int
+		oldest *time.Time
+	)
+	if err := pgtx.Conn(ctx, s.db).QueryRow(ctx, sql, args...).Scan(&count, &oldest); err != nil {
+		return 0, time.Time{}, errors.Wrap(err, "failed to count outbox messages")
+	}
+
+	if oldest == nil {
+		return count, time.Time{}, nil
+	}
+	return count, *oldest, nil
+}
+
+// Failed returns the number of messages given up on after too many attempts.
+// They stay in the outbox until an operator requeues or deletes them.
+func (s *Store) Failed(ctx context.Context) (int, error) {
+	query := squirrel.Select("COUNT(*)").
+		From("outbox").
+		Where(squirrel.NotEq{"failed_at": nil}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to build query")
+	}
+
+	var count int
+	if err := pgtx.Conn(ctx, s.db).QueryRow(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, errors.Wrap(err, "failed to count failed outbox messages")
+	}
+
+	return count, nil
+}
+
+// DeletePublished removes messages published before the given time.
+func (s *Store) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	query := squirrel.Delete("outbox").
+		Where(squirrel.Lt{"published_at": before}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to build query")
+	}
+
+	tag, err := pgtx.Conn(ctx, s.db).Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete published outbox messages")
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,111 @@
+// Package session stores the refresh-token sessions the gateway issues. It
+// is shared with user-service, which ends a user's sessions when their
+// password changes.
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+var (
+	ErrNotFound = errors.New("session not found")
+	// ErrTokenReused means a refresh token that was already exchanged was
+	// presented again, which suggests it was stolen.
+	ErrTokenReused = errors.New("refresh token reused")
+)
+
+// createScript stores the session's token ID and adds the session to its
+// user's index, which lives as long as the user's newest session.
+var createScript = redis.NewScript(`
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[3])
+redis.call('SADD', KEYS[2], ARGV[2])
+if redis.call('PTTL', KEYS[2]) < tonumber(ARGV[3]) then
+	redis.call('PEXPIRE', KEYS[2], ARGV[3])
+end
+return 1
+`)
+
+// rotateScript swaps the session's token ID atomically: it returns 0 if the
+// session is gone, -1 if the presented token is not the current one and 1
+// once the new token ID is stored and the user's index extended.
+var rotateScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if not current then
+	return 0
+end
+if current ~= ARGV[1] then
+	return -1
+end
+redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+if redis.call('PTTL', KEYS[2]) < tonumber(ARGV[3]) then
+	redis.call('PEXPIRE', KEYS[2], ARGV[3])
+end
+return 1
+`)
+
+// deleteUserScript deletes every session in the user's index and the index.
+var deleteUserScript = redis.NewScript(`
+local ids = redis.call('SMEMBERS', KEYS[1])
+for _, id in ipairs(ids) do
+	redis.call('DEL', ARGV[1] .. id)
+end
+redis.call('DEL', KEYS[1])
+return #ids
+`)
+
+// RedisStore tracks the refresh token that is currently valid for each
+// login session, and the sessions of each user.
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) Create(ctx context.Context, sessionID, userID, tokenID string, ttl time.Duration) error {
+	return createScript.Run(ctx, s.client, []string{sessionKey(sessionID), userKey(userID)}, tokenID, sessionID, ttl.Milliseconds()).Err()
+}
+
+// Rotate replaces the session's current token ID with newTokenID if it is
+// still oldTokenID.
+func (s *RedisStore) Rotate(ctx context.Context, sessionID, userID, oldTokenID, newTokenID string, ttl time.Duration) error {
+	res, err := rotateScript.Run(ctx, s.client, []string{sessionKey(sessionID), userKey(userID)}, oldTokenID, newTokenID, ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+
+	switch res {
+	case 0:
+		return ErrNotFound
+	case -1:
+		return ErrTokenReused
+	default:
+		return nil
+	}
+}
+
+// Delete ends one session. Its ID stays in the user's index until the
+// index expires or DeleteUser runs; deleting it again is harmless.
+func (s *RedisStore) Delete(ctx context.Context, sessionID string) error {
+	return s.client.Del(ctx, sessionKey(sessionID)).Err()
+}
+
+// DeleteUser ends all of the user's sessions.
+func (s *RedisStore) DeleteUser(ctx context.Context, userID string) error {
+	return deleteUserScript.Run(ctx, s.client, []string{userKey(userID)}, sessionKeyPrefix).Err()
+}
+
+const sessionKeyPrefix = "session:"
+
+func sessionKey(sessionID string) string {
+	return sessionKeyPrefix + sessionID
+}
+
+func userKey(userID string) string {
+	return "user_sessions:" + userID
+}
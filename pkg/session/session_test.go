@@ -0,0 +1,71 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisStoreSuite struct {
+	suite.Suite
+	ctx   context.Context
+	mock  redismock.ClientMock
+	store *RedisStore
+}
+
+func (s *RedisStoreSuite) SetupTest() {
+	s.ctx = context.Background()
+	db, mock := redismock.NewClientMock()
+	s.mock = mock
+	s.store = NewRedisStore(db)
+}
+
+func (s *RedisStoreSuite) TearDownTest() {
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *RedisStoreSuite) TestCreate() {
+	s.mock.ExpectEvalSha(createScript.Hash(), []string{"session:s1", "user_sessions:u1"}, "t1", "s1", int64(60000)).SetVal(int64(1))
+
+	err := s.store.Create(s.ctx, "s1", "u1", "t1", time.Minute)
+
+	assert.NoError(s.T(), err)
+}
+
+func (s *RedisStoreSuite) TestRotate() {
+	tests := []struct {
+		name string
+		res  int64
+		want error
+	}{
+		{"rotated", 1, nil},
+		{"not found", 0, ErrNotFound},
+		{"reused", -1, ErrTokenReused},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.mock.ExpectEvalSha(rotateScript.Hash(), []string{"session:s1", "user_sessions:u1"}, "t1", "t2", int64(60000)).SetVal(tt.res)
+
+			err := s.store.Rotate(s.ctx, "s1", "u1", "t1", "t2", time.Minute)
+
+			assert.Equal(s.T(), tt.want, err)
+		})
+	}
+}
+
+func (s *RedisStoreSuite) TestDeleteUser() {
+	s.mock.ExpectEvalSha(deleteUserScript.Hash(), []string{"user_sessions:u1"}, "session:").SetVal(int64(2))
+
+	err := s.store.DeleteUser(s.ctx, "u1")
+
+	assert.NoError(s.T(), err)
+}
+
+func TestRedisStoreSuite(t *testing.T) {
+	suite.Run(t, new(RedisStoreSuite))
+}
@@ -5,11 +5,13 @@ import Tasks from './pages/Tasks'
 import Users from './pages/Users'
 import Teams from './pages/Teams'
 import Activities from './pages/Activities'
+import Login from './pages/Login'
 
 export default function App() {
   return (
     <BrowserRouter>
       <Routes>
+        <Route path="/login" element={<Login />} />
         <Route path="/" element={<Layout />}>
           <Route index element={<Dashboard />} />
           <Route path="tasks" element={<Tasks />} />
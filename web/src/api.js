@@ -1,17 +1,55 @@
 const API_BASE = '/api/v1'
+const TOKENS_KEY = 'taskflow.tokens'
 
-async function request(endpoint, options = {}) {
-  const url = `${API_BASE}${endpoint}`
-  const config = {
+export const session = {
+  get: () => JSON.parse(localStorage.getItem(TOKENS_KEY) || 'null'),
+  set: (tokens) => localStorage.setItem(TOKENS_KEY, JSON.stringify(tokens)),
+  clear: () => localStorage.removeItem(TOKENS_KEY),
+}
+
+async function send(endpoint, options) {
+  const tokens = session.get()
+  return fetch(`${API_BASE}${endpoint}`, {
+    ...options,
     headers: {
       'Content-Type': 'application/json',
+      ...(tokens ? { Authorization: `Bearer ${tokens.access_token}` } : {}),
+      ...options.headers,
     },
-    ...options,
+  })
+}
+
+// refreshTokens exchanges the stored refresh token for a new pair and
+// returns whether it succeeded.
+async function refreshTokens() {
+  const tokens = session.get()
+  if (!tokens) return false
+
+  const response = await fetch(`${API_BASE}/auth/refresh`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ refresh_token: tokens.refresh_token }),
+  })
+  if (!response.ok) {
+    session.clear()
+    return false
   }
+  session.set(await response.json())
+  return true
+}
 
+async function request(endpoint, options = {}) {
   try {
-    const response = await fetch(url, config)
-    const data = await response.json()
+    let response = await send(endpoint, options)
+    if (response.status === 401 && (await refreshTokens())) {
+      response = await send(endpoint, options)
+    }
+    if (response.status === 401) {
+      session.clear()
+      window.location.assign('/login')
+    }
+
+    const data = response.status === 204 ? {} : await response.json()
     
     if (!response.ok) {
       throw new Error(data.error || 'Request failed')
@@ -25,6 +63,32 @@ async function request(endpoint, options = {}) {
 }
 
 export const api = {
+  auth: {
+    login: async (data) => {
+      const response = await fetch(`${API_BASE}/auth/login`, {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify(data),
+      })
+      const body = await response.json()
+      if (!response.ok) {
+        throw new Error(body.error || 'Login failed')
+      }
+      session.set(body)
+    },
+    logout: async () => {
+      const tokens = session.get()
+      session.clear()
+      if (tokens) {
+        await fetch(`${API_BASE}/auth/logout`, {
+          method: 'POST',
+          headers: { 'Content-Type': 'application/json' },
+          body: JSON.stringify({ refresh_token: tokens.refresh_token }),
+        })
+      }
+    },
+  },
+
   users: {
     list: () => request('/users'),
     get: (id) => request(`/users/${id}`),
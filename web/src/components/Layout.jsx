@@ -1,4 +1,5 @@
-import { Outlet, NavLink } from 'react-router-dom'
+import { Outlet, NavLink, Navigate, useNavigate } from 'react-router-dom'
+import { api, session } from '../api'
 
 const navItems = [
   { to: '/', icon: HomeIcon, label: 'Dashboard' },
@@ -9,6 +10,17 @@ const navItems = [
 ]
 
 export default function Layout() {
+  const navigate = useNavigate()
+
+  if (!session.get()) {
+    return <Navigate to="/login" replace />
+  }
+
+  async function handleLogout() {
+    await api.auth.logout()
+    navigate('/login', { replace: true })
+  }
+
   return (
     <div className="min-h-screen flex">
       <aside className="w-64 bg-dark-900 border-r border-dark-800 p-4 flex flex-col">
@@ -48,6 +60,9 @@ export default function Layout() {
               <p className="text-sm font-medium text-dark-200">TaskFlow</p>
               <p className="text-xs text-dark-500">v1.0.0</p>
             </div>
+            <button onClick={handleLogout} className="ml-auto text-sm text-dark-400 hover:text-dark-200">
+              Sign out
+            </button>
           </div>
         </div>
       </aside>
@@ -0,0 +1,92 @@
+import { useState } from 'react'
+import { Navigate, useNavigate } from 'react-router-dom'
+import { api, session } from '../api'
+
+export default function Login() {
+  const navigate = useNavigate()
+  const [isSignUp, setIsSignUp] = useState(false)
+  const [formData, setFormData] = useState({ email: '', name: '', password: '' })
+  const [error, setError] = useState('')
+
+  if (session.get()) {
+    return <Navigate to="/" replace />
+  }
+
+  async function handleSubmit(e) {
+    e.preventDefault()
+    setError('')
+    try {
+      if (isSignUp) {
+        await api.users.create(formData)
+      }
+      await api.auth.login({ email: formData.email, password: formData.password })
+      navigate('/', { replace: true })
+    } catch (err) {
+      setError(err.message)
+    }
+  }
+
+  return (
+    <div className="min-h-screen flex items-center justify-center p-4">
+      <div className="card w-full max-w-sm">
+        <h1 className="text-2xl font-bold text-white mb-6">
+          {isSignUp ? 'Create account' : 'Sign in to TaskFlow'}
+        </h1>
+
+        <form onSubmit={handleSubmit} className="space-y-4">
+          {isSignUp && (
+            <div>
+              <label className="block text-sm font-medium text-dark-300 mb-1">Name</label>
+              <input
+                type="text"
+                value={formData.name}
+                onChange={(e) => setFormData({ ...formData, name: e.target.value })}
+                className="input"
+                placeholder="John Doe"
+                required
+              />
+            </div>
+          )}
+
+          <div>
+            <label className="block text-sm font-medium text-dark-300 mb-1">Email</label>
+            <input
+              type="email"
+              value={formData.email}
+              onChange={(e) => setFormData({ ...formData, email: e.target.value })}
+              className="input"
+              placeholder="john@example.com"
+              required
+            />
+          </div>
+
+          <div>
+            <label className="block text-sm font-medium text-dark-300 mb-1">Password</label>
+            <input
+              type="password"
+              value={formData.password}
+              onChange={(e) => setFormData({ ...formData, password: e.target.value })}
+              className="input"
+              minLength={isSignUp ? 8 : undefined}
+              required
+            />
+          </div>
+
+          {error && <p className="text-sm text-red-400">{error}</p>}
+
+          <button type="submit" className="btn btn-primary w-full">
+            {isSignUp ? 'Sign up' : 'Sign in'}
+          </button>
+        </form>
+
+        <button
+          type="button"
+          onClick={() => setIsSignUp(!isSignUp)}
+          className="mt-4 text-sm text-dark-400 hover:text-dark-200"
+        >
+          {isSignUp ? 'Already have an account? Sign in' : 'No account yet? Sign up'}
+        </button>
+      </div>
+    </div>
+  )
+}
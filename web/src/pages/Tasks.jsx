@@ -15,7 +15,6 @@ export default function Tasks() {
     title: '',
     description: '',
     priority: 'medium',
-    creator_id: '',
     assignee_id: '',
     team_id: '',
   })
@@ -53,7 +52,7 @@ export default function Tasks() {
     try {
       await api.tasks.create(formData)
       setIsModalOpen(false)
-      setFormData({ title: '', description: '', priority: 'medium', creator_id: '', assignee_id: '', team_id: '' })
+      setFormData({ title: '', description: '', priority: 'medium', assignee_id: '', team_id: '' })
       loadTasks()
     } catch (error) {
       console.error('Failed to create task:', error)
@@ -203,27 +202,6 @@ export default function Tasks() {
             </select>
           </div>
           
-          <div>
-            <label className="block text-sm font-medium text-dark-300 mb-1">Creator</label>
-            {users.length > 0 ? (
-              <select
-                value={formData.creator_id}
-                onChange={(e) => setFormData({ ...formData, creator_id: e.target.value })}
-                className="input"
-                required
-              >
-                <option value="">Select creator...</option>
-                {users.map(user => (
-                  <option key={user.id} value={user.id}>
-                    {user.name} ({user.email})
-                  </option>
-                ))}
-              </select>
-            ) : (
-              <p className="text-dark-400 text-sm">No users available. Create a user first.</p>
-            )}
-          </div>
-
           <div>
             <label className="block text-sm font-medium text-dark-300 mb-1">Assignee (optional)</label>
             <select
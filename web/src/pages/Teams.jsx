@@ -11,7 +11,7 @@ export default function Teams() {
   const [isAddMemberModalOpen, setIsAddMemberModalOpen] = useState(false)
   const [selectedTeam, setSelectedTeam] = useState(null)
   const [members, setMembers] = useState([])
-  const [formData, setFormData] = useState({ name: '', owner_id: '' })
+  const [formData, setFormData] = useState({ name: '' })
   const [memberFormData, setMemberFormData] = useState({ user_id: '', role: 'member' })
 
   useEffect(() => {
@@ -46,7 +46,7 @@ export default function Teams() {
     try {
       await api.teams.create(formData)
       setIsModalOpen(false)
-      setFormData({ name: '', owner_id: '' })
+      setFormData({ name: '' })
       loadTeams()
     } catch (error) {
       console.error('Failed to create team:', error)
@@ -160,27 +160,6 @@ export default function Teams() {
             />
           </div>
           
-          <div>
-            <label className="block text-sm font-medium text-dark-300 mb-1">Owner</label>
-            {users.length > 0 ? (
-              <select
-                value={formData.owner_id}
-                onChange={(e) => setFormData({ ...formData, owner_id: e.target.value })}
-                className="input"
-                required
-              >
-                <option value="">Select owner...</option>
-                {users.map(user => (
-                  <option key={user.id} value={user.id}>
-                    {user.name} ({user.email})
-                  </option>
-                ))}
-              </select>
-            ) : (
-              <p className="text-dark-400 text-sm">No users available. Create a user first.</p>
-            )}
-          </div>
-          
           <div className="flex gap-3 pt-2">
             <button type="button" onClick={() => setIsModalOpen(false)} className="btn btn-secondary flex-1">
               Cancel
@@ -6,7 +6,7 @@ export default function Users() {
   const [users, setUsers] = useState([])
   const [loading, setLoading] = useState(true)
   const [isModalOpen, setIsModalOpen] = useState(false)
-  const [formData, setFormData] = useState({ email: '', name: '' })
+  const [formData, setFormData] = useState({ email: '', name: '', password: '' })
 
   useEffect(() => {
     loadUsers()
@@ -30,7 +30,7 @@ export default function Users() {
     try {
       await api.users.create(formData)
       setIsModalOpen(false)
-      setFormData({ email: '', name: '' })
+      setFormData({ email: '', name: '', password: '' })
       loadUsers()
     } catch (error) {
       console.error('Failed to create user:', error)
@@ -114,6 +114,18 @@ export default function Users() {
               required
             />
           </div>
+
+          <div>
+            <label className="block text-sm font-medium text-dark-300 mb-1">Password</label>
+            <input
+              type="password"
+              value={formData.password}
+              onChange={(e) => setFormData({ ...formData, password: e.target.value })}
+              className="input"
+              minLength={8}
+              required
+            />
+          </div>
           
           <div className="flex gap-3 pt-2">
             <button type="button" onClick={() => setIsModalOpen(false)} className="btn btn-secondary flex-1">